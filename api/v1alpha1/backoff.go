@@ -0,0 +1,33 @@
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// RetryBackoff records consecutive failures to sync a resource with Better Stack. Kept in
+// status, it lets the retry delay keep growing across operator restarts instead of every
+// failing resource retrying at once after a crash loop.
+type RetryBackoff struct {
+	// Failures counts the consecutive failed syncs of Generation.
+	Failures int `json:"failures"`
+
+	// Generation is the spec generation that failed. A new generation starts over.
+	Generation int64 `json:"generation"`
+
+	// NextRetryTime is when the next sync is attempted.
+	NextRetryTime metav1.Time `json:"nextRetryTime"`
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *RetryBackoff) DeepCopyInto(out *RetryBackoff) {
+	*out = *in
+	in.NextRetryTime.DeepCopyInto(&out.NextRetryTime)
+}
+
+// DeepCopy creates a new copy of the receiver.
+func (in *RetryBackoff) DeepCopy() *RetryBackoff {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryBackoff)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,185 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// BetterStackAlertRouteSpec assigns an escalation policy and contact settings to the
+// BetterStackMonitors in its namespace that match a label selector.
+type BetterStackAlertRouteSpec struct {
+	// Selector matches the labels of BetterStackMonitors in the route's namespace. An empty
+	// selector matches every monitor.
+	// +kubebuilder:validation:Required
+	Selector metav1.LabelSelector `json:"selector"`
+
+	// Priority decides which route applies when several select the same monitor: the
+	// highest wins, and routes of equal priority are ordered by name.
+	Priority int `json:"priority,omitempty"`
+
+	// PolicyID is the Better Stack escalation policy assigned to the selected monitors.
+	PolicyID string `json:"policyID,omitempty"`
+
+	// Contact settings assigned to the selected monitors.
+	Email         *bool `json:"email,omitempty"`
+	SMS           *bool `json:"sms,omitempty"`
+	Call          *bool `json:"call,omitempty"`
+	Push          *bool `json:"push,omitempty"`
+	CriticalAlert *bool `json:"criticalAlert,omitempty"`
+}
+
+// BetterStackAlertRouteStatus reports which monitors the route applies to.
+type BetterStackAlertRouteStatus struct {
+	// ObservedGeneration reflects the spec generation the controller last processed.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Monitors lists the names of the monitors the route applies to. Monitors selected by
+	// a route of higher priority are not included.
+	Monitors []string `json:"monitors,omitempty"`
+
+	// MonitorCount is the length of Monitors.
+	MonitorCount int `json:"monitorCount,omitempty"`
+
+	// Conditions capture whether the route's selector is valid.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories=betterstack,scope=Namespaced
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Policy",type=string,JSONPath=".spec.policyID"
+// +kubebuilder:printcolumn:name="Monitors",type=integer,JSONPath=".status.monitorCount"
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+
+// BetterStackAlertRoute routes the alerts of labelled monitors to a Better Stack escalation
+// policy.
+type BetterStackAlertRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec   BetterStackAlertRouteSpec   `json:"spec"`
+	Status BetterStackAlertRouteStatus `json:"status"`
+}
+
+// +kubebuilder:object:root=true
+
+// BetterStackAlertRouteList contains a list of BetterStackAlertRoute.
+type BetterStackAlertRouteList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []BetterStackAlertRoute `json:"items"`
+}
+
+func (in *BetterStackAlertRouteSpec) DeepCopyInto(out *BetterStackAlertRouteSpec) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	if in.Email != nil {
+		out.Email = new(bool)
+		*out.Email = *in.Email
+	}
+	if in.SMS != nil {
+		out.SMS = new(bool)
+		*out.SMS = *in.SMS
+	}
+	if in.Call != nil {
+		out.Call = new(bool)
+		*out.Call = *in.Call
+	}
+	if in.Push != nil {
+		out.Push = new(bool)
+		*out.Push = *in.Push
+	}
+	if in.CriticalAlert != nil {
+		out.CriticalAlert = new(bool)
+		*out.CriticalAlert = *in.CriticalAlert
+	}
+}
+
+func (in *BetterStackAlertRouteSpec) DeepCopy() *BetterStackAlertRouteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BetterStackAlertRouteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *BetterStackAlertRouteStatus) DeepCopyInto(out *BetterStackAlertRouteStatus) {
+	*out = *in
+	if in.Monitors != nil {
+		out.Monitors = make([]string, len(in.Monitors))
+		copy(out.Monitors, in.Monitors)
+	}
+	if in.Conditions != nil {
+		out.Conditions = make([]metav1.Condition, len(in.Conditions))
+		for i := range in.Conditions {
+			in.Conditions[i].DeepCopyInto(&out.Conditions[i])
+		}
+	}
+}
+
+func (in *BetterStackAlertRouteStatus) DeepCopy() *BetterStackAlertRouteStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BetterStackAlertRouteStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *BetterStackAlertRoute) DeepCopyInto(out *BetterStackAlertRoute) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+func (in *BetterStackAlertRoute) DeepCopy() *BetterStackAlertRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(BetterStackAlertRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *BetterStackAlertRoute) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+func (in *BetterStackAlertRouteList) DeepCopyInto(out *BetterStackAlertRouteList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]BetterStackAlertRoute, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+func (in *BetterStackAlertRouteList) DeepCopy() *BetterStackAlertRouteList {
+	if in == nil {
+		return nil
+	}
+	out := new(BetterStackAlertRouteList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *BetterStackAlertRouteList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// SetCondition updates a condition on the status, creating or replacing it.
+func (s *BetterStackAlertRouteStatus) SetCondition(cond metav1.Condition) {
+	s.Conditions = setCondition(s.Conditions, cond)
+}
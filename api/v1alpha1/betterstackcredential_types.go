@@ -0,0 +1,121 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// BetterStackCredentialSpec describes a Better Stack account that other resources can select via accountRef.
+type BetterStackCredentialSpec struct {
+	// APITokenSecretRef references the secret containing the account's Better Stack API token.
+	// +kubebuilder:validation:Required
+	APITokenSecretRef corev1.SecretKeySelector `json:"apiTokenSecretRef"`
+
+	// Better Stack API base URL. Defaults to https://uptime.betterstack.com/api/v2 when omitted.
+	// Resources may still override it through their own baseURL.
+	// +kubebuilder:validation:Format=uri
+	BaseURL string `json:"baseURL,omitempty"`
+
+	// RequestsPerSecond caps the API request rate shared by every resource using this account.
+	// Zero disables operator-side rate limiting.
+	// +kubebuilder:validation:Minimum=0
+	RequestsPerSecond int `json:"requestsPerSecond,omitempty"`
+
+	// Burst allows short spikes above RequestsPerSecond. Defaults to RequestsPerSecond when omitted.
+	// +kubebuilder:validation:Minimum=0
+	Burst int `json:"burst,omitempty"`
+
+	// Quota records the account's plan limits. Better Stack does not report them through the
+	// API, so they are only used to export quota metrics next to the usage the operator
+	// observes.
+	Quota BetterStackQuota `json:"quota,omitempty"`
+}
+
+// BetterStackQuota holds the plan limits of a Better Stack account. Zero means unknown.
+type BetterStackQuota struct {
+	// Monitors is the number of monitors the plan allows.
+	// +kubebuilder:validation:Minimum=0
+	Monitors int `json:"monitors,omitempty"`
+
+	// Heartbeats is the number of heartbeats the plan allows.
+	// +kubebuilder:validation:Minimum=0
+	Heartbeats int `json:"heartbeats,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories=betterstack,scope=Namespaced
+
+// BetterStackCredential is the Schema for the betterstackcredentials API.
+type BetterStackCredential struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec BetterStackCredentialSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// BetterStackCredentialList contains a list of BetterStackCredential.
+type BetterStackCredentialList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []BetterStackCredential `json:"items"`
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *BetterStackCredential) DeepCopyInto(out *BetterStackCredential) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
+
+// DeepCopy creates a new copy of the receiver.
+func (in *BetterStackCredential) DeepCopy() *BetterStackCredential {
+	if in == nil {
+		return nil
+	}
+	out := new(BetterStackCredential)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject satisfies the runtime.Object interface.
+func (in *BetterStackCredential) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *BetterStackCredentialList) DeepCopyInto(out *BetterStackCredentialList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]BetterStackCredential, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy creates a new copy of the receiver.
+func (in *BetterStackCredentialList) DeepCopy() *BetterStackCredentialList {
+	if in == nil {
+		return nil
+	}
+	out := new(BetterStackCredentialList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject satisfies the runtime.Object interface.
+func (in *BetterStackCredentialList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
@@ -8,7 +8,8 @@ import (
 
 // BetterStackHeartbeatSpec defines the desired state of a Better Stack heartbeat.
 type BetterStackHeartbeatSpec struct {
-	// Name is the human readable display name for the heartbeat.
+	// Name is the human readable display name for the heartbeat. $(CLUSTER_NAME) is replaced
+	// with the operator's --cluster-name.
 	// +kubebuilder:validation:MinLength=1
 	Name string `json:"name"`
 
@@ -20,6 +21,14 @@ type BetterStackHeartbeatSpec struct {
 	// +kubebuilder:validation:Minimum=0
 	GraceSeconds int `json:"graceSeconds,omitempty"`
 
+	// PeriodChangePolicy controls how a shorter periodSeconds is rolled out. Immediate
+	// applies it at once. GraceThenApply first widens the grace so Better Stack keeps the
+	// old deadline for one old period, giving clients time to adopt the new cadence, then
+	// applies graceSeconds.
+	// +kubebuilder:validation:Enum=Immediate;GraceThenApply
+	// +kubebuilder:default=Immediate
+	PeriodChangePolicy string `json:"periodChangePolicy,omitempty"`
+
 	// TeamName assigns the heartbeat to a specific Better Stack team (needed when using a global token).
 	TeamName string `json:"teamName,omitempty"`
 
@@ -45,14 +54,21 @@ type BetterStackHeartbeatSpec struct {
 	// Paused marks the heartbeat as paused in Better Stack.
 	Paused *bool `json:"paused,omitempty"`
 
-	// Maintenance windows.
-	// +kubebuilder:validation:Items={type=string,enum={mon,tue,wed,thu,fri,sat,sun}}
+	// PausedUntil pauses the heartbeat until the given time, after which the operator
+	// restores the paused state above.
+	PausedUntil *metav1.Time `json:"pausedUntil,omitempty"`
+
+	// Maintenance windows. Days are abbreviations such as mon or full names such as
+	// Monday; the operator sends them in the lowercase abbreviated form Better Stack expects.
+	// +kubebuilder:validation:Items={type=string,pattern=`^(?i:mon(day)?|tue(sday)?|wed(nesday)?|thu(rsday)?|fri(day)?|sat(urday)?|sun(day)?)$`}
 	MaintenanceDays     []string `json:"maintenanceDays,omitempty"`
 	MaintenanceFrom     string   `json:"maintenanceFrom,omitempty"`
 	MaintenanceTo       string   `json:"maintenanceTo,omitempty"`
 	MaintenanceTimezone string   `json:"maintenanceTimezone,omitempty"`
 
-	// PolicyID controls the alerting policy Better Stack applies.
+	// PolicyID controls the alerting policy Better Stack applies. It is the policy's
+	// Better Stack ID; the API reference documents no policy lookup, so policies cannot
+	// be referenced by name.
 	PolicyID *string `json:"policyID,omitempty"`
 
 	// Better Stack API base URL. Defaults to https://uptime.betterstack.com/api/v2 when omitted.
@@ -62,6 +78,67 @@ type BetterStackHeartbeatSpec struct {
 	// APITokenSecretRef references the secret containing the Better Stack API token.
 	// +kubebuilder:validation:Required
 	APITokenSecretRef corev1.SecretKeySelector `json:"apiTokenSecretRef"`
+
+	// AccountRef selects a BetterStackCredential, by default in the same namespace. When set
+	// it takes precedence over apiTokenSecretRef and supplies the token, default base URL and rate limit.
+	AccountRef *ResourceRef `json:"accountRef,omitempty"`
+
+	// Suspend stops the operator from reconciling this heartbeat while leaving the remote
+	// heartbeat untouched. Unlike paused, Better Stack keeps running its checks.
+	Suspend bool `json:"suspend,omitempty"`
+
+	// VerifyPings makes the operator re-check the heartbeat every period and report a
+	// PingsMissing condition while Better Stack considers it down, so Kubernetes alerting
+	// does not depend solely on Better Stack notification channels.
+	VerifyPings bool `json:"verifyPings,omitempty"`
+
+	// NameConflictStrategy decides what happens when Better Stack refuses to create the
+	// heartbeat because its name is taken. Fail reports a NameConflict; Suffix retries with
+	// the name followed by a hash of the namespace and records it in status.remoteName.
+	// +kubebuilder:validation:Enum=Fail;Suffix
+	// +kubebuilder:default=Fail
+	NameConflictStrategy string `json:"nameConflictStrategy,omitempty"`
+
+	// URLAnnotation writes the heartbeat's ping URL into an annotation on a workload in the
+	// same namespace, typically the CronJob that sends the pings.
+	URLAnnotation *HeartbeatURLAnnotation `json:"urlAnnotation,omitempty"`
+}
+
+// HeartbeatURLAnnotation selects the object that receives the heartbeat URL annotation.
+type HeartbeatURLAnnotation struct {
+	// Kind of the target object.
+	// +kubebuilder:validation:Enum=CronJob;Deployment;StatefulSet;DaemonSet
+	Kind string `json:"kind"`
+
+	// Name of the target object in the heartbeat's namespace.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Key is the annotation key. It must start with betterstack.io/, so the operator
+	// cannot be used to overwrite annotations other controllers rely on. Defaults to
+	// betterstack.io/heartbeat-url.
+	// +kubebuilder:validation:Pattern=`^betterstack\.io/[A-Za-z0-9]([-A-Za-z0-9_.]{0,61}[A-Za-z0-9])?$`
+	Key string `json:"key,omitempty"`
+
+	// PodTemplate also writes the annotation into the pod template, so containers can read
+	// the URL through a downward API fieldRef. Changing the URL then rolls the workload.
+	PodTemplate bool `json:"podTemplate,omitempty"`
+}
+
+// HeartbeatPeriodTransition records a shortened period being rolled out under
+// periodChangePolicy GraceThenApply.
+type HeartbeatPeriodTransition struct {
+	// FromPeriodSeconds is the period Better Stack applied before the change.
+	FromPeriodSeconds int `json:"fromPeriodSeconds"`
+
+	// ToPeriodSeconds is the new period, already sent to Better Stack.
+	ToPeriodSeconds int `json:"toPeriodSeconds"`
+
+	// GraceSeconds is the widened grace sent until the transition ends.
+	GraceSeconds int `json:"graceSeconds"`
+
+	// Until is when spec.graceSeconds is applied again.
+	Until metav1.Time `json:"until"`
 }
 
 // BetterStackHeartbeatStatus represents the observed state of the heartbeat.
@@ -69,6 +146,33 @@ type BetterStackHeartbeatStatus struct {
 	// HeartbeatID is the identifier assigned by Better Stack.
 	HeartbeatID string `json:"heartbeatID,omitempty"`
 
+	// TeamName is the Better Stack team the heartbeat belongs to, as reported by the API.
+	TeamName string `json:"teamName,omitempty"`
+
+	// ClusterName is the --cluster-name of the operator that last synchronized the heartbeat.
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// BaseURL is the Better Stack API endpoint of the last successful sync.
+	BaseURL string `json:"baseURL,omitempty"`
+
+	// RemoteName is the name used in Better Stack when spec.nameConflictStrategy Suffix
+	// had to change it. Empty when the heartbeat uses spec.name.
+	RemoteName string `json:"remoteName,omitempty"`
+
+	// AppliedPeriodSeconds is the period last written to Better Stack.
+	AppliedPeriodSeconds int `json:"appliedPeriodSeconds,omitempty"`
+
+	// PeriodTransition is set while a shortened period is being rolled out.
+	PeriodTransition *HeartbeatPeriodTransition `json:"periodTransition,omitempty"`
+
+	// ResumedPausedUntil is the expired spec.pausedUntil the operator last resumed the
+	// heartbeat for. The resume is sent once; later syncs leave paused to Better Stack.
+	ResumedPausedUntil *metav1.Time `json:"resumedPausedUntil,omitempty"`
+
+	// URLAnnotation is the object and key the heartbeat URL was last written to, with the
+	// key defaulted. The annotation is removed from it when spec.urlAnnotation changes.
+	URLAnnotation *HeartbeatURLAnnotation `json:"urlAnnotation,omitempty"`
+
 	// ObservedGeneration reflects the spec generation the controller last processed.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 
@@ -77,24 +181,19 @@ type BetterStackHeartbeatStatus struct {
 
 	// LastSyncedTime records when the operator last reconciled successfully.
 	LastSyncedTime *metav1.Time `json:"lastSyncedTime,omitempty"`
+
+	// Backoff tracks consecutive sync failures; it is cleared by the next successful sync.
+	Backoff *RetryBackoff `json:"backoff,omitempty"`
+
+	// DebugTrace holds the most recent reconcile steps while the betterstack.io/debug
+	// annotation is "true".
+	// +kubebuilder:validation:MaxItems=30
+	DebugTrace []ReconcileStep `json:"debugTrace,omitempty"`
 }
 
 // SetCondition updates a condition on the status, creating or replacing it.
 func (s *BetterStackHeartbeatStatus) SetCondition(cond metav1.Condition) {
-	var conditions []metav1.Condition
-	replaced := false
-	for _, existing := range s.Conditions {
-		if existing.Type == cond.Type {
-			conditions = append(conditions, cond)
-			replaced = true
-			continue
-		}
-		conditions = append(conditions, existing)
-	}
-	if !replaced {
-		conditions = append(conditions, cond)
-	}
-	s.Conditions = conditions
+	s.Conditions = setCondition(s.Conditions, cond)
 }
 
 // +kubebuilder:object:root=true
@@ -126,6 +225,15 @@ func (in *BetterStackHeartbeatSpec) DeepCopyInto(out *BetterStackHeartbeatSpec)
 		out.MaintenanceDays = make([]string, len(in.MaintenanceDays))
 		copy(out.MaintenanceDays, in.MaintenanceDays)
 	}
+	if in.PausedUntil != nil {
+		out.PausedUntil = in.PausedUntil.DeepCopy()
+	}
+	if in.AccountRef != nil {
+		out.AccountRef = in.AccountRef.DeepCopy()
+	}
+	if in.URLAnnotation != nil {
+		out.URLAnnotation = in.URLAnnotation.DeepCopy()
+	}
 }
 
 // DeepCopy creates a new copy of the receiver.
@@ -138,9 +246,49 @@ func (in *BetterStackHeartbeatSpec) DeepCopy() *BetterStackHeartbeatSpec {
 	return out
 }
 
+// DeepCopyInto copies the receiver into out.
+func (in *HeartbeatURLAnnotation) DeepCopyInto(out *HeartbeatURLAnnotation) {
+	*out = *in
+}
+
+// DeepCopy creates a new copy of the receiver.
+func (in *HeartbeatURLAnnotation) DeepCopy() *HeartbeatURLAnnotation {
+	if in == nil {
+		return nil
+	}
+	out := new(HeartbeatURLAnnotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *HeartbeatPeriodTransition) DeepCopyInto(out *HeartbeatPeriodTransition) {
+	*out = *in
+	in.Until.DeepCopyInto(&out.Until)
+}
+
+// DeepCopy creates a new copy of the receiver.
+func (in *HeartbeatPeriodTransition) DeepCopy() *HeartbeatPeriodTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(HeartbeatPeriodTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto copies the receiver into out.
 func (in *BetterStackHeartbeatStatus) DeepCopyInto(out *BetterStackHeartbeatStatus) {
 	*out = *in
+	if in.PeriodTransition != nil {
+		out.PeriodTransition = in.PeriodTransition.DeepCopy()
+	}
+	if in.URLAnnotation != nil {
+		out.URLAnnotation = in.URLAnnotation.DeepCopy()
+	}
+	if in.ResumedPausedUntil != nil {
+		out.ResumedPausedUntil = in.ResumedPausedUntil.DeepCopy()
+	}
 	if in.Conditions != nil {
 		out.Conditions = make([]metav1.Condition, len(in.Conditions))
 		for i := range in.Conditions {
@@ -150,6 +298,15 @@ func (in *BetterStackHeartbeatStatus) DeepCopyInto(out *BetterStackHeartbeatStat
 	if in.LastSyncedTime != nil {
 		out.LastSyncedTime = in.LastSyncedTime.DeepCopy()
 	}
+	if in.Backoff != nil {
+		out.Backoff = in.Backoff.DeepCopy()
+	}
+	if in.DebugTrace != nil {
+		out.DebugTrace = make([]ReconcileStep, len(in.DebugTrace))
+		for i := range in.DebugTrace {
+			in.DebugTrace[i].DeepCopyInto(&out.DebugTrace[i])
+		}
+	}
 }
 
 // DeepCopy creates a new copy of the receiver.
@@ -14,7 +14,8 @@ type BetterStackMonitorSpec struct {
 	// +kubebuilder:validation:MinLength=1
 	URL string `json:"url"`
 
-	// Name is the human readable display name for the monitor.
+	// Name is the human readable display name for the monitor. $(CLUSTER_NAME) is replaced
+	// with the operator's --cluster-name.
 	Name string `json:"name,omitempty"`
 
 	// MonitorType controls the Better Stack monitor type (status, expected_status_code, keyword, keyword_absence, ping, tcp, udp, smtp, pop, imap, dns, playwright).
@@ -29,14 +30,25 @@ type BetterStackMonitorSpec struct {
 	// +kubebuilder:validation:Minimum=1
 	CheckFrequencyMinutes int `json:"checkFrequencyMinutes,omitempty"`
 
-	// Regions specifies the Better Stack regions to probe from.
+	// Regions specifies the Better Stack regions to probe from. Which regions are
+	// available depends on the plan; unavailable ones are reported as RegionUnavailable.
+	// +kubebuilder:validation:Items={type=string,enum={us,eu,as,au}}
 	Regions []string `json:"regions,omitempty"`
 
+	// Tags label the monitor in Better Stack. Accounts without tag support reject the
+	// attribute; the operator then syncs the monitor without tags and says so in the Synced
+	// condition, so manifests can carry tags ahead of the feature being enabled.
+	// +kubebuilder:validation:MaxItems=50
+	// +kubebuilder:validation:Items={type=string,minLength=1,maxLength=100}
+	Tags []string `json:"tags,omitempty"`
+
 	// RequestMethod overrides the HTTP method used during the check (for example GET or POST).
-	// +kubebuilder:validation:Enum=get;post;put;patch;delete;head;options;trace
+	// Any case is accepted; the operator sends it lowercased.
+	// +kubebuilder:validation:Pattern=`^(?i:get|post|put|patch|delete|head|options|trace)$`
 	RequestMethod string `json:"requestMethod,omitempty"`
 
 	// ExpectedStatusCode sets a single expected HTTP status code treated as success.
+	// Deprecated: use ExpectedStatusCodes. It is ignored when ExpectedStatusCodes is set.
 	// +kubebuilder:validation:Minimum=100
 	// +kubebuilder:validation:Maximum=599
 	ExpectedStatusCode int `json:"expectedStatusCode,omitempty"`
@@ -51,6 +63,10 @@ type BetterStackMonitorSpec struct {
 	// Paused marks the monitor as paused in Better Stack.
 	Paused bool `json:"paused,omitempty"`
 
+	// PausedUntil pauses the monitor until the given time, after which the operator
+	// restores the paused state above.
+	PausedUntil *metav1.Time `json:"pausedUntil,omitempty"`
+
 	// Contact preference overrides.
 	Email           *bool `json:"email,omitempty"`
 	SMS             *bool `json:"sms,omitempty"`
@@ -88,21 +104,74 @@ type BetterStackMonitorSpec struct {
 	// +kubebuilder:validation:Enum=ipv4;ipv6
 	IPVersion string `json:"ipVersion,omitempty"`
 
-	// +kubebuilder:validation:Items={type=string,enum={mon,tue,wed,thu,fri,sat,sun}}
+	// Maintenance days are abbreviations such as mon or full names such as Monday; the
+	// operator sends them in the lowercase abbreviated form Better Stack expects.
+	// +kubebuilder:validation:Items={type=string,pattern=`^(?i:mon(day)?|tue(sday)?|wed(nesday)?|thu(rsday)?|fri(day)?|sat(urday)?|sun(day)?)$`}
 	MaintenanceDays     []string `json:"maintenanceDays,omitempty"`
 	MaintenanceFrom     string   `json:"maintenanceFrom,omitempty"`
 	MaintenanceTo       string   `json:"maintenanceTo,omitempty"`
 	MaintenanceTimezone string   `json:"maintenanceTimezone,omitempty"`
 
-	RequestHeaders       []BetterStackHeader `json:"requestHeaders,omitempty"`
-	RequestBody          string              `json:"requestBody,omitempty"`
-	AuthUsername         string              `json:"authUsername,omitempty"`
-	AuthPassword         string              `json:"authPassword,omitempty"`
-	EnvironmentVariables map[string]string   `json:"environmentVariables,omitempty"`
-	PlaywrightScript     string              `json:"playwrightScript,omitempty"`
-	ScenarioName         string              `json:"scenarioName,omitempty"`
-
-	// AdditionalAttributes are raw Better Stack API attributes merged into the payload.
+	// OneTimeMaintenance pauses the monitor once, for a single window, in addition to the
+	// recurring maintenance days above.
+	OneTimeMaintenance *BetterStackMaintenanceWindow `json:"oneTimeMaintenance,omitempty"`
+
+	RequestHeaders []BetterStackHeader `json:"requestHeaders,omitempty"`
+	RequestBody    string              `json:"requestBody,omitempty"`
+	AuthUsername   string              `json:"authUsername,omitempty"`
+	AuthPassword   string              `json:"authPassword,omitempty"`
+	// EnvironmentVariables are passed to the Playwright scenario. At most 64 entries of up
+	// to 4096 characters each.
+	// +kubebuilder:validation:MaxProperties=64
+	// +kubebuilder:validation:XValidation:rule="self.all(k, size(self[k]) <= 4096)",message="values must be at most 4096 characters"
+	EnvironmentVariables map[string]string `json:"environmentVariables,omitempty"`
+	PlaywrightScript     string            `json:"playwrightScript,omitempty"`
+	ScenarioName         string            `json:"scenarioName,omitempty"`
+	// PlaywrightTimeoutSeconds bounds how long a Playwright scenario may run. It is sent
+	// as the request timeout and takes precedence over requestTimeoutSeconds. Viewport and
+	// device emulation are not API attributes; configure them in the script itself.
+	// +kubebuilder:validation:Enum=15;30;45;60
+	PlaywrightTimeoutSeconds int `json:"playwrightTimeoutSeconds,omitempty"`
+
+	// BearerTokenSecretRef renders an "Authorization: Bearer <token>" request header from
+	// the referenced secret at reconcile time, replacing any Authorization entry in
+	// requestHeaders.
+	BearerTokenSecretRef *corev1.SecretKeySelector `json:"bearerTokenSecretRef,omitempty"`
+
+	// PreflightCheck makes the operator request the URL once from inside the cluster before
+	// creating the monitor, failing with PreflightFailed when it is unreachable.
+	PreflightCheck bool `json:"preflightCheck,omitempty"`
+
+	// Adopt makes the operator look for an existing remote monitor with the same URL (and
+	// name, when set) before creating one, and manage it instead of creating a duplicate.
+	// Adopted monitors are left in Better Stack when the resource is deleted.
+	Adopt bool `json:"adopt,omitempty"`
+
+	// OnConflict decides what happens when adopt finds a remote monitor with the same URL
+	// and name that another BetterStackMonitor already manages. Fail reports a
+	// RemoteConflict, AdoptAnyway shares the remote monitor when the other resource is in
+	// the same namespace and Rename creates a separate monitor named after this resource.
+	// +kubebuilder:validation:Enum=Fail;AdoptAnyway;Rename
+	// +kubebuilder:default=Fail
+	OnConflict string `json:"onConflict,omitempty"`
+
+	// ExistingMonitorID adopts the remote monitor with this ID instead of creating or
+	// looking one up by URL, and like adopt leaves it in Better Stack when the resource is
+	// deleted. It is ignored once status.monitorID is set. A monitor whose ID
+	// is already managed from another namespace reports RemoteIDClaimed.
+	ExistingMonitorID string `json:"existingMonitorID,omitempty"`
+
+	// UnmanagedFields lists API attributes, such as paused or policy_id, that are changed
+	// outside the operator. They are sent when the monitor is created but never on
+	// updates; differences are reported in status.unmanagedDrift instead of corrected.
+	UnmanagedFields []string `json:"unmanagedFields,omitempty"`
+
+	// AdditionalAttributes are raw Better Stack API attributes merged into the payload for
+	// attributes the typed fields do not cover. Keys the operator sets from typed fields,
+	// such as url or paused, are rejected by the admission webhook.
+	// At most 64 entries of up to 4096 characters each.
+	// +kubebuilder:validation:MaxProperties=64
+	// +kubebuilder:validation:XValidation:rule="self.all(k, size(self[k]) <= 4096)",message="values must be at most 4096 characters"
 	AdditionalAttributes map[string]string `json:"additionalAttributes,omitempty"`
 
 	// Better Stack API base URL. Defaults to https://uptime.betterstack.com/api/v2 when omitted.
@@ -111,6 +180,14 @@ type BetterStackMonitorSpec struct {
 	// APITokenSecretRef references the secret containing the Better Stack API token.
 	// +kubebuilder:validation:Required
 	APITokenSecretRef corev1.SecretKeySelector `json:"apiTokenSecretRef"`
+
+	// AccountRef selects a BetterStackCredential, by default in the same namespace. When set
+	// it takes precedence over apiTokenSecretRef and supplies the token, default base URL and rate limit.
+	AccountRef *ResourceRef `json:"accountRef,omitempty"`
+
+	// Suspend stops the operator from reconciling this monitor while leaving the remote
+	// monitor untouched. Unlike paused, Better Stack keeps running its checks.
+	Suspend bool `json:"suspend,omitempty"`
 }
 
 // BetterStackHeader represents an HTTP header definition for a monitor.
@@ -121,6 +198,22 @@ type BetterStackHeader struct {
 	Value string `json:"value"`
 }
 
+// BetterStackMaintenanceWindow is a single maintenance window. Better Stack has no
+// attribute for one-off windows, so the operator pauses the monitor from From until To and
+// reconciles again at both boundaries.
+type BetterStackMaintenanceWindow struct {
+	// From is the local start of the window, e.g. 2024-06-01T22:00.
+	// +kubebuilder:validation:Pattern=`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}(:\d{2})?$`
+	From string `json:"from"`
+
+	// To is the local end of the window and must be after From.
+	// +kubebuilder:validation:Pattern=`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}(:\d{2})?$`
+	To string `json:"to"`
+
+	// Timezone is the IANA time zone From and To are given in. Defaults to UTC.
+	Timezone string `json:"timezone,omitempty"`
+}
+
 // DeepCopyInto copies the receiver into the provided out struct.
 func (in *BetterStackMonitorSpec) DeepCopyInto(out *BetterStackMonitorSpec) {
 	*out = *in
@@ -128,6 +221,10 @@ func (in *BetterStackMonitorSpec) DeepCopyInto(out *BetterStackMonitorSpec) {
 		out.Regions = make([]string, len(in.Regions))
 		copy(out.Regions, in.Regions)
 	}
+	if in.Tags != nil {
+		out.Tags = make([]string, len(in.Tags))
+		copy(out.Tags, in.Tags)
+	}
 	if in.ExpectedStatusCodes != nil {
 		out.ExpectedStatusCodes = make([]int, len(in.ExpectedStatusCodes))
 		copy(out.ExpectedStatusCodes, in.ExpectedStatusCodes)
@@ -140,6 +237,10 @@ func (in *BetterStackMonitorSpec) DeepCopyInto(out *BetterStackMonitorSpec) {
 		out.RequestHeaders = make([]BetterStackHeader, len(in.RequestHeaders))
 		copy(out.RequestHeaders, in.RequestHeaders)
 	}
+	if in.UnmanagedFields != nil {
+		out.UnmanagedFields = make([]string, len(in.UnmanagedFields))
+		copy(out.UnmanagedFields, in.UnmanagedFields)
+	}
 	if in.AdditionalAttributes != nil {
 		out.AdditionalAttributes = make(map[string]string, len(in.AdditionalAttributes))
 		maps.Copy(out.AdditionalAttributes, in.AdditionalAttributes)
@@ -148,6 +249,20 @@ func (in *BetterStackMonitorSpec) DeepCopyInto(out *BetterStackMonitorSpec) {
 		out.EnvironmentVariables = make(map[string]string, len(in.EnvironmentVariables))
 		maps.Copy(out.EnvironmentVariables, in.EnvironmentVariables)
 	}
+	if in.BearerTokenSecretRef != nil {
+		out.BearerTokenSecretRef = new(corev1.SecretKeySelector)
+		in.BearerTokenSecretRef.DeepCopyInto(out.BearerTokenSecretRef)
+	}
+	if in.PausedUntil != nil {
+		out.PausedUntil = in.PausedUntil.DeepCopy()
+	}
+	if in.OneTimeMaintenance != nil {
+		out.OneTimeMaintenance = new(BetterStackMaintenanceWindow)
+		*out.OneTimeMaintenance = *in.OneTimeMaintenance
+	}
+	if in.AccountRef != nil {
+		out.AccountRef = in.AccountRef.DeepCopy()
+	}
 }
 
 // DeepCopy creates a new copy of the receiver.
@@ -165,6 +280,41 @@ type BetterStackMonitorStatus struct {
 	// MonitorID is the identifier assigned by Better Stack.
 	MonitorID string `json:"monitorID,omitempty"`
 
+	// TeamName is the Better Stack team the monitor belongs to, as reported by the API.
+	TeamName string `json:"teamName,omitempty"`
+
+	// ClusterName is the --cluster-name of the operator that last synchronized the monitor.
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// BaseURL is the Better Stack API endpoint of the last successful sync.
+	BaseURL string `json:"baseURL,omitempty"`
+
+	// AlertRoute names the BetterStackAlertRoute whose policy and contact settings were
+	// last applied to the monitor.
+	AlertRoute string `json:"alertRoute,omitempty"`
+
+	// AlertRouteFields lists the Better Stack attributes the alert route filled in. They
+	// are cleared once no route fills them any more.
+	AlertRouteFields []string `json:"alertRouteFields,omitempty"`
+
+	// UnmanagedDrift lists the spec.unmanagedFields whose Better Stack value differs from
+	// the spec.
+	UnmanagedDrift []string `json:"unmanagedDrift,omitempty"`
+
+	// RemoteName is the pronounceable name used instead of spec.name after
+	// onConflict: Rename created a separate remote monitor.
+	RemoteName string `json:"remoteName,omitempty"`
+
+	// Adopted is true when the remote monitor was not created by this resource but adopted
+	// through spec.adopt or spec.existingMonitorID. The operator never deletes an adopted
+	// monitor.
+	Adopted bool `json:"adopted,omitempty"`
+
+	// MaintenancePausedSince records when the operator-wide maintenance switch started
+	// pausing the monitor. It is cleared by the first sync after maintenance ends, which
+	// sends spec.paused again.
+	MaintenancePausedSince *metav1.Time `json:"maintenancePausedSince,omitempty"`
+
 	// ObservedGeneration reflects the spec generation the controller last processed.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 
@@ -173,6 +323,14 @@ type BetterStackMonitorStatus struct {
 
 	// LastSyncedTime records when the operator last reconciled successfully.
 	LastSyncedTime *metav1.Time `json:"lastSyncedTime,omitempty"`
+
+	// Backoff tracks consecutive sync failures; it is cleared by the next successful sync.
+	Backoff *RetryBackoff `json:"backoff,omitempty"`
+
+	// DebugTrace holds the most recent reconcile steps while the betterstack.io/debug
+	// annotation is "true".
+	// +kubebuilder:validation:MaxItems=30
+	DebugTrace []ReconcileStep `json:"debugTrace,omitempty"`
 }
 
 // DeepCopyInto copies the receiver into the provided out struct.
@@ -187,6 +345,26 @@ func (in *BetterStackMonitorStatus) DeepCopyInto(out *BetterStackMonitorStatus)
 	if in.LastSyncedTime != nil {
 		out.LastSyncedTime = in.LastSyncedTime.DeepCopy()
 	}
+	if in.Backoff != nil {
+		out.Backoff = in.Backoff.DeepCopy()
+	}
+	if in.MaintenancePausedSince != nil {
+		out.MaintenancePausedSince = in.MaintenancePausedSince.DeepCopy()
+	}
+	if in.AlertRouteFields != nil {
+		out.AlertRouteFields = make([]string, len(in.AlertRouteFields))
+		copy(out.AlertRouteFields, in.AlertRouteFields)
+	}
+	if in.UnmanagedDrift != nil {
+		out.UnmanagedDrift = make([]string, len(in.UnmanagedDrift))
+		copy(out.UnmanagedDrift, in.UnmanagedDrift)
+	}
+	if in.DebugTrace != nil {
+		out.DebugTrace = make([]ReconcileStep, len(in.DebugTrace))
+		for i := range in.DebugTrace {
+			in.DebugTrace[i].DeepCopyInto(&out.DebugTrace[i])
+		}
+	}
 }
 
 // DeepCopy creates a new copy of the receiver.
@@ -284,18 +462,5 @@ func (in *BetterStackMonitorList) DeepCopyObject() runtime.Object {
 
 // SetCondition updates a condition on the status, creating or replacing it.
 func (s *BetterStackMonitorStatus) SetCondition(cond metav1.Condition) {
-	var conditions []metav1.Condition
-	replaced := false
-	for _, existing := range s.Conditions {
-		if existing.Type == cond.Type {
-			conditions = append(conditions, cond)
-			replaced = true
-			continue
-		}
-		conditions = append(conditions, existing)
-	}
-	if !replaced {
-		conditions = append(conditions, cond)
-	}
-	s.Conditions = conditions
+	s.Conditions = setCondition(s.Conditions, cond)
 }
@@ -1,6 +1,8 @@
 package v1alpha1
 
 import (
+	"maps"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -8,7 +10,8 @@ import (
 
 // BetterStackMonitorGroupSpec defines the desired state of a Better Stack monitor group.
 type BetterStackMonitorGroupSpec struct {
-	// Name is the human readable display name for the monitor group.
+	// Name is the human readable display name for the monitor group. $(CLUSTER_NAME) is replaced
+	// with the operator's --cluster-name.
 	Name string `json:"name,omitempty"`
 
 	// TeamName assigns the group to a specific Better Stack team (needed when using a global token).
@@ -28,6 +31,33 @@ type BetterStackMonitorGroupSpec struct {
 	// APITokenSecretRef references the secret containing the Better Stack API token.
 	// +kubebuilder:validation:Required
 	APITokenSecretRef corev1.SecretKeySelector `json:"apiTokenSecretRef"`
+
+	// AccountRef selects a BetterStackCredential, by default in the same namespace. When set
+	// it takes precedence over apiTokenSecretRef and supplies the token, default base URL and rate limit.
+	AccountRef *ResourceRef `json:"accountRef,omitempty"`
+
+	// Suspend stops the operator from reconciling this monitor group while leaving the remote
+	// monitor group untouched. Unlike paused, Better Stack keeps running its checks.
+	Suspend bool `json:"suspend,omitempty"`
+
+	// AllowRecreate lets the operator delete and recreate the remote group when a field
+	// Better Stack does not change on existing groups, such as teamName, is changed. The
+	// new group gets a new ID; monitors in the same namespace whose spec.monitorGroupID
+	// names the old one are moved to it. Groups adopted through existingMonitorGroupID are
+	// never recreated.
+	AllowRecreate bool `json:"allowRecreate,omitempty"`
+
+	// ExistingMonitorGroupID adopts the remote group with this ID instead of creating one.
+	// It is ignored once status.monitorGroupID is set. A group whose ID is already managed
+	// from another namespace reports RemoteIDClaimed.
+	ExistingMonitorGroupID string `json:"existingMonitorGroupID,omitempty"`
+
+	// AdditionalAttributes are raw Better Stack API attributes merged into the payload.
+	// They take precedence over typed fields, so they can still override any attribute.
+	// At most 64 entries of up to 4096 characters each.
+	// +kubebuilder:validation:MaxProperties=64
+	// +kubebuilder:validation:XValidation:rule="self.all(k, size(self[k]) <= 4096)",message="values must be at most 4096 characters"
+	AdditionalAttributes map[string]string `json:"additionalAttributes,omitempty"`
 }
 
 // BetterStackMonitorGroupStatus represents the observed state of the monitor group.
@@ -35,6 +65,16 @@ type BetterStackMonitorGroupStatus struct {
 	// MonitorGroupID is the identifier assigned by Better Stack.
 	MonitorGroupID string `json:"monitorGroupID,omitempty"`
 
+	// ClusterName is the --cluster-name of the operator that last synchronized the group.
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// BaseURL is the Better Stack API endpoint of the last successful sync.
+	BaseURL string `json:"baseURL,omitempty"`
+
+	// ReplacedMonitorGroupID is the ID of a group deleted by allowRecreate while monitors
+	// referring to it are still being moved to monitorGroupID.
+	ReplacedMonitorGroupID string `json:"replacedMonitorGroupID,omitempty"`
+
 	// ObservedGeneration reflects the spec generation the controller last processed.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 
@@ -43,6 +83,14 @@ type BetterStackMonitorGroupStatus struct {
 
 	// LastSyncedTime records when the operator last reconciled successfully.
 	LastSyncedTime *metav1.Time `json:"lastSyncedTime,omitempty"`
+
+	// Backoff tracks consecutive sync failures; it is cleared by the next successful sync.
+	Backoff *RetryBackoff `json:"backoff,omitempty"`
+
+	// DebugTrace holds the most recent reconcile steps while the betterstack.io/debug
+	// annotation is "true".
+	// +kubebuilder:validation:MaxItems=30
+	DebugTrace []ReconcileStep `json:"debugTrace,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -77,6 +125,13 @@ func (in *BetterStackMonitorGroupSpec) DeepCopyInto(out *BetterStackMonitorGroup
 		out.Paused = new(bool)
 		*out.Paused = *in.Paused
 	}
+	if in.AccountRef != nil {
+		out.AccountRef = in.AccountRef.DeepCopy()
+	}
+	if in.AdditionalAttributes != nil {
+		out.AdditionalAttributes = make(map[string]string, len(in.AdditionalAttributes))
+		maps.Copy(out.AdditionalAttributes, in.AdditionalAttributes)
+	}
 }
 
 func (in *BetterStackMonitorGroupSpec) DeepCopy() *BetterStackMonitorGroupSpec {
@@ -99,6 +154,15 @@ func (in *BetterStackMonitorGroupStatus) DeepCopyInto(out *BetterStackMonitorGro
 	if in.LastSyncedTime != nil {
 		out.LastSyncedTime = in.LastSyncedTime.DeepCopy()
 	}
+	if in.Backoff != nil {
+		out.Backoff = in.Backoff.DeepCopy()
+	}
+	if in.DebugTrace != nil {
+		out.DebugTrace = make([]ReconcileStep, len(in.DebugTrace))
+		for i := range in.DebugTrace {
+			in.DebugTrace[i].DeepCopyInto(&out.DebugTrace[i])
+		}
+	}
 }
 
 func (in *BetterStackMonitorGroupStatus) DeepCopy() *BetterStackMonitorGroupStatus {
@@ -162,19 +226,7 @@ func (in *BetterStackMonitorGroupList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// SetCondition updates a condition on the status, creating or replacing it.
 func (s *BetterStackMonitorGroupStatus) SetCondition(cond metav1.Condition) {
-	var conditions []metav1.Condition
-	replaced := false
-	for _, existing := range s.Conditions {
-		if existing.Type == cond.Type {
-			conditions = append(conditions, cond)
-			replaced = true
-			continue
-		}
-		conditions = append(conditions, existing)
-	}
-	if !replaced {
-		conditions = append(conditions, cond)
-	}
-	s.Conditions = conditions
+	s.Conditions = setCondition(s.Conditions, cond)
 }
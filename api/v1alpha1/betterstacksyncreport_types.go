@@ -0,0 +1,226 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// BetterStackSyncReportSpec selects the Better Stack account to audit and how often.
+type BetterStackSyncReportSpec struct {
+	// Better Stack API base URL. Defaults to https://uptime.betterstack.com/api/v2 when omitted.
+	// +kubebuilder:validation:Format=uri
+	BaseURL string `json:"baseURL,omitempty"`
+
+	// APITokenSecretRef references the secret containing the Better Stack API token.
+	// +kubebuilder:validation:Required
+	APITokenSecretRef corev1.SecretKeySelector `json:"apiTokenSecretRef"`
+
+	// AccountRef selects a BetterStackCredential, by default in the same namespace. When set
+	// it takes precedence over apiTokenSecretRef.
+	AccountRef *ResourceRef `json:"accountRef,omitempty"`
+
+	// IntervalMinutes controls how often the report is regenerated. Defaults to 60.
+	// +kubebuilder:validation:Minimum=5
+	IntervalMinutes int `json:"intervalMinutes,omitempty"`
+}
+
+// RemoteResourceRef identifies a monitor or heartbeat in Better Stack.
+type RemoteResourceRef struct {
+	// Kind is Monitor or Heartbeat.
+	Kind string `json:"kind"`
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// DuplicateRemoteResources lists remote resources of one kind that share a name, typically
+// left behind by repeated creates.
+type DuplicateRemoteResources struct {
+	Kind string   `json:"kind"`
+	Name string   `json:"name"`
+	IDs  []string `json:"ids"`
+}
+
+// MissingRemoteResource identifies a custom resource whose recorded Better Stack ID no
+// longer exists in the account.
+type MissingRemoteResource struct {
+	// Kind is BetterStackMonitor or BetterStackHeartbeat.
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	RemoteID  string `json:"remoteID"`
+}
+
+// BetterStackSyncReportStatus holds the latest account hygiene report.
+type BetterStackSyncReportStatus struct {
+	// ObservedGeneration reflects the spec generation the controller last processed.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// BaseURL is the Better Stack API endpoint the latest report listed.
+	BaseURL string `json:"baseURL,omitempty"`
+
+	// LastReportTime records when the report was last generated.
+	LastReportTime *metav1.Time `json:"lastReportTime,omitempty"`
+
+	// Unmanaged lists remote resources no custom resource in the cluster manages.
+	Unmanaged []RemoteResourceRef `json:"unmanaged,omitempty"`
+
+	// Duplicates lists remote resources sharing a name.
+	Duplicates []DuplicateRemoteResources `json:"duplicates,omitempty"`
+
+	// MissingRemotes lists custom resources pointing at remote IDs that no longer exist.
+	MissingRemotes []MissingRemoteResource `json:"missingRemotes,omitempty"`
+
+	// UnmanagedCount, DuplicateCount and MissingRemoteCount count every finding, including
+	// those left out of the lists above.
+	UnmanagedCount     int `json:"unmanagedCount,omitempty"`
+	DuplicateCount     int `json:"duplicateCount,omitempty"`
+	MissingRemoteCount int `json:"missingRemoteCount,omitempty"`
+
+	// Truncated is set when a list above was cut short to keep the status small; the
+	// counts stay exact.
+	Truncated bool `json:"truncated,omitempty"`
+
+	// Conditions capture whether the latest report was generated.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories=betterstack,scope=Namespaced
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Unmanaged",type=integer,JSONPath=".status.unmanagedCount"
+// +kubebuilder:printcolumn:name="Duplicates",type=integer,JSONPath=".status.duplicateCount"
+// +kubebuilder:printcolumn:name="Missing",type=integer,JSONPath=".status.missingRemoteCount"
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+
+// BetterStackSyncReport periodically audits a Better Stack account against the custom
+// resources in the cluster.
+type BetterStackSyncReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	Spec   BetterStackSyncReportSpec   `json:"spec"`
+	Status BetterStackSyncReportStatus `json:"status"`
+}
+
+// +kubebuilder:object:root=true
+
+// BetterStackSyncReportList contains a list of BetterStackSyncReport.
+type BetterStackSyncReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []BetterStackSyncReport `json:"items"`
+}
+
+func (in *BetterStackSyncReportSpec) DeepCopyInto(out *BetterStackSyncReportSpec) {
+	*out = *in
+	if in.AccountRef != nil {
+		out.AccountRef = in.AccountRef.DeepCopy()
+	}
+}
+
+func (in *BetterStackSyncReportSpec) DeepCopy() *BetterStackSyncReportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BetterStackSyncReportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *BetterStackSyncReportStatus) DeepCopyInto(out *BetterStackSyncReportStatus) {
+	*out = *in
+	if in.LastReportTime != nil {
+		out.LastReportTime = in.LastReportTime.DeepCopy()
+	}
+	if in.Unmanaged != nil {
+		out.Unmanaged = make([]RemoteResourceRef, len(in.Unmanaged))
+		copy(out.Unmanaged, in.Unmanaged)
+	}
+	if in.Duplicates != nil {
+		out.Duplicates = make([]DuplicateRemoteResources, len(in.Duplicates))
+		for i := range in.Duplicates {
+			out.Duplicates[i] = in.Duplicates[i]
+			if in.Duplicates[i].IDs != nil {
+				out.Duplicates[i].IDs = make([]string, len(in.Duplicates[i].IDs))
+				copy(out.Duplicates[i].IDs, in.Duplicates[i].IDs)
+			}
+		}
+	}
+	if in.MissingRemotes != nil {
+		out.MissingRemotes = make([]MissingRemoteResource, len(in.MissingRemotes))
+		copy(out.MissingRemotes, in.MissingRemotes)
+	}
+	if in.Conditions != nil {
+		out.Conditions = make([]metav1.Condition, len(in.Conditions))
+		for i := range in.Conditions {
+			in.Conditions[i].DeepCopyInto(&out.Conditions[i])
+		}
+	}
+}
+
+func (in *BetterStackSyncReportStatus) DeepCopy() *BetterStackSyncReportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BetterStackSyncReportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *BetterStackSyncReport) DeepCopyInto(out *BetterStackSyncReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+func (in *BetterStackSyncReport) DeepCopy() *BetterStackSyncReport {
+	if in == nil {
+		return nil
+	}
+	out := new(BetterStackSyncReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *BetterStackSyncReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+func (in *BetterStackSyncReportList) DeepCopyInto(out *BetterStackSyncReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]BetterStackSyncReport, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+func (in *BetterStackSyncReportList) DeepCopy() *BetterStackSyncReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(BetterStackSyncReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+func (in *BetterStackSyncReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// SetCondition updates a condition on the status, creating or replacing it.
+func (s *BetterStackSyncReportStatus) SetCondition(cond metav1.Condition) {
+	s.Conditions = setCondition(s.Conditions, cond)
+}
@@ -0,0 +1,25 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// setCondition creates or replaces the condition of the same type. The existing
+// lastTransitionTime is kept when neither status nor reason changed, so that repeated
+// syncs do not reset how long a resource has been in its current state. Conditions of
+// other types, including those added by other controllers, are kept as they are and in
+// place.
+func setCondition(conditions []metav1.Condition, cond metav1.Condition) []metav1.Condition {
+	for i, existing := range conditions {
+		if existing.Type != cond.Type {
+			continue
+		}
+		if existing.Status == cond.Status && existing.Reason == cond.Reason && !existing.LastTransitionTime.IsZero() {
+			cond.LastTransitionTime = existing.LastTransitionTime
+		}
+		updated := append([]metav1.Condition(nil), conditions...)
+		updated[i] = cond
+		return updated
+	}
+	return append(conditions, cond)
+}
@@ -18,4 +18,73 @@ const (
 
 	// ConditionSync captures the outcome of the most recent reconciliation attempt.
 	ConditionSync = "Synced"
+
+	// ConditionSuspended reports whether reconciliation is suspended through spec.suspend.
+	ConditionSuspended = "Suspended"
+
+	// ConditionPingsMissing reports whether a heartbeat with verifyPings has stopped receiving pings.
+	ConditionPingsMissing = "PingsMissing"
+
+	// ConditionDeprecatedFields reports whether the spec sets fields that will be removed
+	// in a later API version.
+	ConditionDeprecatedFields = "DeprecatedFieldsUsed"
+
+	// ConditionImmutableFieldChanged reports whether the spec changes a field Better Stack
+	// does not update on the existing remote resource.
+	ConditionImmutableFieldChanged = "ImmutableFieldChanged"
+
+	// ConditionURLAnnotated reports whether the heartbeat URL was written to the object
+	// selected by spec.urlAnnotation.
+	ConditionURLAnnotated = "URLAnnotated"
+
+	// DefaultHeartbeatURLAnnotation is the annotation key used when spec.urlAnnotation.key
+	// is empty.
+	DefaultHeartbeatURLAnnotation = "betterstack.io/heartbeat-url"
+)
+
+// AdmissionVerifyLabel set to "true" on a namespace makes the validating webhook look up
+// the Better Stack token and groups referenced by monitors and heartbeats created or
+// edited there, rejecting those Better Stack does not know.
+const AdmissionVerifyLabel = "betterstack.io/verify-admission"
+
+// Size limits of the free-form maps in BetterStackMonitorSpec, enforced by the CRD schema
+// and the validating webhook. They keep a monitor far below etcd's object size limit and
+// the request bodies the operator sends to Better Stack small.
+const (
+	// MaxAdditionalAttributes caps the number of spec.additionalAttributes entries.
+	MaxAdditionalAttributes = 64
+	// MaxEnvironmentVariables caps the number of spec.environmentVariables entries.
+	MaxEnvironmentVariables = 64
+	// MaxMapValueLength caps the length of each value in either map.
+	MaxMapValueLength = 4096
+)
+
+// ClusterNameVariable is replaced in spec.name with the operator's --cluster-name, so
+// Better Stack shows which cluster owns a resource.
+const ClusterNameVariable = "$(CLUSTER_NAME)"
+
+// Values of BetterStackMonitorSpec.OnConflict.
+const (
+	// OnConflictFail stops with a RemoteConflict condition. It is the default.
+	OnConflictFail = "Fail"
+	// OnConflictAdoptAnyway adopts the remote monitor even though another resource manages it.
+	OnConflictAdoptAnyway = "AdoptAnyway"
+	// OnConflictRename creates a separate remote monitor with a name derived from the resource.
+	OnConflictRename = "Rename"
+)
+
+// Values of BetterStackHeartbeatSpec.NameConflictStrategy.
+const (
+	// NameConflictFail stops with a NameConflict condition. It is the default.
+	NameConflictFail = "Fail"
+	// NameConflictSuffix retries creation with a namespace-derived suffix on the name.
+	NameConflictSuffix = "Suffix"
+)
+
+// Values of BetterStackHeartbeatSpec.PeriodChangePolicy.
+const (
+	// PeriodChangeImmediate sends a new period as-is. It is the default.
+	PeriodChangeImmediate = "Immediate"
+	// PeriodChangeGraceThenApply widens the grace while a shorter period takes effect.
+	PeriodChangeGraceThenApply = "GraceThenApply"
 )
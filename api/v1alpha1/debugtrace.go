@@ -0,0 +1,48 @@
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+const (
+	// DebugAnnotation set to "true" on a monitor, heartbeat or monitor group logs its
+	// reconciles at every verbosity and records them in status.debugTrace.
+	DebugAnnotation = "betterstack.io/debug"
+
+	// MaxDebugTraceSteps is how many of the most recent steps status.debugTrace keeps.
+	MaxDebugTraceSteps = 30
+
+	// MaxDebugTraceMessageLength truncates the message of a single step.
+	MaxDebugTraceMessageLength = 256
+)
+
+// Kinds of ReconcileStep.
+const (
+	// DebugStepAPI is a Better Stack request, with its status code and duration.
+	DebugStepAPI = "API"
+	// DebugStepStatus is a status update, with the Ready condition it set.
+	DebugStepStatus = "Status"
+)
+
+// ReconcileStep is one entry of a debug trace.
+type ReconcileStep struct {
+	Time metav1.MicroTime `json:"time"`
+	// Kind is API or Status.
+	Kind string `json:"kind"`
+	// +kubebuilder:validation:MaxLength=256
+	Message string `json:"message"`
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *ReconcileStep) DeepCopyInto(out *ReconcileStep) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy creates a new copy of the receiver.
+func (in *ReconcileStep) DeepCopy() *ReconcileStep {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconcileStep)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -24,6 +24,12 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&BetterStackHeartbeatList{},
 		&BetterStackMonitorGroup{},
 		&BetterStackMonitorGroupList{},
+		&BetterStackCredential{},
+		&BetterStackCredentialList{},
+		&BetterStackSyncReport{},
+		&BetterStackSyncReportList{},
+		&BetterStackAlertRoute{},
+		&BetterStackAlertRouteList{},
 	)
 	metav1.AddToGroupVersion(scheme, GroupVersion)
 	return nil
@@ -0,0 +1,87 @@
+package v1alpha1
+
+// Condition and event reasons set by the operator. They form a stable vocabulary that
+// health checks and alert rules can match on; messages are free-form and may change.
+const (
+	// ReasonSuspended marks the Suspended condition while spec.suspend is set.
+	ReasonSuspended = "Suspended"
+	// ReasonResumed marks the Suspended condition after spec.suspend is cleared.
+	ReasonResumed = "Resumed"
+
+	// ReasonTokenResolved means the Better Stack API token was found.
+	ReasonTokenResolved = "TokenResolved"
+	// ReasonTokenUnavailable means the API token secret or credential could not be read.
+	ReasonTokenUnavailable = "TokenUnavailable"
+	// ReasonBearerTokenUnavailable means a monitor's spec.bearerTokenSecretRef could not be read.
+	ReasonBearerTokenUnavailable = "BearerTokenUnavailable"
+
+	// ReasonMonitorSynced, ReasonHeartbeatSynced and ReasonMonitorGroupSynced mean the
+	// remote object matches the spec.
+	ReasonMonitorSynced      = "MonitorSynced"
+	ReasonHeartbeatSynced    = "HeartbeatSynced"
+	ReasonMonitorGroupSynced = "MonitorGroupSynced"
+	// ReasonSyncFailed is the generic reason for a failed Better Stack API call.
+	ReasonSyncFailed = "SyncFailed"
+	// ReasonMonitorQuotaExceeded means Better Stack rejected a monitor because the plan's
+	// monitor limit is reached.
+	ReasonMonitorQuotaExceeded = "MonitorQuotaExceeded"
+	// ReasonHeartbeatQuotaExceeded means Better Stack rejected a heartbeat because the
+	// plan's heartbeat limit is reached.
+	ReasonHeartbeatQuotaExceeded = "HeartbeatQuotaExceeded"
+	// ReasonRegionUnavailable means Better Stack rejected spec.regions, usually because the
+	// account's plan does not include one of them.
+	ReasonRegionUnavailable = "RegionUnavailable"
+	// ReasonRemoteFetchFailed means the existing remote monitor could not be read, so an
+	// update that depends on it was postponed.
+	ReasonRemoteFetchFailed = "RemoteFetchFailed"
+	// ReasonMonitorGroupNotFound means spec.monitorGroupID names a group that does not exist.
+	ReasonMonitorGroupNotFound = "MonitorGroupNotFound"
+	// ReasonRemoteConflict means the remote monitor matching spec.url and spec.name is
+	// already managed by another BetterStackMonitor and spec.onConflict is Fail.
+	ReasonRemoteConflict = "RemoteConflict"
+	// ReasonRemoteIDClaimed means the remote object's ID is already managed by a resource
+	// in another namespace.
+	ReasonRemoteIDClaimed = "RemoteIDClaimed"
+	// ReasonNameConflict means Better Stack refused to create a heartbeat because its name
+	// is taken and spec.nameConflictStrategy did not resolve it.
+	ReasonNameConflict = "NameConflict"
+	// ReasonPreflightFailed means the in-cluster spec.preflightCheck request failed.
+	ReasonPreflightFailed = "PreflightFailed"
+
+	// ReasonHeartbeatUp, ReasonHeartbeatDown, ReasonHeartbeatPaused and
+	// ReasonAwaitingFirstPing describe the PingsMissing condition of heartbeats with
+	// spec.verifyPings.
+	ReasonHeartbeatUp       = "HeartbeatUp"
+	ReasonHeartbeatDown     = "HeartbeatDown"
+	ReasonHeartbeatPaused   = "HeartbeatPaused"
+	ReasonAwaitingFirstPing = "AwaitingFirstPing"
+
+	// ReasonReportGenerated means a BetterStackSyncReport was refreshed.
+	ReasonReportGenerated = "ReportGenerated"
+	// ReasonListFailed means listing remote resources for a BetterStackSyncReport failed.
+	ReasonListFailed = "ListFailed"
+
+	// ReasonRouteApplied and ReasonInvalidSelector describe a BetterStackAlertRoute.
+	ReasonRouteApplied    = "RouteApplied"
+	ReasonInvalidSelector = "InvalidSelector"
+
+	// ReasonDeprecatedField and ReasonNoDeprecatedFields describe the DeprecatedFieldsUsed
+	// condition.
+	ReasonDeprecatedField    = "DeprecatedField"
+	ReasonNoDeprecatedFields = "NoDeprecatedFields"
+
+	// ReasonImmutableFieldChanged, ReasonRemoteRecreated and ReasonImmutableFieldsInSync
+	// describe the ImmutableFieldChanged condition. ReasonImmutableFieldChanged is also the
+	// Ready reason while the change cannot be applied.
+	ReasonImmutableFieldChanged = "ImmutableFieldChanged"
+	ReasonRemoteRecreated       = "RemoteRecreated"
+	ReasonImmutableFieldsInSync = "ImmutableFieldsInSync"
+
+	// ReasonURLAnnotated and ReasonAnnotationFailed describe the URLAnnotated condition.
+	ReasonURLAnnotated     = "URLAnnotated"
+	ReasonAnnotationFailed = "AnnotationFailed"
+
+	// ReasonDeprecatedAPI is the reason of Warning events raised when Better Stack reports
+	// a deprecated endpoint or attribute.
+	ReasonDeprecatedAPI = "DeprecatedAPI"
+)
@@ -0,0 +1,26 @@
+package v1alpha1
+
+// ResourceRef points at another object managed by the operator. Namespace defaults to the
+// namespace of the referencing resource; other namespaces are only honoured when the
+// operator runs with --allow-cross-namespace-refs.
+type ResourceRef struct {
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Namespace of the referenced object. Defaults to the referencing resource's namespace.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *ResourceRef) DeepCopyInto(out *ResourceRef) {
+	*out = *in
+}
+
+// DeepCopy creates a new ResourceRef.
+func (in *ResourceRef) DeepCopy() *ResourceRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceRef)
+	in.DeepCopyInto(out)
+	return out
+}
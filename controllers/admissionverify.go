@@ -0,0 +1,215 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/internal/controller/accounts"
+	"loks0n/betterstack-operator/internal/controller/credentials"
+	"loks0n/betterstack-operator/internal/controller/metrics"
+	"loks0n/betterstack-operator/internal/controller/refs"
+	"loks0n/betterstack-operator/pkg/betterstack"
+)
+
+// admissionVerifyTimeout bounds the Better Stack lookups of one admission request, well
+// inside the API server's default webhook timeout of ten seconds.
+const admissionVerifyTimeout = 5 * time.Second
+
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+// BetterStackVerifyClientFactory provides the Better Stack API clients AdmissionVerifier
+// looks references up with.
+type BetterStackVerifyClientFactory interface {
+	MonitorGroup(baseURL, token string, httpClient *http.Client) betterstack.MonitorGroupClient
+	HeartbeatGroup(baseURL, token string, httpClient *http.Client) betterstack.HeartbeatGroupClient
+}
+
+type defaultBetterStackVerifyClientFactory struct{}
+
+func (defaultBetterStackVerifyClientFactory) client(baseURL, token string, httpClient *http.Client) *betterstack.Client {
+	return betterstack.NewClient(baseURL, token, httpClient, betterstack.WithConnectionTrace(metrics.ObserveConnection), betterstack.WithWarningHandler(recordAPIWarning), betterstack.WithRequestObserver(recordAPIRequest))
+}
+
+func (f defaultBetterStackVerifyClientFactory) MonitorGroup(baseURL, token string, httpClient *http.Client) betterstack.MonitorGroupClient {
+	return f.client(baseURL, token, httpClient).MonitorGroups
+}
+
+func (f defaultBetterStackVerifyClientFactory) HeartbeatGroup(baseURL, token string, httpClient *http.Client) betterstack.HeartbeatGroupClient {
+	return f.client(baseURL, token, httpClient).HeartbeatGroups
+}
+
+// AdmissionVerifier checks monitors and heartbeats against Better Stack before they are
+// admitted: the API token must be accepted and the referenced groups must exist. Better
+// Stack has no dry-run endpoint, and its API reference documents no escalation policy
+// lookup, so these checks are as close to validating a create as the API allows. Each admission then waits on Better Stack, so
+// namespaces opt in with the AdmissionVerifyLabel.
+type AdmissionVerifier struct {
+	Client     client.Client
+	HTTPClient *http.Client
+	Clients    BetterStackVerifyClientFactory
+	Accounts   *accounts.Registry
+
+	// References decides whether accountRef may select a credential in another namespace.
+	References refs.Policy
+
+	// DefaultBaseURL is the --default-base-url the controllers sync against.
+	DefaultBaseURL string
+}
+
+// remoteReference is a Better Stack object a spec field refers to.
+type remoteReference struct {
+	path   *field.Path
+	id     string
+	lookup func(ctx context.Context, id string) error
+}
+
+// Verify returns an Invalid error listing the references Better Stack does not know, or
+// the token field when Better Stack rejects the token. Resources whose credentials cannot
+// be resolved yet, or that are checked while Better Stack is unreachable, are admitted
+// with a warning so an outage or apply ordering does not block deploys.
+func (v *AdmissionVerifier) Verify(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	if v == nil {
+		return nil, nil
+	}
+	object, ok := obj.(client.Object)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("unexpected object %T", obj))
+	}
+	enabled, err := v.enabled(ctx, object.GetNamespace())
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	if !enabled {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, admissionVerifyTimeout)
+	defer cancel()
+
+	spec := field.NewPath("spec")
+	var (
+		kind       string
+		tokenPath  *field.Path
+		references []remoteReference
+		account    credentials.Account
+	)
+	switch obj := obj.(type) {
+	case *monitoringv1alpha1.BetterStackMonitor:
+		kind = "BetterStackMonitor"
+		tokenPath = tokenField(spec, obj.Spec.AccountRef)
+		account, err = resolveAccount(ctx, v.Client, v.References, v.DefaultBaseURL, obj.Namespace, obj.Spec.AccountRef, obj.Spec.APITokenSecretRef, obj.Spec.BaseURL)
+		if err != nil {
+			return credentialsWarning(err), nil
+		}
+		groups := v.clients().MonitorGroup(account.BaseURL, account.Token, v.Accounts.HTTPClient(account, v.HTTPClient))
+		references = appendReference(references, spec.Child("monitorGroupID"), obj.Spec.MonitorGroupID, func(ctx context.Context, id string) error {
+			_, err := groups.Get(ctx, id)
+			return err
+		})
+		if len(references) == 0 {
+			references = append(references, tokenReference(tokenPath, func(ctx context.Context) error {
+				_, err := groups.List(ctx)
+				return err
+			}))
+		}
+	case *monitoringv1alpha1.BetterStackHeartbeat:
+		kind = "BetterStackHeartbeat"
+		tokenPath = tokenField(spec, obj.Spec.AccountRef)
+		account, err = resolveAccount(ctx, v.Client, v.References, v.DefaultBaseURL, obj.Namespace, obj.Spec.AccountRef, obj.Spec.APITokenSecretRef, obj.Spec.BaseURL)
+		if err != nil {
+			return credentialsWarning(err), nil
+		}
+		groups := v.clients().HeartbeatGroup(account.BaseURL, account.Token, v.Accounts.HTTPClient(account, v.HTTPClient))
+		if obj.Spec.HeartbeatGroupID != nil {
+			references = appendReference(references, spec.Child("heartbeatGroupID"), strconv.Itoa(*obj.Spec.HeartbeatGroupID), func(ctx context.Context, id string) error {
+				_, err := groups.Get(ctx, id)
+				return err
+			})
+		}
+		if len(references) == 0 {
+			references = append(references, tokenReference(tokenPath, func(ctx context.Context) error {
+				_, err := groups.List(ctx)
+				return err
+			}))
+		}
+	default:
+		return nil, nil
+	}
+
+	var errs field.ErrorList
+	for _, ref := range references {
+		err := ref.lookup(ctx, ref.id)
+		var apiErr *betterstack.APIError
+		switch {
+		case err == nil:
+		case betterstack.IsNotFound(err):
+			errs = append(errs, field.Invalid(ref.path, ref.id, "does not exist in Better Stack"))
+		case errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden):
+			errs = append(errs, field.Invalid(tokenPath, field.OmitValueType{}, fmt.Sprintf("Better Stack rejected the API token: %s", apiErr.Message)))
+			return nil, apierrors.NewInvalid(monitoringv1alpha1.GroupVersion.WithKind(kind).GroupKind(), object.GetName(), errs)
+		default:
+			return admission.Warnings{fmt.Sprintf("could not verify %s against Better Stack: %v", ref.path, err)}, nil
+		}
+	}
+	if len(errs) == 0 {
+		return nil, nil
+	}
+	return nil, apierrors.NewInvalid(monitoringv1alpha1.GroupVersion.WithKind(kind).GroupKind(), object.GetName(), errs)
+}
+
+// enabled reports whether namespace opted in to verification.
+func (v *AdmissionVerifier) enabled(ctx context.Context, namespace string) (bool, error) {
+	var ns corev1.Namespace
+	if err := v.Client.Get(ctx, client.ObjectKey{Name: namespace}, &ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return ns.Labels[monitoringv1alpha1.AdmissionVerifyLabel] == "true", nil
+}
+
+func (v *AdmissionVerifier) clients() BetterStackVerifyClientFactory {
+	if v.Clients == nil {
+		return defaultBetterStackVerifyClientFactory{}
+	}
+	return v.Clients
+}
+
+func appendReference(references []remoteReference, path *field.Path, id string, lookup func(context.Context, string) error) []remoteReference {
+	if id == "" {
+		return references
+	}
+	return append(references, remoteReference{path: path, id: id, lookup: lookup})
+}
+
+// tokenReference checks the token alone with list, a request that needs nothing but an
+// accepted token, for specs that reference nothing else.
+func tokenReference(path *field.Path, list func(context.Context) error) remoteReference {
+	return remoteReference{path: path, lookup: func(ctx context.Context, _ string) error {
+		return list(ctx)
+	}}
+}
+
+func tokenField(spec *field.Path, accountRef *monitoringv1alpha1.ResourceRef) *field.Path {
+	if accountRef != nil {
+		return spec.Child("accountRef")
+	}
+	return spec.Child("apiTokenSecretRef")
+}
+
+func credentialsWarning(err error) admission.Warnings {
+	return admission.Warnings{fmt.Sprintf("skipped verifying against Better Stack: %v", err)}
+}
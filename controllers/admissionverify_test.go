@@ -0,0 +1,141 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/internal/testutil/assert"
+	"loks0n/betterstack-operator/internal/testutil/controllertest"
+	"loks0n/betterstack-operator/pkg/betterstack"
+	"loks0n/betterstack-operator/pkg/betterstack/betterstackfakes"
+)
+
+type fakeBetterStackVerifyClientFactory struct {
+	monitorGroup   *betterstackfakes.MonitorGroupClient
+	heartbeatGroup *betterstackfakes.HeartbeatGroupClient
+}
+
+func (f *fakeBetterStackVerifyClientFactory) MonitorGroup(string, string, *http.Client) betterstack.MonitorGroupClient {
+	return f.monitorGroup
+}
+
+func (f *fakeBetterStackVerifyClientFactory) HeartbeatGroup(string, string, *http.Client) betterstack.HeartbeatGroupClient {
+	return f.heartbeatGroup
+}
+
+func newVerifyingValidator(t *testing.T, verify bool, factory *fakeBetterStackVerifyClientFactory) *UniquenessValidator {
+	t.Helper()
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+	if verify {
+		namespace.Labels = map[string]string{monitoringv1alpha1.AdmissionVerifyLabel: "true"}
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+	c := fake.NewClientBuilder().WithScheme(controllertest.NewScheme(t)).WithObjects(namespace, secret).Build()
+	return &UniquenessValidator{Client: c, Verifier: &AdmissionVerifier{Client: c, Clients: factory}}
+}
+
+func verifiedMonitor() *monitoringv1alpha1.BetterStackMonitor {
+	monitor := uniquenessMonitor("default", "api", "https://example.com/health", "API")
+	monitor.Spec.APITokenSecretRef = corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "api"}, Key: "token"}
+	return monitor
+}
+
+func TestUniquenessValidatorVerifiesMonitorReferences(t *testing.T) {
+	factory := &fakeBetterStackVerifyClientFactory{
+		monitorGroup: &betterstackfakes.MonitorGroupClient{GetFn: func(context.Context, string) (betterstack.MonitorGroup, error) {
+			return betterstack.MonitorGroup{}, &betterstack.APIError{StatusCode: http.StatusNotFound}
+		}},
+	}
+	v := newVerifyingValidator(t, true, factory)
+
+	monitor := verifiedMonitor()
+	monitor.Spec.PolicyID = "policy-1"
+	monitor.Spec.MonitorGroupID = "42"
+	_, err := v.ValidateCreate(context.Background(), monitor)
+	assert.Error(t, err, "validate create")
+	assert.Bool(t, "invalid", apierrors.IsInvalid(err), true)
+	assert.Bool(t, "names monitor group", strings.Contains(err.Error(), "spec.monitorGroupID"), true)
+	assert.Bool(t, "leaves policy to the sync", strings.Contains(err.Error(), "spec.policyID"), false)
+	assert.Int(t, "group lookups", factory.monitorGroup.GetCalls, 1)
+	assert.Int(t, "token checks", factory.monitorGroup.ListCalls, 0)
+
+	// Metadata-only updates are admitted without asking Better Stack again.
+	relabeled := monitor.DeepCopy()
+	relabeled.Labels = map[string]string{"team": "platform"}
+	_, err = v.ValidateUpdate(context.Background(), monitor, relabeled)
+	assert.NoError(t, err, "validate metadata update")
+	assert.Int(t, "group lookups after metadata update", factory.monitorGroup.GetCalls, 1)
+}
+
+func TestUniquenessValidatorVerifiesToken(t *testing.T) {
+	factory := &fakeBetterStackVerifyClientFactory{
+		monitorGroup: &betterstackfakes.MonitorGroupClient{ListFn: func(context.Context) ([]betterstack.MonitorGroup, error) {
+			return nil, &betterstack.APIError{StatusCode: http.StatusUnauthorized, Message: "Invalid Team API token"}
+		}},
+	}
+	v := newVerifyingValidator(t, true, factory)
+
+	_, err := v.ValidateCreate(context.Background(), verifiedMonitor())
+	assert.Error(t, err, "validate create")
+	assert.Bool(t, "invalid", apierrors.IsInvalid(err), true)
+	assert.Bool(t, "names token", strings.Contains(err.Error(), "spec.apiTokenSecretRef"), true)
+	assert.Int(t, "token checks", factory.monitorGroup.ListCalls, 1)
+}
+
+func TestUniquenessValidatorVerifiesHeartbeatGroup(t *testing.T) {
+	factory := &fakeBetterStackVerifyClientFactory{
+		heartbeatGroup: &betterstackfakes.HeartbeatGroupClient{GetFn: func(_ context.Context, id string) (betterstack.HeartbeatGroup, error) {
+			assert.String(t, "group id", id, "7")
+			return betterstack.HeartbeatGroup{}, &betterstack.APIError{StatusCode: http.StatusNotFound}
+		}},
+	}
+	v := newVerifyingValidator(t, true, factory)
+
+	heartbeat := uniquenessHeartbeat("default", "nightly", "Nightly backup")
+	heartbeat.Spec.APITokenSecretRef = corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "api"}, Key: "token"}
+	groupID := 7
+	heartbeat.Spec.HeartbeatGroupID = &groupID
+	_, err := v.ValidateCreate(context.Background(), heartbeat)
+	assert.Error(t, err, "validate create")
+	assert.Bool(t, "names heartbeat group", strings.Contains(err.Error(), "spec.heartbeatGroupID"), true)
+}
+
+func TestUniquenessValidatorVerifyWarnsWhenUnavailable(t *testing.T) {
+	factory := &fakeBetterStackVerifyClientFactory{
+		monitorGroup: &betterstackfakes.MonitorGroupClient{ListFn: func(context.Context) ([]betterstack.MonitorGroup, error) {
+			return nil, &betterstack.APIError{StatusCode: http.StatusServiceUnavailable}
+		}},
+	}
+	v := newVerifyingValidator(t, true, factory)
+
+	warnings, err := v.ValidateCreate(context.Background(), verifiedMonitor())
+	assert.NoError(t, err, "validate create")
+	assert.Int(t, "warnings", len(warnings), 1)
+
+	// Missing credentials may be applied after the monitor, so they only warn too.
+	monitor := verifiedMonitor()
+	monitor.Spec.APITokenSecretRef.Name = "missing"
+	warnings, err = v.ValidateCreate(context.Background(), monitor)
+	assert.NoError(t, err, "validate create without credentials")
+	assert.Int(t, "warnings without credentials", len(warnings), 1)
+}
+
+func TestUniquenessValidatorSkipsVerifyWithoutNamespaceLabel(t *testing.T) {
+	factory := &fakeBetterStackVerifyClientFactory{monitorGroup: &betterstackfakes.MonitorGroupClient{}}
+	v := newVerifyingValidator(t, false, factory)
+
+	_, err := v.ValidateCreate(context.Background(), verifiedMonitor())
+	assert.NoError(t, err, "validate create")
+	assert.Int(t, "token checks", factory.monitorGroup.ListCalls, 0)
+}
@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/pkg/betterstack"
+)
+
+// adoption is the outcome of looking for an existing remote monitor before creating one.
+// At most one of adopt, conflict and renameTo is set; all empty means create as usual.
+type adoption struct {
+	// adopt is the remote monitor to update instead of creating a new one.
+	adopt *betterstack.Monitor
+	// conflict names the resource already managing the matching remote monitor when
+	// spec.onConflict is Fail.
+	conflict string
+	// renameTo is the pronounceable name to create a separate monitor with.
+	renameTo string
+}
+
+// resolveAdoption looks for a remote monitor with the URL and name of spec, the monitor's
+// spec as sent to Better Stack. It is only called for monitors that set spec.adopt, since it
+// lists every remote monitor of the account. An unmanaged match is adopted; a match already recorded in
+// another BetterStackMonitor's status is handled according to spec.onConflict.
+func (r *BetterStackMonitorReconciler) resolveAdoption(ctx context.Context, monitorAPI betterstack.MonitorClient, monitor *monitoringv1alpha1.BetterStackMonitor, spec monitoringv1alpha1.BetterStackMonitorSpec) (adoption, error) {
+	remotes, err := monitorAPI.List(ctx)
+	if err != nil {
+		return adoption{}, fmt.Errorf("list remote monitors: %w", err)
+	}
+
+	var match *betterstack.Monitor
+	for i := range remotes {
+		if adoptable(spec, remotes[i]) {
+			match = &remotes[i]
+			break
+		}
+	}
+	if match == nil {
+		return adoption{}, nil
+	}
+
+	owner, err := r.remoteOwner(ctx, monitor, match.ID)
+	if err != nil {
+		return adoption{}, err
+	}
+	if owner == "" {
+		return adoption{adopt: match}, nil
+	}
+
+	switch spec.OnConflict {
+	case monitoringv1alpha1.OnConflictAdoptAnyway:
+		return adoption{adopt: match}, nil
+	case monitoringv1alpha1.OnConflictRename:
+		return adoption{renameTo: renamedMonitorName(monitor, spec)}, nil
+	default:
+		return adoption{conflict: owner}, nil
+	}
+}
+
+// adoptable reports whether remote is the monitor spec describes: the same URL and, when
+// spec.name is set, the same pronounceable name.
+func adoptable(spec monitoringv1alpha1.BetterStackMonitorSpec, remote betterstack.Monitor) bool {
+	if spec.URL == "" || !equivalentURLs(remote.Attributes.URL, spec.URL) {
+		return false
+	}
+	return spec.Name == "" || remote.Attributes.PronounceableName == spec.Name
+}
+
+// remoteOwner returns the namespace/name of another BetterStackMonitor whose status
+// records id, or "" when none does or id is empty.
+func (r *BetterStackMonitorReconciler) remoteOwner(ctx context.Context, monitor *monitoringv1alpha1.BetterStackMonitor, id string) (string, error) {
+	if id == "" {
+		return "", nil
+	}
+	var monitors monitoringv1alpha1.BetterStackMonitorList
+	if err := r.List(ctx, &monitors, client.MatchingFields{monitorIDIndexKey: id}); err != nil {
+		return "", fmt.Errorf("list monitors: %w", err)
+	}
+	for i := range monitors.Items {
+		other := &monitors.Items[i]
+		if other.Status.MonitorID != id || client.ObjectKeyFromObject(other) == client.ObjectKeyFromObject(monitor) {
+			continue
+		}
+		return client.ObjectKeyFromObject(other).String(), nil
+	}
+	return "", nil
+}
+
+// renamedMonitorName derives a pronounceable name that cannot collide with the original by
+// suffixing the resource's namespace and name.
+func renamedMonitorName(monitor *monitoringv1alpha1.BetterStackMonitor, spec monitoringv1alpha1.BetterStackMonitorSpec) string {
+	base := spec.Name
+	if base == "" {
+		base = spec.URL
+	}
+	return fmt.Sprintf("%s (%s/%s)", base, monitor.Namespace, monitor.Name)
+}
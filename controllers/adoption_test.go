@@ -0,0 +1,347 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/internal/testutil/assert"
+	"loks0n/betterstack-operator/internal/testutil/controllertest"
+	"loks0n/betterstack-operator/pkg/betterstack"
+	"loks0n/betterstack-operator/pkg/betterstack/betterstackfakes"
+)
+
+func TestAdoptable(t *testing.T) {
+	remote := betterstack.Monitor{ID: "1", Attributes: betterstack.MonitorAttributes{URL: "https://Example.com/", PronounceableName: "API"}}
+	cases := []struct {
+		name string
+		spec monitoringv1alpha1.BetterStackMonitorSpec
+		want bool
+	}{
+		{name: "normalized url without name", spec: monitoringv1alpha1.BetterStackMonitorSpec{URL: "https://example.com"}, want: true},
+		{name: "url and name", spec: monitoringv1alpha1.BetterStackMonitorSpec{URL: "https://example.com", Name: "API"}, want: true},
+		{name: "different name", spec: monitoringv1alpha1.BetterStackMonitorSpec{URL: "https://example.com", Name: "Web"}, want: false},
+		{name: "different url", spec: monitoringv1alpha1.BetterStackMonitorSpec{URL: "https://example.org", Name: "API"}, want: false},
+		{name: "empty url", spec: monitoringv1alpha1.BetterStackMonitorSpec{Name: "API"}, want: false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Bool(t, "adoptable", adoptable(tc.spec, remote), tc.want)
+		})
+	}
+}
+
+func TestReconcileAdoptsUnmanagedRemoteMonitor(t *testing.T) {
+	monitor := newAdoptionMonitor("api", "")
+	service := adoptionMonitorService()
+	c, r := newAdoptionReconciler(t, service, monitor)
+
+	reconcileAdoption(t, r, monitor)
+
+	assert.Int(t, "create calls", service.CreateCalls, 0)
+	assert.Int(t, "update calls", service.UpdateCalls, 1)
+	updated := fetchAdoptionMonitor(t, c, monitor)
+	assert.String(t, "monitor id", updated.Status.MonitorID, "remote-1")
+	assert.String(t, "remote name", updated.Status.RemoteName, "")
+	assert.Bool(t, "adopted", updated.Status.Adopted, true)
+}
+
+func TestReconcileCreatesWithoutAdopt(t *testing.T) {
+	monitor := newAdoptionMonitor("api", "")
+	monitor.Spec.Adopt = false
+	service := adoptionMonitorService()
+	service.CreateFn = func(ctx context.Context, req betterstack.MonitorCreateRequest) (betterstack.Monitor, error) {
+		return betterstack.Monitor{ID: "remote-2"}, nil
+	}
+	c, r := newAdoptionReconciler(t, service, monitor)
+
+	reconcileAdoption(t, r, monitor)
+
+	assert.Int(t, "list calls", service.ListCalls, 0)
+	assert.Int(t, "create calls", service.CreateCalls, 1)
+	updated := fetchAdoptionMonitor(t, c, monitor)
+	assert.String(t, "monitor id", updated.Status.MonitorID, "remote-2")
+	assert.Bool(t, "adopted", updated.Status.Adopted, false)
+}
+
+func TestReconcileFailsOnManagedRemoteMonitor(t *testing.T) {
+	for _, strategy := range []string{"", monitoringv1alpha1.OnConflictFail} {
+		t.Run("onConflict="+strategy, func(t *testing.T) {
+			monitor := newAdoptionMonitor("api", strategy)
+			service := adoptionMonitorService()
+			c, r := newAdoptionReconciler(t, service, monitor, newAdoptionOwner())
+
+			res := reconcileAdoption(t, r, monitor)
+
+			assert.Equal(t, "requeueAfter", res.RequeueAfter, requeueIntervalOnError)
+			assert.Int(t, "create calls", service.CreateCalls, 0)
+			assert.Int(t, "update calls", service.UpdateCalls, 0)
+			updated := fetchAdoptionMonitor(t, c, monitor)
+			assert.String(t, "monitor id", updated.Status.MonitorID, "")
+			cond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionSync)
+			assert.NotNil(t, "sync condition", cond)
+			assert.String(t, "sync reason", cond.Reason, monitoringv1alpha1.ReasonRemoteConflict)
+			assert.String(t, "sync message", cond.Message, "Remote monitor for https://example.com is already managed by other/owner; set spec.onConflict to AdoptAnyway or Rename")
+		})
+	}
+}
+
+func TestReconcileAdoptsManagedRemoteMonitorAnyway(t *testing.T) {
+	monitor := newAdoptionMonitor("api", monitoringv1alpha1.OnConflictAdoptAnyway)
+	owner := newAdoptionOwner()
+	owner.Namespace = monitor.Namespace
+	service := adoptionMonitorService()
+	c, r := newAdoptionReconciler(t, service, monitor, owner)
+
+	reconcileAdoption(t, r, monitor)
+
+	assert.Int(t, "create calls", service.CreateCalls, 0)
+	assert.Int(t, "update calls", service.UpdateCalls, 1)
+	updated := fetchAdoptionMonitor(t, c, monitor)
+	assert.String(t, "monitor id", updated.Status.MonitorID, "remote-1")
+	assert.Bool(t, "adopted", updated.Status.Adopted, true)
+}
+
+func TestReconcileAdoptAnywayRefusesOtherNamespace(t *testing.T) {
+	monitor := newAdoptionMonitor("api", monitoringv1alpha1.OnConflictAdoptAnyway)
+	monitor.Spec.ExistingMonitorID = "remote-1"
+	service := adoptionMonitorService()
+	c, r := newAdoptionReconciler(t, service, monitor, newAdoptionOwner())
+
+	res := reconcileAdoption(t, r, monitor)
+
+	assert.Equal(t, "requeueAfter", res.RequeueAfter, requeueIntervalOnError)
+	assert.Int(t, "update calls", service.UpdateCalls, 0)
+	updated := fetchAdoptionMonitor(t, c, monitor)
+	assert.String(t, "monitor id", updated.Status.MonitorID, "")
+	cond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionSync)
+	assert.NotNil(t, "sync condition", cond)
+	assert.String(t, "sync reason", cond.Reason, monitoringv1alpha1.ReasonRemoteIDClaimed)
+}
+
+func TestReconcileRenamesOnManagedRemoteMonitor(t *testing.T) {
+	monitor := newAdoptionMonitor("api", monitoringv1alpha1.OnConflictRename)
+	service := adoptionMonitorService()
+	service.CreateFn = func(ctx context.Context, req betterstack.MonitorCreateRequest) (betterstack.Monitor, error) {
+		return betterstack.Monitor{ID: "remote-2"}, nil
+	}
+	c, r := newAdoptionReconciler(t, service, monitor, newAdoptionOwner())
+
+	reconcileAdoption(t, r, monitor)
+
+	assert.Int(t, "create calls", service.CreateCalls, 1)
+	assert.EqualPtr(t, "created name", service.LastCreateReq.PronounceableName, "API (default/api)")
+	updated := fetchAdoptionMonitor(t, c, monitor)
+	assert.String(t, "monitor id", updated.Status.MonitorID, "remote-2")
+	assert.String(t, "remote name", updated.Status.RemoteName, "API (default/api)")
+
+	// Later reconciles keep the renamed monitor's name rather than reverting to spec.name.
+	reconcileAdoption(t, r, monitor)
+
+	assert.Int(t, "create calls", service.CreateCalls, 1)
+	assert.EqualPtr(t, "updated name", service.LastUpdateReq.PronounceableName, "API (default/api)")
+	updated = fetchAdoptionMonitor(t, c, monitor)
+	assert.String(t, "remote name", updated.Status.RemoteName, "API (default/api)")
+
+	// Renaming the resource drops the derived name, so the new spec.name is sent.
+	updated.Spec.Name = "Public API"
+	assert.NoError(t, c.Update(context.Background(), updated), "rename monitor")
+	reconcileAdoption(t, r, monitor)
+
+	assert.EqualPtr(t, "renamed name", service.LastUpdateReq.PronounceableName, "Public API")
+	updated = fetchAdoptionMonitor(t, c, monitor)
+	assert.String(t, "remote name", updated.Status.RemoteName, "")
+}
+
+func TestReconcileAdoptionKeepsPreparedRequest(t *testing.T) {
+	monitor := newAdoptionMonitor("api", "")
+	monitor.Spec.RequestHeaders = []monitoringv1alpha1.BetterStackHeader{{Name: "X-Env", Value: "prod"}}
+	// A route removed while the remote monitor was gone still has its fields cleared.
+	monitor.Status.AlertRouteFields = []string{"policy_id"}
+	service := adoptionMonitorService()
+	service.ListFn = func(ctx context.Context) ([]betterstack.Monitor, error) {
+		return []betterstack.Monitor{{ID: "remote-1", Attributes: betterstack.MonitorAttributes{
+			URL:               "https://example.com",
+			PronounceableName: "API",
+			RequestHeaders:    []betterstack.MonitorHeader{{ID: "7", Name: "x-env", Value: "staging"}},
+		}}}, nil
+	}
+	c, r := newAdoptionReconciler(t, service, monitor)
+	r.AlertRoutes = true
+
+	reconcileAdoption(t, r, monitor)
+
+	assert.Int(t, "update calls", service.UpdateCalls, 1)
+	sent := service.LastUpdateReq
+	assert.Int(t, "request headers", len(sent.RequestHeaders), 1)
+	assert.EqualPtr(t, "header id", sent.RequestHeaders[0].ID, "7")
+	policy, ok := sent.AdditionalAttributes["policy_id"]
+	assert.Bool(t, "policy_id cleared", ok, true)
+	assert.Nil(t, "policy_id", policy)
+	updated := fetchAdoptionMonitor(t, c, monitor)
+	assert.Bool(t, "adopted", updated.Status.Adopted, true)
+	assert.Int(t, "alert route fields", len(updated.Status.AlertRouteFields), 0)
+}
+
+func TestReconcileHandlesAdoptionListError(t *testing.T) {
+	monitor := newAdoptionMonitor("api", "")
+	service := &betterstackfakes.MonitorClient{
+		ListFn: func(ctx context.Context) ([]betterstack.Monitor, error) {
+			return nil, &betterstack.APIError{StatusCode: 500}
+		},
+	}
+	c, r := newAdoptionReconciler(t, service, monitor)
+
+	res := reconcileAdoption(t, r, monitor)
+
+	assert.Equal(t, "requeueAfter", res.RequeueAfter, requeueIntervalOnError)
+	assert.Int(t, "create calls", service.CreateCalls, 0)
+	updated := fetchAdoptionMonitor(t, c, monitor)
+	cond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionSync)
+	assert.NotNil(t, "sync condition", cond)
+	assert.String(t, "sync reason", cond.Reason, monitoringv1alpha1.ReasonSyncFailed)
+}
+
+func TestReconcileFailedAdoptionForgetsCachedMonitor(t *testing.T) {
+	monitor := newAdoptionMonitor("api", "")
+	service := adoptionMonitorService()
+	service.UpdateFn = func(ctx context.Context, id string, req betterstack.MonitorUpdateRequest) (betterstack.Monitor, error) {
+		return betterstack.Monitor{}, &betterstack.APIError{StatusCode: 500}
+	}
+	c, r := newAdoptionReconciler(t, service, monitor)
+	r.RemoteCacheTTL = time.Minute
+
+	ctx := context.Background()
+	account, err := resolveAccount(ctx, c, r.References, r.DefaultBaseURL, monitor.Namespace, monitor.Spec.AccountRef, monitor.Spec.APITokenSecretRef, monitor.Spec.BaseURL)
+	assert.NoError(t, err, "resolve account")
+	r.remote.put(account, betterstack.Monitor{ID: "remote-1"}, r.RemoteCacheTTL, time.Now())
+
+	reconcileAdoption(t, r, monitor)
+
+	assert.Int(t, "update calls", service.UpdateCalls, 1)
+	_, cached := r.remote.get(account, "remote-1", r.RemoteCacheTTL, time.Now())
+	assert.Bool(t, "cached", cached, false)
+}
+
+func TestDeleteAdoptedMonitorLeavesRemote(t *testing.T) {
+	monitor := newAdoptionMonitor("api", "")
+	monitor.Status = monitoringv1alpha1.BetterStackMonitorStatus{MonitorID: "remote-1", Adopted: true}
+	service := &betterstackfakes.MonitorClient{}
+	c, r := newAdoptionReconciler(t, service, monitor)
+	assert.NoError(t, c.Delete(context.Background(), monitor), "delete monitor")
+
+	reconcileAdoption(t, r, monitor)
+
+	assert.Int(t, "remote delete calls", service.DeleteCalls, 0)
+	err := c.Get(context.Background(), client.ObjectKeyFromObject(monitor), &monitoringv1alpha1.BetterStackMonitor{})
+	assert.Bool(t, "monitor removed", apierrors.IsNotFound(err), true)
+}
+
+func TestDeleteSharedMonitorLeavesRemote(t *testing.T) {
+	// The resource that created remote-1 is deleted while another one shares it.
+	monitor := newAdoptionMonitor("api", "")
+	monitor.Status = monitoringv1alpha1.BetterStackMonitorStatus{MonitorID: "remote-1"}
+	sharer := newAdoptionOwner()
+	sharer.Status.Adopted = true
+	service := &betterstackfakes.MonitorClient{}
+	c, r := newAdoptionReconciler(t, service, monitor, sharer)
+	assert.NoError(t, c.Delete(context.Background(), monitor), "delete monitor")
+
+	reconcileAdoption(t, r, monitor)
+
+	assert.Int(t, "remote delete calls", service.DeleteCalls, 0)
+
+	// Once the last resource using it is gone, a monitor the operator created is deleted.
+	creator := newAdoptionMonitor("creator", "")
+	creator.Status = monitoringv1alpha1.BetterStackMonitorStatus{MonitorID: "remote-9"}
+	c, r = newAdoptionReconciler(t, service, creator)
+	assert.NoError(t, c.Delete(context.Background(), creator), "delete creator")
+
+	reconcileAdoption(t, r, creator)
+
+	assert.Int(t, "remote delete calls", service.DeleteCalls, 1)
+}
+
+func newAdoptionMonitor(name, onConflict string) *monitoringv1alpha1.BetterStackMonitor {
+	return &monitoringv1alpha1.BetterStackMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Namespace:  "default",
+			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackMonitorSpec{
+			URL:        "https://example.com",
+			Name:       "API",
+			Adopt:      true,
+			OnConflict: onConflict,
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+		},
+	}
+}
+
+// newAdoptionOwner returns a monitor in another namespace that already manages remote-1.
+func newAdoptionOwner() *monitoringv1alpha1.BetterStackMonitor {
+	owner := newAdoptionMonitor("owner", "")
+	owner.Namespace = "other"
+	owner.Status.MonitorID = "remote-1"
+	return owner
+}
+
+// adoptionMonitorService lists a single remote monitor matching newAdoptionMonitor.
+func adoptionMonitorService() *betterstackfakes.MonitorClient {
+	return &betterstackfakes.MonitorClient{
+		ListFn: func(ctx context.Context) ([]betterstack.Monitor, error) {
+			return []betterstack.Monitor{
+				{ID: "remote-0", Attributes: betterstack.MonitorAttributes{URL: "https://example.com", PronounceableName: "Web"}},
+				{ID: "remote-1", Attributes: betterstack.MonitorAttributes{URL: "https://example.com/", PronounceableName: "API"}},
+			}, nil
+		},
+		UpdateFn: func(ctx context.Context, id string, req betterstack.MonitorUpdateRequest) (betterstack.Monitor, error) {
+			return betterstack.Monitor{ID: id}, nil
+		},
+	}
+}
+
+func newAdoptionReconciler(t *testing.T, service *betterstackfakes.MonitorClient, monitors ...*monitoringv1alpha1.BetterStackMonitor) (client.Client, *BetterStackMonitorReconciler) {
+	t.Helper()
+	scheme := controllertest.NewScheme(t)
+	builder := newIndexedClientBuilder(scheme).
+		WithObjects(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+			Data:       map[string][]byte{"token": []byte("abcd")},
+		})
+	for _, monitor := range monitors {
+		builder = builder.WithStatusSubresource(monitor).WithObjects(monitor.DeepCopy())
+	}
+	c := builder.Build()
+	return c, &BetterStackMonitorReconciler{
+		Client:  c,
+		Scheme:  scheme,
+		Clients: &fakeBetterStackMonitorClientFactory{monitor: service},
+	}
+}
+
+func reconcileAdoption(t *testing.T, r *BetterStackMonitorReconciler, monitor *monitoringv1alpha1.BetterStackMonitor) ctrl.Result {
+	t.Helper()
+	res, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}})
+	assert.NoError(t, err, "reconcile")
+	return res
+}
+
+func fetchAdoptionMonitor(t *testing.T, c client.Client, monitor *monitoringv1alpha1.BetterStackMonitor) *monitoringv1alpha1.BetterStackMonitor {
+	t.Helper()
+	updated := &monitoringv1alpha1.BetterStackMonitor{}
+	assert.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(monitor), updated), "fetch monitor")
+	return updated
+}
@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/pkg/betterstack"
+)
+
+// selectAlertRoute returns the route that applies to monitor: of the routes whose selector
+// matches the monitor's labels, the one with the highest priority, ties going to the
+// lowest name. Routes with an invalid selector never match.
+func selectAlertRoute(routes []monitoringv1alpha1.BetterStackAlertRoute, monitor *monitoringv1alpha1.BetterStackMonitor) *monitoringv1alpha1.BetterStackAlertRoute {
+	var selected *monitoringv1alpha1.BetterStackAlertRoute
+	for i := range routes {
+		route := &routes[i]
+		if !alertRouteMatches(route, monitor) {
+			continue
+		}
+		if selected == nil || route.Spec.Priority > selected.Spec.Priority ||
+			(route.Spec.Priority == selected.Spec.Priority && route.Name < selected.Name) {
+			selected = route
+		}
+	}
+	return selected
+}
+
+func alertRouteMatches(route *monitoringv1alpha1.BetterStackAlertRoute, monitor *monitoringv1alpha1.BetterStackMonitor) bool {
+	if route.Namespace != monitor.Namespace {
+		return false
+	}
+	selector, err := metav1.LabelSelectorAsSelector(&route.Spec.Selector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(monitor.Labels))
+}
+
+// applyAlertRoute fills the policy and contact settings spec leaves unset from route, so
+// a monitor can still override the route it is selected by. It returns the Better Stack
+// attributes it filled.
+func applyAlertRoute(spec *monitoringv1alpha1.BetterStackMonitorSpec, route *monitoringv1alpha1.BetterStackAlertRoute) []string {
+	var filled []string
+	if spec.PolicyID == "" && route.Spec.PolicyID != "" {
+		spec.PolicyID = route.Spec.PolicyID
+		filled = append(filled, "policy_id")
+	}
+	if spec.Email == nil && route.Spec.Email != nil {
+		spec.Email = route.Spec.Email
+		filled = append(filled, "email")
+	}
+	if spec.SMS == nil && route.Spec.SMS != nil {
+		spec.SMS = route.Spec.SMS
+		filled = append(filled, "sms")
+	}
+	if spec.Call == nil && route.Spec.Call != nil {
+		spec.Call = route.Spec.Call
+		filled = append(filled, "call")
+	}
+	if spec.Push == nil && route.Spec.Push != nil {
+		spec.Push = route.Spec.Push
+		filled = append(filled, "push")
+	}
+	if spec.CriticalAlert == nil && route.Spec.CriticalAlert != nil {
+		spec.CriticalAlert = route.Spec.CriticalAlert
+		filled = append(filled, "critical_alert")
+	}
+	return filled
+}
+
+// clearAlertRouteFields resets the attributes a route filled on the previous sync that
+// neither the current route nor the spec sets any more: the policy is removed and the
+// contact channels are turned off. Leaving them out of the request would keep the values
+// Better Stack last received.
+func clearAlertRouteFields(req *betterstack.MonitorCreateRequest, previous, current []string) {
+	for _, field := range previous {
+		if slices.Contains(current, field) {
+			continue
+		}
+		switch field {
+		case "policy_id":
+			if req.PolicyID == nil {
+				if req.AdditionalAttributes == nil {
+					req.AdditionalAttributes = map[string]any{}
+				}
+				if _, ok := req.AdditionalAttributes[field]; !ok {
+					req.AdditionalAttributes[field] = nil
+				}
+			}
+		case "email":
+			if req.Email == nil {
+				req.Email = ptr.To(false)
+			}
+		case "sms":
+			if req.SMS == nil {
+				req.SMS = ptr.To(false)
+			}
+		case "call":
+			if req.Call == nil {
+				req.Call = ptr.To(false)
+			}
+		case "push":
+			if req.Push == nil {
+				req.Push = ptr.To(false)
+			}
+		case "critical_alert":
+			if req.CriticalAlert == nil {
+				req.CriticalAlert = ptr.To(false)
+			}
+		}
+	}
+}
+
+// alertRouteFor returns the BetterStackAlertRoute that applies to monitor, or nil.
+func (r *BetterStackMonitorReconciler) alertRouteFor(ctx context.Context, monitor *monitoringv1alpha1.BetterStackMonitor) (*monitoringv1alpha1.BetterStackAlertRoute, error) {
+	var routes monitoringv1alpha1.BetterStackAlertRouteList
+	if err := r.List(ctx, &routes, client.InNamespace(monitor.Namespace)); err != nil {
+		return nil, fmt.Errorf("list alert routes: %w", err)
+	}
+	return selectAlertRoute(routes.Items, monitor), nil
+}
+
+// requestsForAlertRoute enqueues the monitors a route selects and those it was last
+// applied to, so edits and deletions of the route reach both.
+func (r *BetterStackMonitorReconciler) requestsForAlertRoute(ctx context.Context, obj client.Object) []reconcile.Request {
+	route, ok := obj.(*monitoringv1alpha1.BetterStackAlertRoute)
+	if !ok {
+		return nil
+	}
+	var monitors monitoringv1alpha1.BetterStackMonitorList
+	if err := r.List(ctx, &monitors, client.InNamespace(route.Namespace)); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list monitors for alert route", "route", route.Name)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range monitors.Items {
+		monitor := &monitors.Items[i]
+		if monitor.Status.AlertRoute == route.Name || alertRouteMatches(route, monitor) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: monitor.Namespace, Name: monitor.Name}})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/pkg/betterstack"
+)
+
+type apiWarningsKey struct{}
+
+// apiWarnings collects the deprecation notices returned while reconciling one resource.
+type apiWarnings struct {
+	mu    sync.Mutex
+	items []betterstack.Warning
+}
+
+// withAPIWarnings returns a context whose Better Stack requests record deprecation notices
+// into the returned collector.
+func withAPIWarnings(ctx context.Context) (context.Context, *apiWarnings) {
+	warnings := &apiWarnings{}
+	return context.WithValue(ctx, apiWarningsKey{}, warnings), warnings
+}
+
+// recordAPIWarning is the betterstack.WithWarningHandler callback used by the default
+// client factories.
+func recordAPIWarning(ctx context.Context, warning betterstack.Warning) {
+	warnings, ok := ctx.Value(apiWarningsKey{}).(*apiWarnings)
+	if !ok {
+		return
+	}
+	warnings.mu.Lock()
+	warnings.items = append(warnings.items, warning)
+	warnings.mu.Unlock()
+}
+
+// emit publishes each distinct notice as a Warning event on obj.
+func (w *apiWarnings) emit(recorder record.EventRecorder, obj runtime.Object) {
+	if recorder == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	seen := map[string]bool{}
+	for _, warning := range w.items {
+		message := fmt.Sprintf("Better Stack API %s %s: %s", warning.Method, warning.Path, warning.Message)
+		if warning.Sunset != "" {
+			message += fmt.Sprintf(" (sunset %s)", warning.Sunset)
+		}
+		if seen[message] {
+			continue
+		}
+		seen[message] = true
+		recorder.Event(obj, corev1.EventTypeWarning, monitoringv1alpha1.ReasonDeprecatedAPI, message)
+	}
+}
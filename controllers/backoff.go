@@ -0,0 +1,69 @@
+package controllers
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/internal/controller/retries"
+)
+
+// nextBackoff records another failed sync of generation at now. The delay doubles from
+// requeueIntervalOnError with each consecutive failure up to maxRequeueIntervalOnError; a
+// failure of a newer generation starts over.
+func nextBackoff(previous *monitoringv1alpha1.RetryBackoff, generation int64, now time.Time) *monitoringv1alpha1.RetryBackoff {
+	failures := 1
+	if previous != nil && previous.Generation == generation {
+		failures = previous.Failures + 1
+	}
+	return &monitoringv1alpha1.RetryBackoff{
+		Failures:      failures,
+		Generation:    generation,
+		NextRetryTime: metav1.NewTime(now.Add(backoffDelay(failures))),
+	}
+}
+
+func backoffDelay(failures int) time.Duration {
+	delay := requeueIntervalOnError
+	for i := 1; i < failures && delay < maxRequeueIntervalOnError; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRequeueIntervalOnError)
+}
+
+// backoffRemaining returns how long a resource of generation still waits before its next
+// sync. Events and restarts that arrive earlier requeue for the rest of the wait; editing
+// the spec retries immediately.
+func backoffRemaining(backoff *monitoringv1alpha1.RetryBackoff, generation int64, now time.Time) (time.Duration, bool) {
+	if backoff == nil || backoff.Generation != generation {
+		return 0, false
+	}
+	remaining := backoff.NextRetryTime.Sub(now)
+	return remaining, remaining > 0
+}
+
+// trackBackoff lists obj on the /backoff endpoint with the failure that caused backoff.
+func trackBackoff(tracker *retries.Tracker, kind string, obj client.Object, backoff *monitoringv1alpha1.RetryBackoff, reason, message string) {
+	tracker.Record(retries.Entry{
+		Kind:          kind,
+		Namespace:     obj.GetNamespace(),
+		Name:          obj.GetName(),
+		Failures:      backoff.Failures,
+		NextRetryTime: backoff.NextRetryTime.Time,
+		Reason:        reason,
+		LastError:     message,
+	})
+}
+
+// trackStoredBackoff lists obj with a backoff read back from status, as after a restart;
+// the failing Synced condition supplies the last error.
+func trackStoredBackoff(tracker *retries.Tracker, kind string, obj client.Object, backoff *monitoringv1alpha1.RetryBackoff, conditions []metav1.Condition) {
+	reason, message := "", ""
+	if synced := meta.FindStatusCondition(conditions, monitoringv1alpha1.ConditionSync); synced != nil && synced.Status == metav1.ConditionFalse {
+		reason, message = synced.Reason, synced.Message
+	}
+	trackBackoff(tracker, kind, obj, backoff, reason, message)
+}
@@ -0,0 +1,34 @@
+package controllers
+
+import (
+	"cmp"
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/internal/controller/credentials"
+	"loks0n/betterstack-operator/internal/controller/refs"
+	"loks0n/betterstack-operator/pkg/betterstack"
+)
+
+// resolveAccount is credentials.Resolve with the API endpoint settled: spec.baseURL, then
+// the credential's baseURL, then the operator's --default-base-url, then the public API.
+// Every controller resolves accounts this way so a resource is always synced, deleted and
+// reported against the same endpoint.
+func resolveAccount(ctx context.Context, c client.Client, policy refs.Policy, defaultBaseURL, namespace string, accountRef *monitoringv1alpha1.ResourceRef, selector corev1.SecretKeySelector, baseURL string) (credentials.Account, error) {
+	account, err := credentials.Resolve(ctx, c, policy, namespace, accountRef, selector, baseURL)
+	if err != nil {
+		return credentials.Account{}, err
+	}
+	account.BaseURL = effectiveBaseURL(account.BaseURL, defaultBaseURL)
+	return account, nil
+}
+
+// effectiveBaseURL returns the endpoint requests for baseURL go to, without a trailing
+// slash, falling back to defaultBaseURL and then the public API.
+func effectiveBaseURL(baseURL, defaultBaseURL string) string {
+	return strings.TrimSuffix(cmp.Or(baseURL, defaultBaseURL, betterstack.DefaultBaseURL), "/")
+}
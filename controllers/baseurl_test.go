@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/internal/testutil/assert"
+	"loks0n/betterstack-operator/internal/testutil/controllertest"
+	"loks0n/betterstack-operator/pkg/betterstack"
+)
+
+const operatorDefaultBaseURL = "https://operator.default/api/v2"
+
+// baseURLCases covers the precedence of spec.baseURL over --default-base-url and of that
+// over the public API.
+var baseURLCases = []struct {
+	name           string
+	specBaseURL    string
+	defaultBaseURL string
+	want           string
+}{
+	{name: "spec wins", specBaseURL: "https://spec.example/api/v2/", defaultBaseURL: operatorDefaultBaseURL, want: "https://spec.example/api/v2"},
+	{name: "operator default", defaultBaseURL: operatorDefaultBaseURL, want: operatorDefaultBaseURL},
+	{name: "public API", want: betterstack.DefaultBaseURL},
+}
+
+func baseURLTestClient(t *testing.T, obj ctrlclient.Object) ctrlclient.Client {
+	t.Helper()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+	return newIndexedClientBuilder(controllertest.NewScheme(t)).
+		WithStatusSubresource(obj).
+		WithObjects(obj, secret).
+		Build()
+}
+
+var baseURLTokenRef = corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "api"}, Key: "token"}
+
+func baseURLObjectMeta(finalizer string) metav1.ObjectMeta {
+	meta := metav1.ObjectMeta{Name: "example", Namespace: "default", Generation: 1}
+	if finalizer != "" {
+		meta.Finalizers = []string{finalizer}
+	}
+	return meta
+}
+
+func TestMonitorReconcileBaseURLPrecedence(t *testing.T) {
+	for _, tc := range baseURLCases {
+		t.Run(tc.name, func(t *testing.T) {
+			monitor := &monitoringv1alpha1.BetterStackMonitor{
+				ObjectMeta: baseURLObjectMeta(monitoringv1alpha1.BetterStackMonitorFinalizer),
+				Spec:       monitoringv1alpha1.BetterStackMonitorSpec{URL: "https://example.com", BaseURL: tc.specBaseURL, APITokenSecretRef: baseURLTokenRef},
+			}
+			client := baseURLTestClient(t, monitor)
+			factory := &fakeBetterStackMonitorClientFactory{}
+			r := &BetterStackMonitorReconciler{Client: client, Scheme: client.Scheme(), Clients: factory, DefaultBaseURL: tc.defaultBaseURL}
+
+			_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: ctrlclient.ObjectKeyFromObject(monitor)})
+			assert.NoError(t, err, "reconcile")
+			assert.String(t, "client base URL", factory.lastMonitorBaseURL, tc.want)
+
+			updated := &monitoringv1alpha1.BetterStackMonitor{}
+			assert.NoError(t, client.Get(context.Background(), ctrlclient.ObjectKeyFromObject(monitor), updated), "fetch monitor")
+			assert.String(t, "status base URL", updated.Status.BaseURL, tc.want)
+		})
+	}
+}
+
+func TestHeartbeatReconcileBaseURLPrecedence(t *testing.T) {
+	for _, tc := range baseURLCases {
+		t.Run(tc.name, func(t *testing.T) {
+			heartbeat := &monitoringv1alpha1.BetterStackHeartbeat{
+				ObjectMeta: baseURLObjectMeta(monitoringv1alpha1.BetterStackHeartbeatFinalizer),
+				Spec:       monitoringv1alpha1.BetterStackHeartbeatSpec{Name: "Example", PeriodSeconds: 60, BaseURL: tc.specBaseURL, APITokenSecretRef: baseURLTokenRef},
+			}
+			client := baseURLTestClient(t, heartbeat)
+			factory := &fakeBetterStackHeartbeatClientFactory{}
+			r := &BetterStackHeartbeatReconciler{Client: client, Scheme: client.Scheme(), Clients: factory, DefaultBaseURL: tc.defaultBaseURL}
+
+			_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: ctrlclient.ObjectKeyFromObject(heartbeat)})
+			assert.NoError(t, err, "reconcile")
+			assert.String(t, "client base URL", factory.lastHeartbeatBaseURL, tc.want)
+
+			updated := &monitoringv1alpha1.BetterStackHeartbeat{}
+			assert.NoError(t, client.Get(context.Background(), ctrlclient.ObjectKeyFromObject(heartbeat), updated), "fetch heartbeat")
+			assert.String(t, "status base URL", updated.Status.BaseURL, tc.want)
+		})
+	}
+}
+
+func TestMonitorGroupReconcileBaseURLPrecedence(t *testing.T) {
+	for _, tc := range baseURLCases {
+		t.Run(tc.name, func(t *testing.T) {
+			group := &monitoringv1alpha1.BetterStackMonitorGroup{
+				ObjectMeta: baseURLObjectMeta(monitoringv1alpha1.BetterStackMonitorGroupFinalizer),
+				Spec:       monitoringv1alpha1.BetterStackMonitorGroupSpec{Name: "Example", BaseURL: tc.specBaseURL, APITokenSecretRef: baseURLTokenRef},
+			}
+			client := baseURLTestClient(t, group)
+			factory := &fakeBetterStackMonitorGroupClientFactory{}
+			r := &BetterStackMonitorGroupReconciler{Client: client, Scheme: client.Scheme(), Clients: factory, DefaultBaseURL: tc.defaultBaseURL}
+
+			_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: ctrlclient.ObjectKeyFromObject(group)})
+			assert.NoError(t, err, "reconcile")
+			assert.String(t, "client base URL", factory.lastBaseURL, tc.want)
+
+			updated := &monitoringv1alpha1.BetterStackMonitorGroup{}
+			assert.NoError(t, client.Get(context.Background(), ctrlclient.ObjectKeyFromObject(group), updated), "fetch monitor group")
+			assert.String(t, "status base URL", updated.Status.BaseURL, tc.want)
+		})
+	}
+}
+
+func TestSyncReportReconcileBaseURLPrecedence(t *testing.T) {
+	for _, tc := range baseURLCases {
+		t.Run(tc.name, func(t *testing.T) {
+			report := &monitoringv1alpha1.BetterStackSyncReport{
+				ObjectMeta: baseURLObjectMeta(""),
+				Spec:       monitoringv1alpha1.BetterStackSyncReportSpec{BaseURL: tc.specBaseURL, APITokenSecretRef: baseURLTokenRef},
+			}
+			client := baseURLTestClient(t, report)
+			monitors := &fakeBetterStackMonitorClientFactory{}
+			heartbeats := &fakeBetterStackHeartbeatClientFactory{}
+			r := &BetterStackSyncReportReconciler{Client: client, Scheme: client.Scheme(), MonitorClients: monitors, HeartbeatClients: heartbeats, DefaultBaseURL: tc.defaultBaseURL}
+
+			_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: ctrlclient.ObjectKeyFromObject(report)})
+			assert.NoError(t, err, "reconcile")
+			assert.String(t, "monitor client base URL", monitors.lastMonitorBaseURL, tc.want)
+			assert.String(t, "heartbeat client base URL", heartbeats.lastHeartbeatBaseURL, tc.want)
+
+			updated := &monitoringv1alpha1.BetterStackSyncReport{}
+			assert.NoError(t, client.Get(context.Background(), ctrlclient.ObjectKeyFromObject(report), updated), "fetch sync report")
+			assert.String(t, "status base URL", updated.Status.BaseURL, tc.want)
+		})
+	}
+}
@@ -4,4 +4,8 @@ import "time"
 
 const (
 	requeueIntervalOnError = time.Minute
+
+	// maxRequeueIntervalOnError caps the delay between retries of a resource that keeps
+	// failing to sync.
+	maxRequeueIntervalOnError = 16 * time.Minute
 )
@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/internal/controller/conditions"
+)
+
+// BetterStackAlertRouteReconciler reports which monitors each BetterStackAlertRoute
+// applies to. The routes themselves are applied by the monitor reconciler, so a monitor
+// has a single writer in Better Stack.
+type BetterStackAlertRouteReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=monitoring.betterstack.io,resources=betterstackalertroutes,verbs=get;list;watch
+//+kubebuilder:rbac:groups=monitoring.betterstack.io,resources=betterstackalertroutes/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=monitoring.betterstack.io,resources=betterstackmonitors,verbs=get;list;watch
+
+func (r *BetterStackAlertRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	route := &monitoringv1alpha1.BetterStackAlertRoute{}
+	if err := r.Get(ctx, req.NamespacedName, route); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if _, err := metav1.LabelSelectorAsSelector(&route.Spec.Selector); err != nil {
+		return ctrl.Result{}, r.patchStatus(ctx, route, func(status *monitoringv1alpha1.BetterStackAlertRouteStatus) {
+			now := metav1.Now()
+			status.ObservedGeneration = route.Generation
+			status.Monitors = nil
+			status.MonitorCount = 0
+			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionFalse, monitoringv1alpha1.ReasonInvalidSelector, err.Error(), &now))
+		})
+	}
+
+	var routes monitoringv1alpha1.BetterStackAlertRouteList
+	if err := r.List(ctx, &routes, client.InNamespace(route.Namespace)); err != nil {
+		return ctrl.Result{}, err
+	}
+	var monitors monitoringv1alpha1.BetterStackMonitorList
+	if err := r.List(ctx, &monitors, client.InNamespace(route.Namespace)); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var names []string
+	for i := range monitors.Items {
+		if selected := selectAlertRoute(routes.Items, &monitors.Items[i]); selected != nil && selected.Name == route.Name {
+			names = append(names, monitors.Items[i].Name)
+		}
+	}
+	sort.Strings(names)
+
+	return ctrl.Result{}, r.patchStatus(ctx, route, func(status *monitoringv1alpha1.BetterStackAlertRouteStatus) {
+		now := metav1.Now()
+		status.ObservedGeneration = route.Generation
+		status.Monitors = names
+		status.MonitorCount = len(names)
+		status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionTrue, monitoringv1alpha1.ReasonRouteApplied, fmt.Sprintf("Route applies to %d monitors", len(names)), &now))
+	})
+}
+
+func (r *BetterStackAlertRouteReconciler) patchStatus(ctx context.Context, route *monitoringv1alpha1.BetterStackAlertRoute, mutate func(*monitoringv1alpha1.BetterStackAlertRouteStatus)) error {
+	return patchObjectStatus(ctx, r.Client, route, func(route *monitoringv1alpha1.BetterStackAlertRoute) *monitoringv1alpha1.BetterStackAlertRouteStatus {
+		return &route.Status
+	}, mutate)
+}
+
+// SetupWithManager sets up the controller with the Manager. Any route or monitor change
+// re-evaluates every route in its namespace, since routes of higher priority hide lower
+// ones.
+func (r *BetterStackAlertRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("betterstackalertroute").
+		Watches(&monitoringv1alpha1.BetterStackAlertRoute{}, handler.EnqueueRequestsFromMapFunc(r.requestsForNamespace)).
+		Watches(&monitoringv1alpha1.BetterStackMonitor{}, handler.EnqueueRequestsFromMapFunc(r.requestsForNamespace)).
+		Complete(r)
+}
+
+// requestsForNamespace enqueues every route in the namespace of obj.
+func (r *BetterStackAlertRouteReconciler) requestsForNamespace(ctx context.Context, obj client.Object) []reconcile.Request {
+	var routes monitoringv1alpha1.BetterStackAlertRouteList
+	if err := r.List(ctx, &routes, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list alert routes")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(routes.Items))
+	for _, route := range routes.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: route.Namespace, Name: route.Name}})
+	}
+	return requests
+}
@@ -0,0 +1,286 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/internal/testutil/assert"
+	"loks0n/betterstack-operator/internal/testutil/controllertest"
+	"loks0n/betterstack-operator/pkg/betterstack"
+	"loks0n/betterstack-operator/pkg/betterstack/betterstackfakes"
+)
+
+func alertRoute(name string, priority int, matchLabels map[string]string) monitoringv1alpha1.BetterStackAlertRoute {
+	return monitoringv1alpha1.BetterStackAlertRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: monitoringv1alpha1.BetterStackAlertRouteSpec{
+			Selector: metav1.LabelSelector{MatchLabels: matchLabels},
+			Priority: priority,
+			PolicyID: name + "-policy",
+		},
+	}
+}
+
+func TestSelectAlertRoute(t *testing.T) {
+	invalid := alertRoute("invalid", 100, nil)
+	invalid.Spec.Selector.MatchExpressions = []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Bogus"}}
+	otherNamespace := alertRoute("elsewhere", 100, nil)
+	otherNamespace.Namespace = "team-b"
+
+	routes := []monitoringv1alpha1.BetterStackAlertRoute{
+		alertRoute("everything", 0, nil),
+		alertRoute("critical-b", 10, map[string]string{"severity": "critical"}),
+		alertRoute("critical-a", 10, map[string]string{"severity": "critical"}),
+		alertRoute("payments", 5, map[string]string{"team": "payments"}),
+		invalid,
+		otherNamespace,
+	}
+
+	cases := []struct {
+		name   string
+		labels map[string]string
+		want   string
+	}{
+		{name: "fallback", labels: nil, want: "everything"},
+		{name: "higher priority", labels: map[string]string{"team": "payments"}, want: "payments"},
+		{name: "tie broken by name", labels: map[string]string{"team": "payments", "severity": "critical"}, want: "critical-a"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			monitor := &monitoringv1alpha1.BetterStackMonitor{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default", Labels: tc.labels}}
+			selected := selectAlertRoute(routes, monitor)
+			assert.NotNil(t, "selected route", selected)
+			assert.String(t, "selected route", selected.Name, tc.want)
+		})
+	}
+
+	monitor := &monitoringv1alpha1.BetterStackMonitor{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "team-c"}}
+	assert.Nil(t, "route in another namespace", selectAlertRoute(routes, monitor))
+}
+
+func TestApplyAlertRouteKeepsMonitorSettings(t *testing.T) {
+	route := alertRoute("critical", 0, nil)
+	route.Spec.Call = ptr.To(true)
+	route.Spec.SMS = ptr.To(true)
+
+	spec := monitoringv1alpha1.BetterStackMonitorSpec{PolicyID: "own-policy", SMS: ptr.To(false)}
+	filled := applyAlertRoute(&spec, &route)
+	assert.StringSlice(t, "filled", filled, []string{"call"})
+	assert.String(t, "policy", spec.PolicyID, "own-policy")
+	assert.EqualPtr(t, "sms", spec.SMS, false)
+	assert.EqualPtr(t, "call", spec.Call, true)
+	assert.Nil(t, "email", spec.Email)
+}
+
+func TestAlertRouteReconcileReportsMonitors(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	general := alertRoute("general", 0, map[string]string{"tier": "web"})
+	critical := alertRoute("critical", 10, map[string]string{"severity": "critical"})
+	monitors := []*monitoringv1alpha1.BetterStackMonitor{
+		{ObjectMeta: metav1.ObjectMeta{Name: "shop", Namespace: "default", Labels: map[string]string{"tier": "web"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "blog", Namespace: "default", Labels: map[string]string{"tier": "web"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "default", Labels: map[string]string{"tier": "web", "severity": "critical"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "team-b", Labels: map[string]string{"tier": "web"}}},
+	}
+
+	builder := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(&general, &critical).
+		WithObjects(general.DeepCopy(), critical.DeepCopy())
+	for _, monitor := range monitors {
+		builder = builder.WithObjects(monitor)
+	}
+	client := builder.Build()
+
+	r := &BetterStackAlertRouteReconciler{Client: client, Scheme: scheme}
+
+	ctx := context.Background()
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: general.Name, Namespace: general.Namespace}})
+	assert.NoError(t, err, "reconcile")
+
+	updated := &monitoringv1alpha1.BetterStackAlertRoute{}
+	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: general.Name, Namespace: general.Namespace}, updated), "fetch route")
+	assert.StringSlice(t, "monitors", updated.Status.Monitors, []string{"blog", "shop"})
+	assert.Int(t, "monitor count", updated.Status.MonitorCount, 2)
+
+	readyCond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionReady)
+	assert.NotNil(t, "ready condition", readyCond)
+	assert.Equal(t, "ready status", readyCond.Status, metav1.ConditionTrue)
+	assert.String(t, "ready reason", readyCond.Reason, monitoringv1alpha1.ReasonRouteApplied)
+}
+
+func TestAlertRouteReconcileRejectsInvalidSelector(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	route := alertRoute("broken", 0, nil)
+	route.Spec.Selector.MatchExpressions = []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Bogus"}}
+
+	client := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(&route).
+		WithObjects(route.DeepCopy()).
+		Build()
+
+	r := &BetterStackAlertRouteReconciler{Client: client, Scheme: scheme}
+
+	ctx := context.Background()
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: route.Name, Namespace: route.Namespace}})
+	assert.NoError(t, err, "reconcile")
+
+	updated := &monitoringv1alpha1.BetterStackAlertRoute{}
+	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: route.Name, Namespace: route.Namespace}, updated), "fetch route")
+	readyCond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionReady)
+	assert.NotNil(t, "ready condition", readyCond)
+	assert.Equal(t, "ready status", readyCond.Status, metav1.ConditionFalse)
+	assert.String(t, "ready reason", readyCond.Reason, monitoringv1alpha1.ReasonInvalidSelector)
+}
+
+func TestReconcileAppliesAlertRoute(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	monitor := &monitoringv1alpha1.BetterStackMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			Labels:     map[string]string{"severity": "critical"},
+			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackMonitorSpec{
+			URL:   "https://example.com",
+			Email: ptr.To(false),
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+			BaseURL: "https://api.test",
+		},
+	}
+	route := alertRoute("critical", 0, map[string]string{"severity": "critical"})
+	route.Spec.Call = ptr.To(true)
+	route.Spec.Email = ptr.To(true)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+
+	client := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(monitor).
+		WithObjects(monitor.DeepCopy(), route.DeepCopy(), secret.DeepCopy()).
+		Build()
+
+	var created betterstack.MonitorCreateRequest
+	service := &betterstackfakes.MonitorClient{
+		CreateFn: func(ctx context.Context, req betterstack.MonitorCreateRequest) (betterstack.Monitor, error) {
+			created = req
+			return betterstack.Monitor{ID: "remote-1"}, nil
+		},
+	}
+	r := &BetterStackMonitorReconciler{
+		Client:      client,
+		Scheme:      scheme,
+		Clients:     &fakeBetterStackMonitorClientFactory{monitor: service},
+		AlertRoutes: true,
+	}
+
+	ctx := context.Background()
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}})
+	assert.NoError(t, err, "reconcile")
+	assert.Int(t, "create calls", service.CreateCalls, 1)
+	assert.EqualPtr(t, "policy", created.PolicyID, "critical-policy")
+	assert.EqualPtr(t, "call", created.Call, true)
+	assert.EqualPtr(t, "email", created.Email, false)
+
+	updated := &monitoringv1alpha1.BetterStackMonitor{}
+	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}, updated), "fetch monitor")
+	assert.String(t, "alert route", updated.Status.AlertRoute, "critical")
+	assert.String(t, "spec policy", updated.Spec.PolicyID, "")
+}
+
+func TestReconcileClearsDeletedAlertRoute(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	monitor := &monitoringv1alpha1.BetterStackMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			Labels:     map[string]string{"severity": "critical"},
+			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackMonitorSpec{
+			URL:   "https://example.com",
+			Email: ptr.To(true),
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+			BaseURL: "https://api.test",
+		},
+	}
+	route := alertRoute("critical", 0, map[string]string{"severity": "critical"})
+	route.Spec.Call = ptr.To(true)
+	route.Spec.Email = ptr.To(false)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+
+	client := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(monitor).
+		WithObjects(monitor.DeepCopy(), route.DeepCopy(), secret.DeepCopy()).
+		Build()
+
+	var updated betterstack.MonitorUpdateRequest
+	service := &betterstackfakes.MonitorClient{
+		CreateFn: func(ctx context.Context, req betterstack.MonitorCreateRequest) (betterstack.Monitor, error) {
+			return betterstack.Monitor{ID: "remote-1"}, nil
+		},
+		GetFn: func(ctx context.Context, id string) (betterstack.Monitor, error) {
+			return betterstack.Monitor{ID: id}, nil
+		},
+		UpdateFn: func(ctx context.Context, id string, req betterstack.MonitorUpdateRequest) (betterstack.Monitor, error) {
+			updated = req
+			return betterstack.Monitor{ID: id}, nil
+		},
+	}
+	r := &BetterStackMonitorReconciler{
+		Client:      client,
+		Scheme:      scheme,
+		Clients:     &fakeBetterStackMonitorClientFactory{monitor: service},
+		AlertRoutes: true,
+	}
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile with route")
+	synced := &monitoringv1alpha1.BetterStackMonitor{}
+	assert.NoError(t, client.Get(ctx, key, synced), "fetch monitor")
+	assert.StringSlice(t, "alert route fields", synced.Status.AlertRouteFields, []string{"policy_id", "call"})
+
+	assert.NoError(t, client.Delete(ctx, route.DeepCopy()), "delete route")
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile without route")
+	assert.Int(t, "update calls", service.UpdateCalls, 1)
+	assert.EqualPtr(t, "call", updated.Call, false)
+	assert.EqualPtr(t, "email", updated.Email, true)
+	body, err := json.Marshal(updated)
+	assert.NoError(t, err, "marshal update")
+	var payload map[string]any
+	assert.NoError(t, json.Unmarshal(body, &payload), "decode update")
+	policy, ok := payload["policy_id"]
+	assert.Bool(t, "policy_id sent", ok, true)
+	assert.Nil(t, "policy_id", policy)
+
+	assert.NoError(t, client.Get(ctx, key, synced), "fetch monitor")
+	assert.String(t, "alert route", synced.Status.AlertRoute, "")
+	assert.Int(t, "alert route fields", len(synced.Status.AlertRouteFields), 0)
+}
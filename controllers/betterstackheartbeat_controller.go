@@ -6,25 +6,30 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"k8s.io/utils/ptr"
 
 	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/internal/controller/accounts"
 	"loks0n/betterstack-operator/internal/controller/conditions"
 	"loks0n/betterstack-operator/internal/controller/credentials"
+	"loks0n/betterstack-operator/internal/controller/metrics"
+	"loks0n/betterstack-operator/internal/controller/refs"
+	"loks0n/betterstack-operator/internal/controller/retries"
+	"loks0n/betterstack-operator/internal/controller/secretwatch"
+	"loks0n/betterstack-operator/internal/controller/shutdown"
 	"loks0n/betterstack-operator/pkg/betterstack"
 
-	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
-	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 // BetterStackHeartbeatClientFactory provides Better Stack API clients for reconcilers.
@@ -35,7 +40,7 @@ type BetterStackHeartbeatClientFactory interface {
 type defaultBetterStackHeartbeatClientFactory struct{}
 
 func (defaultBetterStackHeartbeatClientFactory) Heartbeat(baseURL, token string, httpClient *http.Client) betterstack.HeartbeatClient {
-	client := betterstack.NewClient(baseURL, token, httpClient)
+	client := betterstack.NewClient(baseURL, token, httpClient, betterstack.WithConnectionTrace(metrics.ObserveConnection), betterstack.WithWarningHandler(recordAPIWarning), betterstack.WithRequestObserver(recordAPIRequest))
 	return client.Heartbeats
 }
 
@@ -45,31 +50,63 @@ type BetterStackHeartbeatReconciler struct {
 	Scheme     *runtime.Scheme
 	HTTPClient *http.Client
 	Clients    BetterStackHeartbeatClientFactory
-}
+	Accounts   *accounts.Registry
+	Retries    *retries.Tracker
+	Recorder   record.EventRecorder
+	Drainer    *shutdown.Drainer
 
-const (
-	// ReasonHeartbeatQuotaExceeded marks a reconciliation failure caused by Better Stack heartbeat quota limits.
-	ReasonHeartbeatQuotaExceeded = "HeartbeatQuotaExceeded"
-)
+	// References decides whether accountRef may select a credential in another namespace.
+	References refs.Policy
 
-const heartbeatSecretIndexKey = "monitoring.betterstack.io/heartbeat-secret"
+	// ClusterName replaces $(CLUSTER_NAME) in spec.name and is recorded in status.clusterName.
+	ClusterName string
+
+	// DefaultBaseURL is the Better Stack API endpoint for resources whose spec and
+	// credential set no baseURL. Empty means the public API.
+	DefaultBaseURL string
+}
 
 //+kubebuilder:rbac:groups=monitoring.betterstack.io,resources=betterstackheartbeats,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=monitoring.betterstack.io,resources=betterstackheartbeats/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=monitoring.betterstack.io,resources=betterstackheartbeats/finalizers,verbs=update
+//+kubebuilder:rbac:groups=monitoring.betterstack.io,resources=betterstackcredentials,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=patch
+//+kubebuilder:rbac:groups=apps,resources=deployments;statefulsets;daemonsets,verbs=patch
 
 func (r *BetterStackHeartbeatReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, done, ok := r.Drainer.Begin(ctx)
+	if !ok {
+		return ctrl.Result{}, nil
+	}
+	defer done()
+
 	logger := log.FromContext(ctx)
 
 	heartbeat := &monitoringv1alpha1.BetterStackHeartbeat{}
 	if err := r.Get(ctx, req.NamespacedName, heartbeat); err != nil {
 		if apierrors.IsNotFound(err) {
+			r.Retries.Forget("heartbeat", req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{}, err
 	}
 
+	// Only a sync that fails again keeps the resource listed as backing off. Every other
+	// outcome, including giving up early on credentials or a claimed ID, drops it.
+	backingOff := false
+	defer func() {
+		if !backingOff {
+			r.Retries.Forget("heartbeat", req.NamespacedName)
+		}
+	}()
+
+	ctx = withReconcileTrace(ctx, heartbeat)
+	logger = log.FromContext(ctx)
+	ctx, warnings := withAPIWarnings(ctx)
+	defer warnings.emit(r.Recorder, heartbeat)
+
 	if heartbeat.DeletionTimestamp.IsZero() {
 		if !controllerutil.ContainsFinalizer(heartbeat, monitoringv1alpha1.BetterStackHeartbeatFinalizer) {
 			controllerutil.AddFinalizer(heartbeat, monitoringv1alpha1.BetterStackHeartbeatFinalizer)
@@ -82,27 +119,90 @@ func (r *BetterStackHeartbeatReconciler) Reconcile(ctx context.Context, req ctrl
 		return r.handleDelete(ctx, heartbeat)
 	}
 
-	token, err := credentials.FetchAPIToken(ctx, r.Client, heartbeat.Namespace, heartbeat.Spec.APITokenSecretRef)
+	if heartbeat.Spec.Suspend {
+		err := r.patchStatus(ctx, heartbeat, func(status *monitoringv1alpha1.BetterStackHeartbeatStatus) {
+			now := metav1.Now()
+			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionSuspended, metav1.ConditionTrue, monitoringv1alpha1.ReasonSuspended, "Reconciliation suspended via spec.suspend", &now))
+		})
+		return ctrl.Result{}, err
+	}
+	if meta.IsStatusConditionTrue(heartbeat.Status.Conditions, monitoringv1alpha1.ConditionSuspended) {
+		_ = r.patchStatus(ctx, heartbeat, func(status *monitoringv1alpha1.BetterStackHeartbeatStatus) {
+			now := metav1.Now()
+			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionSuspended, metav1.ConditionFalse, monitoringv1alpha1.ReasonResumed, "Reconciliation resumed", &now))
+		})
+	}
+
+	if wait, ok := backoffRemaining(heartbeat.Status.Backoff, heartbeat.Generation, time.Now()); ok {
+		logger.V(1).Info("backing off after failed syncs", "failures", heartbeat.Status.Backoff.Failures, "retryIn", wait)
+		backingOff = true
+		trackStoredBackoff(r.Retries, "heartbeat", heartbeat, heartbeat.Status.Backoff, heartbeat.Status.Conditions)
+		return ctrl.Result{RequeueAfter: wait}, nil
+	}
+
+	account, err := resolveAccount(ctx, r.Client, r.References, r.DefaultBaseURL, heartbeat.Namespace, heartbeat.Spec.AccountRef, heartbeat.Spec.APITokenSecretRef, heartbeat.Spec.BaseURL)
 	if err != nil {
 		logger.Error(err, "unable to fetch Better Stack API token")
 		_ = r.patchStatus(ctx, heartbeat, func(status *monitoringv1alpha1.BetterStackHeartbeatStatus) {
 			now := metav1.Now()
-			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionCredentials, metav1.ConditionFalse, "TokenUnavailable", err.Error(), &now))
-			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionFalse, "TokenUnavailable", "API credentials not available", &now))
+			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionCredentials, metav1.ConditionFalse, monitoringv1alpha1.ReasonTokenUnavailable, err.Error(), &now))
+			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionFalse, monitoringv1alpha1.ReasonTokenUnavailable, "API credentials not available", &now))
 		})
 		return ctrl.Result{RequeueAfter: requeueIntervalOnError}, nil
 	}
 
 	_ = r.patchStatus(ctx, heartbeat, func(status *monitoringv1alpha1.BetterStackHeartbeatStatus) {
 		now := metav1.Now()
-		status.SetCondition(conditions.New(monitoringv1alpha1.ConditionCredentials, metav1.ConditionTrue, "TokenResolved", fmt.Sprintf("Using secret %s/%s", heartbeat.Namespace, heartbeat.Spec.APITokenSecretRef.Name), &now))
+		status.SetCondition(conditions.New(monitoringv1alpha1.ConditionCredentials, metav1.ConditionTrue, monitoringv1alpha1.ReasonTokenResolved, fmt.Sprintf("Using %s", account.Source), &now))
 	})
 
-	service := r.heartbeatService(heartbeat.Spec.BaseURL, token)
-	request := buildHeartbeatRequest(heartbeat.Spec)
-
+	service := r.heartbeatService(account)
+	spec := heartbeat.Spec
+	spec.Name = expandClusterName(spec.Name, r.ClusterName)
+	request := buildHeartbeatRequest(spec)
+	resumeIn, timedPaused := timedPause(heartbeat.Spec.PausedUntil, time.Now())
+	if timedPaused {
+		request.Paused = ptr.To(true)
+	} else if heartbeat.Spec.PausedUntil != nil && request.Paused == nil && !heartbeat.Spec.PausedUntil.Equal(heartbeat.Status.ResumedPausedUntil) {
+		// Unpause explicitly once the timed pause expires; omitting paused would leave
+		// the remote heartbeat paused. It is sent once, so a pause made in Better Stack
+		// afterwards is left alone.
+		request.Paused = ptr.To(false)
+	}
+	transition := periodTransition(heartbeat, time.Now())
+	if transition != nil {
+		request.Grace = ptr.To(transition.GraceSeconds)
+	} else if heartbeat.Status.PeriodTransition != nil && request.Grace == nil {
+		// Send a zero grace explicitly; omitting it would keep the widened grace.
+		request.Grace = ptr.To(0)
+	}
+
+	// Keep a suffixed name while it still derives from spec.name; a changed spec.name is
+	// tried as-is again.
+	remoteName := ""
+	if heartbeat.Status.RemoteName != "" && heartbeat.Status.RemoteName == suffixedHeartbeatName(heartbeat.Namespace, spec.Name) {
+		remoteName = heartbeat.Status.RemoteName
+		request.Name = ptr.To(remoteName)
+	}
+
+	previousID := heartbeat.Status.HeartbeatID
 	var apiHeartbeat betterstack.Heartbeat
-	if heartbeat.Status.HeartbeatID != "" {
+	upToDate := false
+	if heartbeat.Status.HeartbeatID != "" && heartbeatSynced(heartbeat) {
+		// Secret events and resyncs reconcile unchanged heartbeats; a read that confirms
+		// the remote still matches avoids writing the same request again.
+		apiHeartbeat, upToDate, err = remoteHeartbeatMatches(ctx, service, heartbeat.Status.HeartbeatID, request)
+		if betterstack.IsNotFound(err) {
+			logger.Info("remote heartbeat missing, creating anew", "id", heartbeat.Status.HeartbeatID)
+			heartbeat.Status.HeartbeatID = ""
+		} else if err != nil {
+			logger.V(1).Info("unable to verify remote heartbeat, updating", "id", heartbeat.Status.HeartbeatID, "error", err.Error())
+		} else if upToDate {
+			logger.V(1).Info("remote heartbeat up to date, skipping update", "id", heartbeat.Status.HeartbeatID)
+		}
+		err = nil
+	}
+	if heartbeat.Status.HeartbeatID != "" && !upToDate {
 		apiHeartbeat, err = service.Update(ctx, heartbeat.Status.HeartbeatID, betterstack.HeartbeatUpdateRequest(request))
 		if betterstack.IsNotFound(err) {
 			logger.Info("remote heartbeat missing, creating anew", "id", heartbeat.Status.HeartbeatID)
@@ -113,39 +213,155 @@ func (r *BetterStackHeartbeatReconciler) Reconcile(ctx context.Context, req ctrl
 
 	if err == nil && heartbeat.Status.HeartbeatID == "" {
 		apiHeartbeat, err = service.Create(ctx, request)
+		if isHeartbeatNameConflict(err) && remoteName == "" && heartbeat.Spec.NameConflictStrategy == monitoringv1alpha1.NameConflictSuffix {
+			remoteName = suffixedHeartbeatName(heartbeat.Namespace, spec.Name)
+			logger.Info("heartbeat name taken, retrying with suffix", "name", heartbeat.Spec.Name, "remoteName", remoteName)
+			request.Name = ptr.To(remoteName)
+			apiHeartbeat, err = service.Create(ctx, request)
+		}
 	}
 
 	if err != nil {
 		logger.Error(err, "unable to reconcile Better Stack heartbeat")
-		syncReason := "SyncFailed"
+		syncReason := monitoringv1alpha1.ReasonSyncFailed
 		syncMessage := err.Error()
 		readyMessage := "Heartbeat reconciliation failed"
 		if isHeartbeatQuotaExceeded(err) {
-			syncReason = ReasonHeartbeatQuotaExceeded
+			syncReason = monitoringv1alpha1.ReasonHeartbeatQuotaExceeded
 			syncMessage = "Better Stack heartbeat quota reached"
 			readyMessage = "Better Stack heartbeat quota reached"
+		} else if isHeartbeatNameConflict(err) {
+			syncReason = monitoringv1alpha1.ReasonNameConflict
+			syncMessage = fmt.Sprintf("Heartbeat name %q is already taken in Better Stack: %s", ptr.Deref(request.Name, ""), err.Error())
+			if heartbeat.Spec.NameConflictStrategy != monitoringv1alpha1.NameConflictSuffix {
+				syncMessage += "; set spec.nameConflictStrategy to Suffix or choose another name"
+			}
+			readyMessage = "Heartbeat name already taken"
 		}
+		backoff := nextBackoff(heartbeat.Status.Backoff, heartbeat.Generation, time.Now())
 		_ = r.patchStatus(ctx, heartbeat, func(status *monitoringv1alpha1.BetterStackHeartbeatStatus) {
 			now := metav1.Now()
+			status.Backoff = backoff
 			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionSync, metav1.ConditionFalse, syncReason, syncMessage, &now))
 			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionFalse, syncReason, readyMessage, &now))
 		})
-		return ctrl.Result{RequeueAfter: requeueIntervalOnError}, nil
+		backingOff = true
+		trackBackoff(r.Retries, "heartbeat", heartbeat, backoff, syncReason, syncMessage)
+		return ctrl.Result{RequeueAfter: backoffDelay(backoff.Failures)}, nil
+	}
+
+	var annotateErr error
+	annotated := heartbeat.Status.URLAnnotation
+	if target := heartbeat.Spec.URLAnnotation; target != nil && apiHeartbeat.Attributes.URL == "" {
+		annotateErr = errors.New("heartbeat URL missing from Better Stack response")
+	} else if target != nil || annotated != nil {
+		annotated, annotateErr = syncHeartbeatURLAnnotation(ctx, r.Client, heartbeat.Namespace, annotated, target, apiHeartbeat.Attributes.URL)
+	}
+	if annotateErr != nil {
+		logger.Error(annotateErr, "unable to annotate heartbeat URL")
+	}
+
+	if previousID != "" && previousID != apiHeartbeat.ID {
+		metrics.RemoteRecreated.WithLabelValues("heartbeat").Inc()
 	}
 
 	now := metav1.Now()
 	updateErr := r.patchStatus(ctx, heartbeat, func(status *monitoringv1alpha1.BetterStackHeartbeatStatus) {
 		status.HeartbeatID = apiHeartbeat.ID
+		status.RemoteName = remoteName
+		if apiHeartbeat.Attributes.TeamName != "" {
+			status.TeamName = apiHeartbeat.Attributes.TeamName
+		}
+		status.ClusterName = r.ClusterName
+		status.BaseURL = account.BaseURL
+		status.AppliedPeriodSeconds = heartbeat.Spec.PeriodSeconds
+		status.PeriodTransition = transition
+		status.ResumedPausedUntil = nil
+		if !timedPaused && heartbeat.Spec.PausedUntil != nil {
+			status.ResumedPausedUntil = heartbeat.Spec.PausedUntil.DeepCopy()
+		}
 		status.ObservedGeneration = heartbeat.Generation
 		status.LastSyncedTime = &now
-		status.SetCondition(conditions.New(monitoringv1alpha1.ConditionSync, metav1.ConditionTrue, "HeartbeatSynced", "Heartbeat synchronized with Better Stack", &now))
-		status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionTrue, "HeartbeatSynced", "Heartbeat synchronized with Better Stack", &now))
+		status.Backoff = nil
+		status.SetCondition(conditions.New(monitoringv1alpha1.ConditionSync, metav1.ConditionTrue, monitoringv1alpha1.ReasonHeartbeatSynced, "Heartbeat synchronized with Better Stack", &now))
+		status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionTrue, monitoringv1alpha1.ReasonHeartbeatSynced, "Heartbeat synchronized with Better Stack", &now))
+		if heartbeat.Spec.VerifyPings {
+			status.SetCondition(pingsMissingCondition(apiHeartbeat.Attributes, &now))
+		}
+		status.URLAnnotation = annotated
+		if annotateErr != nil {
+			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionURLAnnotated, metav1.ConditionFalse, monitoringv1alpha1.ReasonAnnotationFailed, annotateErr.Error(), &now))
+		} else if target := heartbeat.Spec.URLAnnotation; target == nil {
+			meta.RemoveStatusCondition(&status.Conditions, monitoringv1alpha1.ConditionURLAnnotated)
+		} else {
+			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionURLAnnotated, metav1.ConditionTrue, monitoringv1alpha1.ReasonURLAnnotated, fmt.Sprintf("Heartbeat URL written to %s %s", target.Kind, target.Name), &now))
+		}
 	})
 	if updateErr != nil {
 		return ctrl.Result{}, updateErr
 	}
 
-	return ctrl.Result{}, nil
+	var result ctrl.Result
+	if heartbeat.Spec.VerifyPings {
+		result.RequeueAfter = pingVerificationInterval(heartbeat.Spec)
+	}
+	if timedPaused {
+		result = requeueBefore(result, resumeIn)
+	}
+	if transition != nil {
+		// Tighten the grace back to spec.graceSeconds once the transition ends.
+		result = requeueBefore(result, time.Until(transition.Until.Time))
+	}
+	if annotateErr != nil {
+		result = requeueBefore(result, requeueIntervalOnError)
+	}
+	return result, nil
+}
+
+// heartbeatSynced reports whether the current generation was already written to Better
+// Stack successfully.
+func heartbeatSynced(heartbeat *monitoringv1alpha1.BetterStackHeartbeat) bool {
+	return heartbeat.Status.ObservedGeneration == heartbeat.Generation &&
+		meta.IsStatusConditionTrue(heartbeat.Status.Conditions, monitoringv1alpha1.ConditionSync)
+}
+
+// remoteHeartbeatMatches fetches the remote heartbeat and reports whether request would
+// leave it unchanged, using the comparison of the request preview. Fields the API does not
+// return, such as paused, always count as changed.
+func remoteHeartbeatMatches(ctx context.Context, service betterstack.HeartbeatClient, id string, request betterstack.HeartbeatCreateRequest) (betterstack.Heartbeat, bool, error) {
+	remote, err := service.Get(ctx, id)
+	if err != nil {
+		return betterstack.Heartbeat{}, false, err
+	}
+	preview, err := newRequestPreview(id, request, remote.Attributes)
+	if err != nil {
+		return betterstack.Heartbeat{}, false, err
+	}
+	return remote, len(preview.Diff) == 0, nil
+}
+
+// pingsMissingCondition derives the PingsMissing condition from the remote heartbeat state.
+func pingsMissingCondition(attrs betterstack.HeartbeatAttributes, now *metav1.Time) metav1.Condition {
+	switch {
+	case attrs.IsPaused():
+		return conditions.New(monitoringv1alpha1.ConditionPingsMissing, metav1.ConditionFalse, monitoringv1alpha1.ReasonHeartbeatPaused, "Heartbeat is paused", now)
+	case attrs.Healthy():
+		return conditions.New(monitoringv1alpha1.ConditionPingsMissing, metav1.ConditionFalse, monitoringv1alpha1.ReasonHeartbeatUp, "Pings are arriving on schedule", now)
+	case attrs.Status == betterstack.HeartbeatStatusDown:
+		return conditions.New(monitoringv1alpha1.ConditionPingsMissing, metav1.ConditionTrue, monitoringv1alpha1.ReasonHeartbeatDown, "Better Stack has not received a ping within the expected period", now)
+	default:
+		return conditions.New(monitoringv1alpha1.ConditionPingsMissing, metav1.ConditionUnknown, monitoringv1alpha1.ReasonAwaitingFirstPing, "Heartbeat has not received its first ping", now)
+	}
+}
+
+// pingVerificationInterval re-checks a heartbeat once per expected ping cadence, never more
+// often than the error requeue interval.
+func pingVerificationInterval(spec monitoringv1alpha1.BetterStackHeartbeatSpec) time.Duration {
+	interval := time.Duration(spec.PeriodSeconds+spec.GraceSeconds) * time.Second
+	if interval < requeueIntervalOnError {
+		return requeueIntervalOnError
+	}
+	return interval
 }
 
 func (r *BetterStackHeartbeatReconciler) handleDelete(ctx context.Context, heartbeat *monitoringv1alpha1.BetterStackHeartbeat) (ctrl.Result, error) {
@@ -155,18 +371,35 @@ func (r *BetterStackHeartbeatReconciler) handleDelete(ctx context.Context, heart
 		return ctrl.Result{}, nil
 	}
 
+	// The spec target is cleaned up too, in case the last sync failed before recording it.
+	targets := []*monitoringv1alpha1.HeartbeatURLAnnotation{heartbeat.Status.URLAnnotation}
+	if desired := resolvedURLAnnotation(heartbeat.Spec.URLAnnotation); desired != nil && (heartbeat.Status.URLAnnotation == nil || *desired != *heartbeat.Status.URLAnnotation) {
+		targets = append(targets, desired)
+	}
+	for _, target := range targets {
+		if target == nil {
+			continue
+		}
+		if err := annotateHeartbeatURL(ctx, r.Client, heartbeat.Namespace, target, ""); err != nil && !apierrors.IsNotFound(err) {
+			logger.Error(err, "unable to remove heartbeat URL annotation", "kind", target.Kind, "name", target.Name)
+		}
+	}
+
 	if heartbeat.Status.HeartbeatID != "" {
-		token, err := credentials.FetchAPIToken(ctx, r.Client, heartbeat.Namespace, heartbeat.Spec.APITokenSecretRef)
+		account, err := resolveAccount(ctx, r.Client, r.References, r.DefaultBaseURL, heartbeat.Namespace, heartbeat.Spec.AccountRef, heartbeat.Spec.APITokenSecretRef, heartbeat.Spec.BaseURL)
 		if err != nil {
 			logger.Info("skipping remote heartbeat deletion due to missing credentials", "heartbeatID", heartbeat.Status.HeartbeatID, "error", err)
+			metrics.FinalizerSkippedRemoteDelete.WithLabelValues("heartbeat", metrics.SkipReasonCredentialsUnavailable).Inc()
 		} else {
-			service := r.heartbeatService(heartbeat.Spec.BaseURL, token)
+			service := r.heartbeatService(account)
 			if err := service.Delete(ctx, heartbeat.Status.HeartbeatID); err != nil && !betterstack.IsNotFound(err) {
 				logger.Error(err, "unable to delete Better Stack heartbeat", "heartbeatID", heartbeat.Status.HeartbeatID)
+				metrics.FinalizerSkippedRemoteDelete.WithLabelValues("heartbeat", metrics.SkipReasonDeleteFailed).Inc()
 			}
 		}
 	}
 
+	r.Retries.Forget("heartbeat", client.ObjectKeyFromObject(heartbeat))
 	controllerutil.RemoveFinalizer(heartbeat, monitoringv1alpha1.BetterStackHeartbeatFinalizer)
 	if err := r.Update(ctx, heartbeat); err != nil {
 		return ctrl.Result{}, err
@@ -175,9 +408,12 @@ func (r *BetterStackHeartbeatReconciler) handleDelete(ctx context.Context, heart
 }
 
 func (r *BetterStackHeartbeatReconciler) patchStatus(ctx context.Context, heartbeat *monitoringv1alpha1.BetterStackHeartbeat, mutate func(*monitoringv1alpha1.BetterStackHeartbeatStatus)) error {
-	base := heartbeat.DeepCopy()
-	mutate(&heartbeat.Status)
-	return r.Status().Patch(ctx, heartbeat, client.MergeFrom(base))
+	return patchObjectStatus(ctx, r.Client, heartbeat, func(heartbeat *monitoringv1alpha1.BetterStackHeartbeat) *monitoringv1alpha1.BetterStackHeartbeatStatus {
+		return &heartbeat.Status
+	}, func(status *monitoringv1alpha1.BetterStackHeartbeatStatus) {
+		mutate(status)
+		status.DebugTrace = debugTrace(ctx, status.DebugTrace, status.Conditions)
+	})
 }
 
 func buildHeartbeatRequest(spec monitoringv1alpha1.BetterStackHeartbeatSpec) betterstack.HeartbeatCreateRequest {
@@ -223,7 +459,7 @@ func buildHeartbeatRequest(spec monitoringv1alpha1.BetterStackHeartbeatSpec) bet
 		req.Paused = spec.Paused
 	}
 	if len(spec.MaintenanceDays) > 0 {
-		req.MaintenanceDays = append([]string(nil), spec.MaintenanceDays...)
+		req.MaintenanceDays = normalizeWeekdays(spec.MaintenanceDays)
 	}
 	if spec.MaintenanceFrom != "" {
 		req.MaintenanceFrom = ptr.To(spec.MaintenanceFrom)
@@ -242,33 +478,23 @@ func buildHeartbeatRequest(spec monitoringv1alpha1.BetterStackHeartbeatSpec) bet
 }
 
 func (r *BetterStackHeartbeatReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	ctx := context.Background()
-	if err := mgr.GetFieldIndexer().IndexField(ctx, &monitoringv1alpha1.BetterStackHeartbeat{}, heartbeatSecretIndexKey, func(obj client.Object) []string {
-		heartbeat, ok := obj.(*monitoringv1alpha1.BetterStackHeartbeat)
-		if !ok {
-			return nil
-		}
-		secretName := heartbeat.Spec.APITokenSecretRef.Name
-		if secretName == "" {
-			return nil
-		}
-		return []string{secretIndexValue(heartbeat.Namespace, secretName)}
-	}); err != nil {
+	builder, err := secretwatch.Watch(mgr, ctrl.NewControllerManagedBy(mgr).Named("betterstackheartbeat").Watches(&monitoringv1alpha1.BetterStackHeartbeat{}, enqueueSpecChangesFirst()), "heartbeat", &monitoringv1alpha1.BetterStackHeartbeat{}, func() client.ObjectList { return &monitoringv1alpha1.BetterStackHeartbeatList{} }, heartbeatCredentialRefs)
+	if err != nil {
 		return err
 	}
+	return builder.Complete(r)
+}
 
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&monitoringv1alpha1.BetterStackHeartbeat{}).
-		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.requestsForSecret)).
-		Complete(r)
+func heartbeatCredentialRefs(heartbeat *monitoringv1alpha1.BetterStackHeartbeat) ([]string, *monitoringv1alpha1.ResourceRef) {
+	return []string{heartbeat.Spec.APITokenSecretRef.Name}, heartbeat.Spec.AccountRef
 }
 
-func (r *BetterStackHeartbeatReconciler) heartbeatService(baseURL, token string) betterstack.HeartbeatClient {
+func (r *BetterStackHeartbeatReconciler) heartbeatService(account credentials.Account) betterstack.HeartbeatClient {
 	factory := r.Clients
 	if factory == nil {
 		factory = defaultBetterStackHeartbeatClientFactory{}
 	}
-	return factory.Heartbeat(baseURL, token, r.HTTPClient)
+	return factory.Heartbeat(account.BaseURL, account.Token, r.Accounts.HTTPClient(account, r.HTTPClient))
 }
 
 func isHeartbeatQuotaExceeded(err error) bool {
@@ -281,26 +507,3 @@ func isHeartbeatQuotaExceeded(err error) bool {
 	}
 	return strings.Contains(strings.ToLower(apiErr.Message), "quota")
 }
-
-func (r *BetterStackHeartbeatReconciler) requestsForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
-	secret, ok := obj.(*corev1.Secret)
-	if !ok {
-		return nil
-	}
-	if secret.Namespace == "" || secret.Name == "" {
-		return nil
-	}
-
-	secretKey := secretIndexValue(secret.Namespace, secret.Name)
-	list := &monitoringv1alpha1.BetterStackHeartbeatList{}
-	if err := r.List(ctx, list, client.InNamespace(secret.Namespace), client.MatchingFields{heartbeatSecretIndexKey: secretKey}); err != nil {
-		log.FromContext(ctx).Error(err, "unable to list heartbeats for secret", "secret", secretKey)
-		return nil
-	}
-
-	requests := make([]reconcile.Request, 0, len(list.Items))
-	for _, heartbeat := range list.Items {
-		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: heartbeat.Namespace, Name: heartbeat.Name}})
-	}
-	return requests
-}
@@ -10,6 +10,7 @@ import (
 
 	"k8s.io/utils/ptr"
 
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -19,9 +20,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/internal/controller/conditions"
 	"loks0n/betterstack-operator/internal/testutil/assert"
 	"loks0n/betterstack-operator/internal/testutil/controllertest"
 	"loks0n/betterstack-operator/pkg/betterstack"
+	"loks0n/betterstack-operator/pkg/betterstack/betterstackfakes"
 )
 
 type fakeBetterStackHeartbeatClientFactory struct {
@@ -36,72 +39,11 @@ func (f *fakeBetterStackHeartbeatClientFactory) Heartbeat(baseURL, token string,
 	f.lastHeartbeatBaseURL = baseURL
 	f.lastHeartbeatToken = token
 	if f.heartbeat == nil {
-		return &fakeHeartbeatService{}
+		return &betterstackfakes.HeartbeatClient{}
 	}
 	return f.heartbeat
 }
 
-type fakeHeartbeatService struct {
-	getFn    func(ctx context.Context, id string) (betterstack.Heartbeat, error)
-	updateFn func(ctx context.Context, id string, req betterstack.HeartbeatUpdateRequest) (betterstack.Heartbeat, error)
-	createFn func(ctx context.Context, req betterstack.HeartbeatCreateRequest) (betterstack.Heartbeat, error)
-	deleteFn func(ctx context.Context, id string) error
-	listFn   func(ctx context.Context) ([]betterstack.Heartbeat, error)
-
-	getCalls    int
-	updateCalls int
-	createCalls int
-	deleteCalls int
-	listCalls   int
-
-	lastUpdateReq betterstack.HeartbeatUpdateRequest
-	lastCreateReq betterstack.HeartbeatCreateRequest
-}
-
-func (s *fakeHeartbeatService) Get(ctx context.Context, id string) (betterstack.Heartbeat, error) {
-	s.getCalls++
-	if s.getFn != nil {
-		return s.getFn(ctx, id)
-	}
-	return betterstack.Heartbeat{}, nil
-}
-
-func (s *fakeHeartbeatService) Update(ctx context.Context, id string, req betterstack.HeartbeatUpdateRequest) (betterstack.Heartbeat, error) {
-	s.updateCalls++
-	s.lastUpdateReq = req
-	if s.updateFn != nil {
-		return s.updateFn(ctx, id, req)
-	}
-	return betterstack.Heartbeat{}, nil
-}
-
-func (s *fakeHeartbeatService) Create(ctx context.Context, req betterstack.HeartbeatCreateRequest) (betterstack.Heartbeat, error) {
-	s.createCalls++
-	s.lastCreateReq = req
-	if s.createFn != nil {
-		return s.createFn(ctx, req)
-	}
-	return betterstack.Heartbeat{}, nil
-}
-
-func (s *fakeHeartbeatService) Delete(ctx context.Context, id string) error {
-	s.deleteCalls++
-	if s.deleteFn != nil {
-		return s.deleteFn(ctx, id)
-	}
-	return nil
-}
-
-func (s *fakeHeartbeatService) List(ctx context.Context) ([]betterstack.Heartbeat, error) {
-	s.listCalls++
-	if s.listFn != nil {
-		return s.listFn(ctx)
-	}
-	return nil, nil
-}
-
-var _ betterstack.HeartbeatClient = (*fakeHeartbeatService)(nil)
-
 func TestHeartbeatReconcileAddsFinalizer(t *testing.T) {
 	scheme := controllertest.NewScheme(t)
 
@@ -177,11 +119,11 @@ func TestHeartbeatReconcileHandlesMissingCredentials(t *testing.T) {
 	creds := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionCredentials)
 	assert.NotNil(t, "credentials condition", creds)
 	assert.Equal(t, "credentials status", creds.Status, metav1.ConditionFalse)
-	assert.String(t, "credentials reason", creds.Reason, "TokenUnavailable")
+	assert.String(t, "credentials reason", creds.Reason, monitoringv1alpha1.ReasonTokenUnavailable)
 	ready := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionReady)
 	assert.NotNil(t, "ready condition", ready)
 	assert.Equal(t, "ready status", ready.Status, metav1.ConditionFalse)
-	assert.String(t, "ready reason", ready.Reason, "TokenUnavailable")
+	assert.String(t, "ready reason", ready.Reason, monitoringv1alpha1.ReasonTokenUnavailable)
 }
 
 func TestHeartbeatReconcileCreatesHeartbeatWhenRemoteMissing(t *testing.T) {
@@ -234,11 +176,11 @@ func TestHeartbeatReconcileCreatesHeartbeatWhenRemoteMissing(t *testing.T) {
 		Data:       map[string][]byte{"token": []byte("abcd")},
 	}
 
-	service := &fakeHeartbeatService{
-		updateFn: func(ctx context.Context, id string, req betterstack.HeartbeatUpdateRequest) (betterstack.Heartbeat, error) {
+	service := &betterstackfakes.HeartbeatClient{
+		UpdateFn: func(ctx context.Context, id string, req betterstack.HeartbeatUpdateRequest) (betterstack.Heartbeat, error) {
 			return betterstack.Heartbeat{}, &betterstack.APIError{StatusCode: http.StatusNotFound}
 		},
-		createFn: func(ctx context.Context, req betterstack.HeartbeatCreateRequest) (betterstack.Heartbeat, error) {
+		CreateFn: func(ctx context.Context, req betterstack.HeartbeatCreateRequest) (betterstack.Heartbeat, error) {
 			assert.NotNil(t, "request name", req.Name)
 			assert.String(t, "request name", *req.Name, "Example")
 			assert.NotNil(t, "request team", req.TeamName)
@@ -258,7 +200,7 @@ func TestHeartbeatReconcileCreatesHeartbeatWhenRemoteMissing(t *testing.T) {
 			assert.NotNil(t, "request paused", req.Paused)
 			assert.Bool(t, "request paused", *req.Paused, true)
 			assert.Int(t, "request maintenance days len", len(req.MaintenanceDays), 2)
-			return betterstack.Heartbeat{ID: "new-id"}, nil
+			return betterstack.Heartbeat{ID: "new-id", Attributes: betterstack.HeartbeatAttributes{TeamName: "SRE"}}, nil
 		},
 	}
 
@@ -280,15 +222,385 @@ func TestHeartbeatReconcileCreatesHeartbeatWhenRemoteMissing(t *testing.T) {
 	updated := &monitoringv1alpha1.BetterStackHeartbeat{}
 	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: heartbeat.Name, Namespace: heartbeat.Namespace}, updated), "fetch updated heartbeat")
 	assert.String(t, "heartbeat id", updated.Status.HeartbeatID, "new-id")
+	assert.String(t, "team name", updated.Status.TeamName, "SRE")
 	assert.Equal(t, "observed generation", updated.Status.ObservedGeneration, heartbeat.Generation)
 	ready := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionReady)
 	assert.NotNil(t, "ready condition", ready)
 	assert.Equal(t, "ready status", ready.Status, metav1.ConditionTrue)
-	assert.String(t, "ready reason", ready.Reason, "HeartbeatSynced")
+	assert.String(t, "ready reason", ready.Reason, monitoringv1alpha1.ReasonHeartbeatSynced)
 	assert.Int(t, "heartbeat factory calls", factory.heartbeatCalls, 1)
 	assert.String(t, "last token", factory.lastHeartbeatToken, "abcd")
 }
 
+func TestHeartbeatReconcileAnnotatesURL(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+	assert.NoError(t, batchv1.AddToScheme(scheme), "add batchv1 to scheme")
+
+	heartbeat := &monitoringv1alpha1.BetterStackHeartbeat{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			Finalizers: []string{monitoringv1alpha1.BetterStackHeartbeatFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackHeartbeatSpec{
+			Name:          "Example",
+			PeriodSeconds: 60,
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+			URLAnnotation: &monitoringv1alpha1.HeartbeatURLAnnotation{Kind: "CronJob", Name: "backup", PodTemplate: true},
+		},
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "default", Annotations: map[string]string{"team": "storage"}},
+		Spec:       batchv1.CronJobSpec{Schedule: "@daily"},
+	}
+
+	service := &betterstackfakes.HeartbeatClient{
+		CreateFn: func(ctx context.Context, req betterstack.HeartbeatCreateRequest) (betterstack.Heartbeat, error) {
+			return betterstack.Heartbeat{ID: "new-id", Attributes: betterstack.HeartbeatAttributes{URL: "https://uptime.betterstack.com/api/v1/heartbeat/abc"}}, nil
+		},
+	}
+
+	client := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(heartbeat).
+		WithObjects(heartbeat.DeepCopy(), secret.DeepCopy(), cronJob.DeepCopy()).
+		Build()
+
+	r := &BetterStackHeartbeatReconciler{Client: client, Scheme: scheme, Clients: &fakeBetterStackHeartbeatClientFactory{heartbeat: service}}
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: heartbeat.Name, Namespace: heartbeat.Namespace}
+	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile")
+	assert.Equal(t, "requeueAfter", res.RequeueAfter, time.Duration(0))
+
+	annotated := &batchv1.CronJob{}
+	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: "backup", Namespace: "default"}, annotated), "fetch cronjob")
+	assert.String(t, "cronjob annotation", annotated.Annotations[monitoringv1alpha1.DefaultHeartbeatURLAnnotation], "https://uptime.betterstack.com/api/v1/heartbeat/abc")
+	assert.String(t, "existing annotation", annotated.Annotations["team"], "storage")
+	assert.String(t, "pod template annotation", annotated.Spec.JobTemplate.Spec.Template.Annotations[monitoringv1alpha1.DefaultHeartbeatURLAnnotation], "https://uptime.betterstack.com/api/v1/heartbeat/abc")
+	assert.String(t, "schedule", annotated.Spec.Schedule, "@daily")
+
+	updated := &monitoringv1alpha1.BetterStackHeartbeat{}
+	assert.NoError(t, client.Get(ctx, key, updated), "fetch updated heartbeat")
+	cond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionURLAnnotated)
+	assert.NotNil(t, "url annotated condition", cond)
+	assert.Equal(t, "url annotated status", cond.Status, metav1.ConditionTrue)
+
+	assert.NoError(t, client.Delete(ctx, updated), "delete heartbeat")
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile deletion")
+	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: "backup", Namespace: "default"}, annotated), "fetch cronjob after deletion")
+	_, found := annotated.Annotations[monitoringv1alpha1.DefaultHeartbeatURLAnnotation]
+	assert.Bool(t, "annotation removed", found, false)
+	assert.String(t, "existing annotation kept", annotated.Annotations["team"], "storage")
+}
+
+func TestHeartbeatReconcileMovesURLAnnotation(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+	assert.NoError(t, batchv1.AddToScheme(scheme), "add batchv1 to scheme")
+
+	heartbeat := &monitoringv1alpha1.BetterStackHeartbeat{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			Finalizers: []string{monitoringv1alpha1.BetterStackHeartbeatFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackHeartbeatSpec{
+			Name:          "Example",
+			PeriodSeconds: 60,
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+			URLAnnotation: &monitoringv1alpha1.HeartbeatURLAnnotation{Kind: "CronJob", Name: "backup", PodTemplate: true},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+	cronJob := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "default"}}
+	otherJob := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: "restore", Namespace: "default"}}
+
+	const pingURL = "https://uptime.betterstack.com/api/v1/heartbeat/abc"
+	service := &betterstackfakes.HeartbeatClient{
+		CreateFn: func(ctx context.Context, req betterstack.HeartbeatCreateRequest) (betterstack.Heartbeat, error) {
+			return betterstack.Heartbeat{ID: "hb-1", Attributes: betterstack.HeartbeatAttributes{URL: pingURL}}, nil
+		},
+		GetFn: func(ctx context.Context, id string) (betterstack.Heartbeat, error) {
+			return betterstack.Heartbeat{ID: id, Attributes: betterstack.HeartbeatAttributes{URL: pingURL}}, nil
+		},
+		UpdateFn: func(ctx context.Context, id string, req betterstack.HeartbeatUpdateRequest) (betterstack.Heartbeat, error) {
+			return betterstack.Heartbeat{ID: id, Attributes: betterstack.HeartbeatAttributes{URL: pingURL}}, nil
+		},
+	}
+
+	client := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(heartbeat).
+		WithObjects(heartbeat.DeepCopy(), secret.DeepCopy(), cronJob.DeepCopy(), otherJob.DeepCopy()).
+		Build()
+	r := &BetterStackHeartbeatReconciler{Client: client, Scheme: scheme, Clients: &fakeBetterStackHeartbeatClientFactory{heartbeat: service}}
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: heartbeat.Name, Namespace: heartbeat.Namespace}
+	updateSpec := func(target *monitoringv1alpha1.HeartbeatURLAnnotation) {
+		t.Helper()
+		current := &monitoringv1alpha1.BetterStackHeartbeat{}
+		assert.NoError(t, client.Get(ctx, key, current), "fetch heartbeat")
+		current.Spec.URLAnnotation = target
+		assert.NoError(t, client.Update(ctx, current), "update heartbeat")
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		assert.NoError(t, err, "reconcile")
+	}
+	annotations := func(name string) (map[string]string, map[string]string) {
+		t.Helper()
+		job := &batchv1.CronJob{}
+		assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, job), "fetch cronjob")
+		return job.Annotations, job.Spec.JobTemplate.Spec.Template.Annotations
+	}
+
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile")
+
+	// Moving the annotation to another CronJob and key clears both levels on the first.
+	updateSpec(&monitoringv1alpha1.HeartbeatURLAnnotation{Kind: "CronJob", Name: "restore", Key: "betterstack.io/ping-url"})
+	job, template := annotations("backup")
+	_, found := job[monitoringv1alpha1.DefaultHeartbeatURLAnnotation]
+	assert.Bool(t, "old annotation", found, false)
+	_, found = template[monitoringv1alpha1.DefaultHeartbeatURLAnnotation]
+	assert.Bool(t, "old pod template annotation", found, false)
+	job, _ = annotations("restore")
+	assert.String(t, "new annotation", job["betterstack.io/ping-url"], pingURL)
+
+	updated := &monitoringv1alpha1.BetterStackHeartbeat{}
+	assert.NoError(t, client.Get(ctx, key, updated), "fetch heartbeat")
+	assert.NotNil(t, "status url annotation", updated.Status.URLAnnotation)
+	assert.Equal(t, "status url annotation", *updated.Status.URLAnnotation, monitoringv1alpha1.HeartbeatURLAnnotation{Kind: "CronJob", Name: "restore", Key: "betterstack.io/ping-url"})
+
+	// Removing spec.urlAnnotation removes the annotation it last wrote.
+	updateSpec(nil)
+	job, _ = annotations("restore")
+	_, found = job["betterstack.io/ping-url"]
+	assert.Bool(t, "removed annotation", found, false)
+	assert.NoError(t, client.Get(ctx, key, updated), "fetch heartbeat")
+	assert.Nil(t, "status url annotation", updated.Status.URLAnnotation)
+	assert.Nil(t, "url annotated condition", controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionURLAnnotated))
+
+	// Keys outside the betterstack.io/ prefix are refused.
+	updateSpec(&monitoringv1alpha1.HeartbeatURLAnnotation{Kind: "CronJob", Name: "restore", Key: "kubectl.kubernetes.io/last-applied-configuration"})
+	job, _ = annotations("restore")
+	_, found = job["kubectl.kubernetes.io/last-applied-configuration"]
+	assert.Bool(t, "foreign annotation", found, false)
+	assert.NoError(t, client.Get(ctx, key, updated), "fetch heartbeat")
+	cond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionURLAnnotated)
+	assert.NotNil(t, "url annotated condition", cond)
+	assert.Equal(t, "url annotated status", cond.Status, metav1.ConditionFalse)
+}
+
+func TestHeartbeatReconcileReportsMissingURLAnnotationTarget(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	heartbeat := &monitoringv1alpha1.BetterStackHeartbeat{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			Finalizers: []string{monitoringv1alpha1.BetterStackHeartbeatFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackHeartbeatSpec{
+			Name:          "Example",
+			PeriodSeconds: 60,
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+			URLAnnotation: &monitoringv1alpha1.HeartbeatURLAnnotation{Kind: "Deployment", Name: "worker"},
+		},
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+
+	service := &betterstackfakes.HeartbeatClient{
+		CreateFn: func(ctx context.Context, req betterstack.HeartbeatCreateRequest) (betterstack.Heartbeat, error) {
+			return betterstack.Heartbeat{ID: "new-id", Attributes: betterstack.HeartbeatAttributes{URL: "https://uptime.betterstack.com/api/v1/heartbeat/abc"}}, nil
+		},
+	}
+
+	client := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(heartbeat).
+		WithObjects(heartbeat.DeepCopy(), secret.DeepCopy()).
+		Build()
+
+	r := &BetterStackHeartbeatReconciler{Client: client, Scheme: scheme, Clients: &fakeBetterStackHeartbeatClientFactory{heartbeat: service}}
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: heartbeat.Name, Namespace: heartbeat.Namespace}
+	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile")
+	assert.Equal(t, "requeueAfter", res.RequeueAfter, requeueIntervalOnError)
+
+	updated := &monitoringv1alpha1.BetterStackHeartbeat{}
+	assert.NoError(t, client.Get(ctx, key, updated), "fetch updated heartbeat")
+	assert.String(t, "heartbeat id", updated.Status.HeartbeatID, "new-id")
+	cond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionURLAnnotated)
+	assert.NotNil(t, "url annotated condition", cond)
+	assert.Equal(t, "url annotated status", cond.Status, metav1.ConditionFalse)
+	assert.String(t, "url annotated reason", cond.Reason, monitoringv1alpha1.ReasonAnnotationFailed)
+}
+
+func TestHeartbeatReconcileReportsMissingPings(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	heartbeat := &monitoringv1alpha1.BetterStackHeartbeat{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			Finalizers: []string{monitoringv1alpha1.BetterStackHeartbeatFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackHeartbeatSpec{
+			Name:          "Nightly backup",
+			PeriodSeconds: 3600,
+			GraceSeconds:  300,
+			VerifyPings:   true,
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+		},
+		Status: monitoringv1alpha1.BetterStackHeartbeatStatus{
+			HeartbeatID: "remote-123",
+		},
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+
+	remoteStatus := betterstack.HeartbeatStatusDown
+	service := &betterstackfakes.HeartbeatClient{
+		UpdateFn: func(ctx context.Context, id string, req betterstack.HeartbeatUpdateRequest) (betterstack.Heartbeat, error) {
+			return betterstack.Heartbeat{ID: id, Attributes: betterstack.HeartbeatAttributes{Status: remoteStatus}}, nil
+		},
+	}
+
+	client := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(heartbeat).
+		WithObjects(heartbeat.DeepCopy(), secret.DeepCopy()).
+		Build()
+
+	r := &BetterStackHeartbeatReconciler{Client: client, Scheme: scheme, Clients: &fakeBetterStackHeartbeatClientFactory{heartbeat: service}}
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: heartbeat.Name, Namespace: heartbeat.Namespace}
+	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile")
+	assert.Equal(t, "requeueAfter", res.RequeueAfter, 3900*time.Second)
+
+	updated := &monitoringv1alpha1.BetterStackHeartbeat{}
+	assert.NoError(t, client.Get(ctx, key, updated), "fetch heartbeat")
+	missing := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionPingsMissing)
+	assert.NotNil(t, "pings missing condition", missing)
+	assert.Equal(t, "pings missing status", missing.Status, metav1.ConditionTrue)
+	assert.String(t, "pings missing reason", missing.Reason, monitoringv1alpha1.ReasonHeartbeatDown)
+
+	remoteStatus = betterstack.HeartbeatStatusUp
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile after recovery")
+
+	assert.NoError(t, client.Get(ctx, key, updated), "fetch recovered heartbeat")
+	missing = controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionPingsMissing)
+	assert.Equal(t, "pings missing status", missing.Status, metav1.ConditionFalse)
+	assert.String(t, "pings missing reason", missing.Reason, monitoringv1alpha1.ReasonHeartbeatUp)
+}
+
+func TestHeartbeatReconcilePausesUntilPausedUntil(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	until := metav1.NewTime(time.Now().Add(10 * time.Minute))
+	heartbeat := &monitoringv1alpha1.BetterStackHeartbeat{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			Finalizers: []string{monitoringv1alpha1.BetterStackHeartbeatFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackHeartbeatSpec{
+			Name:          "Nightly backup",
+			PeriodSeconds: 3600,
+			GraceSeconds:  300,
+			VerifyPings:   true,
+			PausedUntil:   &until,
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+		},
+		Status: monitoringv1alpha1.BetterStackHeartbeatStatus{
+			HeartbeatID: "remote-123",
+		},
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+
+	service := &betterstackfakes.HeartbeatClient{
+		UpdateFn: func(ctx context.Context, id string, req betterstack.HeartbeatUpdateRequest) (betterstack.Heartbeat, error) {
+			return betterstack.Heartbeat{ID: id}, nil
+		},
+	}
+
+	client := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(heartbeat).
+		WithObjects(heartbeat.DeepCopy(), secret.DeepCopy()).
+		Build()
+
+	r := &BetterStackHeartbeatReconciler{Client: client, Scheme: scheme, Clients: &fakeBetterStackHeartbeatClientFactory{heartbeat: service}}
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: heartbeat.Name, Namespace: heartbeat.Namespace}
+	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile while paused")
+	assert.EqualPtr(t, "paused", service.LastUpdateReq.Paused, true)
+	if res.RequeueAfter <= 0 || res.RequeueAfter > 10*time.Minute {
+		t.Fatalf("expected requeue before the pause expires, got %s", res.RequeueAfter)
+	}
+
+	expired := &monitoringv1alpha1.BetterStackHeartbeat{}
+	assert.NoError(t, client.Get(ctx, key, expired), "fetch heartbeat")
+	expired.Spec.PausedUntil = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+	assert.NoError(t, client.Update(ctx, expired), "expire pause")
+
+	res, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile after pause expired")
+	assert.EqualPtr(t, "paused", service.LastUpdateReq.Paused, false)
+	assert.Equal(t, "requeueAfter", res.RequeueAfter, 3900*time.Second)
+
+	assert.NoError(t, client.Get(ctx, key, expired), "fetch resumed heartbeat")
+	assert.NotNil(t, "resume recorded", expired.Status.ResumedPausedUntil)
+
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile after resume")
+	assert.Nil(t, "paused not resent", service.LastUpdateReq.Paused)
+}
+
 func TestHeartbeatReconcileHandlesUpdateError(t *testing.T) {
 	scheme := controllertest.NewScheme(t)
 
@@ -316,8 +628,8 @@ func TestHeartbeatReconcileHandlesUpdateError(t *testing.T) {
 		Data:       map[string][]byte{"token": []byte("abcd")},
 	}
 
-	service := &fakeHeartbeatService{
-		updateFn: func(ctx context.Context, id string, req betterstack.HeartbeatUpdateRequest) (betterstack.Heartbeat, error) {
+	service := &betterstackfakes.HeartbeatClient{
+		UpdateFn: func(ctx context.Context, id string, req betterstack.HeartbeatUpdateRequest) (betterstack.Heartbeat, error) {
 			return betterstack.Heartbeat{}, &betterstack.APIError{StatusCode: http.StatusInternalServerError, Message: "boom"}
 		},
 	}
@@ -342,11 +654,11 @@ func TestHeartbeatReconcileHandlesUpdateError(t *testing.T) {
 	syncCond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionSync)
 	assert.NotNil(t, "sync condition", syncCond)
 	assert.Equal(t, "sync status", syncCond.Status, metav1.ConditionFalse)
-	assert.String(t, "sync reason", syncCond.Reason, "SyncFailed")
+	assert.String(t, "sync reason", syncCond.Reason, monitoringv1alpha1.ReasonSyncFailed)
 	readyCond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionReady)
 	assert.NotNil(t, "ready condition", readyCond)
 	assert.Equal(t, "ready status", readyCond.Status, metav1.ConditionFalse)
-	assert.String(t, "ready reason", readyCond.Reason, "SyncFailed")
+	assert.String(t, "ready reason", readyCond.Reason, monitoringv1alpha1.ReasonSyncFailed)
 }
 
 func TestHeartbeatReconcileHandlesCreateError(t *testing.T) {
@@ -375,8 +687,8 @@ func TestHeartbeatReconcileHandlesCreateError(t *testing.T) {
 		Data:       map[string][]byte{"token": []byte("abcd")},
 	}
 
-	service := &fakeHeartbeatService{
-		createFn: func(ctx context.Context, req betterstack.HeartbeatCreateRequest) (betterstack.Heartbeat, error) {
+	service := &betterstackfakes.HeartbeatClient{
+		CreateFn: func(ctx context.Context, req betterstack.HeartbeatCreateRequest) (betterstack.Heartbeat, error) {
 			return betterstack.Heartbeat{}, &betterstack.APIError{StatusCode: http.StatusInternalServerError, Message: "boom"}
 		},
 	}
@@ -402,7 +714,7 @@ func TestHeartbeatReconcileHandlesCreateError(t *testing.T) {
 	syncCond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionSync)
 	assert.NotNil(t, "sync condition", syncCond)
 	assert.Equal(t, "sync status", syncCond.Status, metav1.ConditionFalse)
-	assert.String(t, "sync reason", syncCond.Reason, "SyncFailed")
+	assert.String(t, "sync reason", syncCond.Reason, monitoringv1alpha1.ReasonSyncFailed)
 }
 
 func TestHeartbeatReconcileHandlesQuotaExceeded(t *testing.T) {
@@ -431,8 +743,8 @@ func TestHeartbeatReconcileHandlesQuotaExceeded(t *testing.T) {
 		Data:       map[string][]byte{"token": []byte("abcd")},
 	}
 
-	service := &fakeHeartbeatService{
-		createFn: func(ctx context.Context, req betterstack.HeartbeatCreateRequest) (betterstack.Heartbeat, error) {
+	service := &betterstackfakes.HeartbeatClient{
+		CreateFn: func(ctx context.Context, req betterstack.HeartbeatCreateRequest) (betterstack.Heartbeat, error) {
 			return betterstack.Heartbeat{}, &betterstack.APIError{StatusCode: http.StatusForbidden, Message: "Heartbeat quota reached. Please upgrade your account."}
 		},
 	}
@@ -456,15 +768,99 @@ func TestHeartbeatReconcileHandlesQuotaExceeded(t *testing.T) {
 	syncCond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionSync)
 	assert.NotNil(t, "sync condition", syncCond)
 	assert.Equal(t, "sync status", syncCond.Status, metav1.ConditionFalse)
-	assert.String(t, "sync reason", syncCond.Reason, ReasonHeartbeatQuotaExceeded)
+	assert.String(t, "sync reason", syncCond.Reason, monitoringv1alpha1.ReasonHeartbeatQuotaExceeded)
 	assert.String(t, "sync message", syncCond.Message, "Better Stack heartbeat quota reached")
 	readyCond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionReady)
 	assert.NotNil(t, "ready condition", readyCond)
 	assert.Equal(t, "ready status", readyCond.Status, metav1.ConditionFalse)
-	assert.String(t, "ready reason", readyCond.Reason, ReasonHeartbeatQuotaExceeded)
+	assert.String(t, "ready reason", readyCond.Reason, monitoringv1alpha1.ReasonHeartbeatQuotaExceeded)
 	assert.String(t, "ready message", readyCond.Message, "Better Stack heartbeat quota reached")
 }
 
+func TestHeartbeatReconcileHandlesNameConflict(t *testing.T) {
+	suffixed := suffixedHeartbeatName("default", "Example")
+	cases := []struct {
+		strategy    string
+		wantCreates int
+		wantID      string
+		wantName    string
+		wantReason  string
+	}{
+		{strategy: "", wantCreates: 1, wantReason: monitoringv1alpha1.ReasonNameConflict},
+		{strategy: monitoringv1alpha1.NameConflictFail, wantCreates: 1, wantReason: monitoringv1alpha1.ReasonNameConflict},
+		{strategy: monitoringv1alpha1.NameConflictSuffix, wantCreates: 2, wantID: "hb-1", wantName: suffixed, wantReason: monitoringv1alpha1.ReasonHeartbeatSynced},
+	}
+	for _, tc := range cases {
+		t.Run("strategy="+tc.strategy, func(t *testing.T) {
+			scheme := controllertest.NewScheme(t)
+
+			heartbeat := &monitoringv1alpha1.BetterStackHeartbeat{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "example",
+					Namespace:  "default",
+					Finalizers: []string{monitoringv1alpha1.BetterStackHeartbeatFinalizer},
+				},
+				Spec: monitoringv1alpha1.BetterStackHeartbeatSpec{
+					Name:                 "Example",
+					PeriodSeconds:        60,
+					NameConflictStrategy: tc.strategy,
+					APITokenSecretRef: corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+						Key:                  "token",
+					},
+				},
+			}
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+				Data:       map[string][]byte{"token": []byte("abcd")},
+			}
+
+			service := &betterstackfakes.HeartbeatClient{
+				CreateFn: func(ctx context.Context, req betterstack.HeartbeatCreateRequest) (betterstack.Heartbeat, error) {
+					if *req.Name == "Example" {
+						return betterstack.Heartbeat{}, &betterstack.APIError{StatusCode: http.StatusUnprocessableEntity, Message: "Name has already been taken"}
+					}
+					return betterstack.Heartbeat{ID: "hb-1"}, nil
+				},
+				UpdateFn: func(ctx context.Context, id string, req betterstack.HeartbeatUpdateRequest) (betterstack.Heartbeat, error) {
+					return betterstack.Heartbeat{ID: id}, nil
+				},
+			}
+
+			client := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithStatusSubresource(heartbeat).
+				WithObjects(heartbeat.DeepCopy(), secret.DeepCopy()).
+				Build()
+
+			r := &BetterStackHeartbeatReconciler{Client: client, Scheme: scheme, Clients: &fakeBetterStackHeartbeatClientFactory{heartbeat: service}}
+
+			ctx := context.Background()
+			key := types.NamespacedName{Name: heartbeat.Name, Namespace: heartbeat.Namespace}
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			assert.NoError(t, err, "reconcile")
+			assert.Int(t, "create calls", service.CreateCalls, tc.wantCreates)
+
+			updated := &monitoringv1alpha1.BetterStackHeartbeat{}
+			assert.NoError(t, client.Get(ctx, key, updated), "fetch updated heartbeat")
+			assert.String(t, "heartbeat id", updated.Status.HeartbeatID, tc.wantID)
+			assert.String(t, "remote name", updated.Status.RemoteName, tc.wantName)
+			syncCond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionSync)
+			assert.NotNil(t, "sync condition", syncCond)
+			assert.String(t, "sync reason", syncCond.Reason, tc.wantReason)
+
+			if tc.wantID == "" {
+				return
+			}
+			// Updates keep the suffixed name instead of reverting to the taken one.
+			_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			assert.NoError(t, err, "reconcile again")
+			assert.EqualPtr(t, "updated name", service.LastUpdateReq.Name, tc.wantName)
+		})
+	}
+}
+
 func TestHeartbeatReconcileHandlesDeletion(t *testing.T) {
 	scheme := controllertest.NewScheme(t)
 
@@ -492,8 +888,8 @@ func TestHeartbeatReconcileHandlesDeletion(t *testing.T) {
 	}
 
 	deleted := false
-	service := &fakeHeartbeatService{
-		deleteFn: func(ctx context.Context, id string) error {
+	service := &betterstackfakes.HeartbeatClient{
+		DeleteFn: func(ctx context.Context, id string) error {
 			deleted = true
 			assert.String(t, "delete id", id, "remote-123")
 			return nil
@@ -592,8 +988,8 @@ func TestHeartbeatReconcileHandlesDeletionRemoteNotFound(t *testing.T) {
 		Data:       map[string][]byte{"token": []byte("abcd")},
 	}
 
-	service := &fakeHeartbeatService{
-		deleteFn: func(ctx context.Context, id string) error {
+	service := &betterstackfakes.HeartbeatClient{
+		DeleteFn: func(ctx context.Context, id string) error {
 			return &betterstack.APIError{StatusCode: http.StatusNotFound}
 		},
 	}
@@ -647,8 +1043,8 @@ func TestHeartbeatReconcileReturnsErrorWhenStatusPatchFails(t *testing.T) {
 		Data:       map[string][]byte{"token": []byte("abcd")},
 	}
 
-	service := &fakeHeartbeatService{
-		createFn: func(ctx context.Context, req betterstack.HeartbeatCreateRequest) (betterstack.Heartbeat, error) {
+	service := &betterstackfakes.HeartbeatClient{
+		CreateFn: func(ctx context.Context, req betterstack.HeartbeatCreateRequest) (betterstack.Heartbeat, error) {
 			return betterstack.Heartbeat{ID: "new-id"}, nil
 		},
 	}
@@ -730,3 +1126,173 @@ func TestBuildHeartbeatRequest(t *testing.T) {
 	diff := diffMaps(got, expected)
 	assert.String(t, "diff", fmt.Sprint(diff), "map[]")
 }
+
+func TestHeartbeatReconcileSkipsUpdateWhenRemoteUpToDate(t *testing.T) {
+	tests := []struct {
+		name        string
+		generation  int64
+		remote      betterstack.HeartbeatAttributes
+		wantGets    int
+		wantUpdates int
+	}{
+		{name: "unchanged", generation: 2, remote: betterstack.HeartbeatAttributes{Name: "Example", Period: 60}, wantGets: 1, wantUpdates: 0},
+		{name: "remote drifted", generation: 2, remote: betterstack.HeartbeatAttributes{Name: "Example", Period: 30}, wantGets: 1, wantUpdates: 1},
+		{name: "spec changed", generation: 3, remote: betterstack.HeartbeatAttributes{Name: "Example", Period: 60}, wantGets: 0, wantUpdates: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := controllertest.NewScheme(t)
+
+			now := metav1.Now()
+			heartbeat := &monitoringv1alpha1.BetterStackHeartbeat{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "example",
+					Namespace:  "default",
+					Generation: tt.generation,
+					Finalizers: []string{monitoringv1alpha1.BetterStackHeartbeatFinalizer},
+				},
+				Spec: monitoringv1alpha1.BetterStackHeartbeatSpec{
+					Name:          "Example",
+					PeriodSeconds: 60,
+					BaseURL:       "https://api.test",
+					APITokenSecretRef: corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+						Key:                  "token",
+					},
+				},
+				Status: monitoringv1alpha1.BetterStackHeartbeatStatus{
+					HeartbeatID:        "remote-123",
+					ObservedGeneration: 2,
+					Conditions: []metav1.Condition{
+						conditions.New(monitoringv1alpha1.ConditionSync, metav1.ConditionTrue, monitoringv1alpha1.ReasonHeartbeatSynced, "Heartbeat synchronized with Better Stack", &now),
+					},
+				},
+			}
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+				Data:       map[string][]byte{"token": []byte("abcd")},
+			}
+
+			service := &betterstackfakes.HeartbeatClient{
+				GetFn: func(ctx context.Context, id string) (betterstack.Heartbeat, error) {
+					return betterstack.Heartbeat{ID: id, Attributes: tt.remote}, nil
+				},
+				UpdateFn: func(ctx context.Context, id string, req betterstack.HeartbeatUpdateRequest) (betterstack.Heartbeat, error) {
+					return betterstack.Heartbeat{ID: id, Attributes: betterstack.HeartbeatAttributes{Name: "Example", Period: 60}}, nil
+				},
+			}
+			factory := &fakeBetterStackHeartbeatClientFactory{heartbeat: service}
+
+			client := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithStatusSubresource(heartbeat).
+				WithObjects(heartbeat.DeepCopy(), secret.DeepCopy()).
+				Build()
+
+			r := &BetterStackHeartbeatReconciler{Client: client, Scheme: scheme, Clients: factory}
+
+			ctx := context.Background()
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: heartbeat.Name, Namespace: heartbeat.Namespace}})
+			assert.NoError(t, err, "reconcile")
+			assert.Int(t, "get calls", service.GetCalls, tt.wantGets)
+			assert.Int(t, "update calls", service.UpdateCalls, tt.wantUpdates)
+			assert.Int(t, "create calls", service.CreateCalls, 0)
+
+			updated := &monitoringv1alpha1.BetterStackHeartbeat{}
+			assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: heartbeat.Name, Namespace: heartbeat.Namespace}, updated), "fetch updated heartbeat")
+			assert.String(t, "heartbeat id", updated.Status.HeartbeatID, "remote-123")
+			assert.Equal(t, "observed generation", updated.Status.ObservedGeneration, tt.generation)
+		})
+	}
+}
+
+func TestHeartbeatReconcileWidensGraceWhilePeriodShrinks(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	heartbeat := &monitoringv1alpha1.BetterStackHeartbeat{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			Generation: 2,
+			Finalizers: []string{monitoringv1alpha1.BetterStackHeartbeatFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackHeartbeatSpec{
+			Name:               "Example",
+			PeriodSeconds:      60,
+			GraceSeconds:       30,
+			PeriodChangePolicy: monitoringv1alpha1.PeriodChangeGraceThenApply,
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+		},
+		Status: monitoringv1alpha1.BetterStackHeartbeatStatus{
+			HeartbeatID:          "hb-1",
+			AppliedPeriodSeconds: 300,
+			ObservedGeneration:   1,
+		},
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+
+	service := &betterstackfakes.HeartbeatClient{
+		UpdateFn: func(ctx context.Context, id string, req betterstack.HeartbeatUpdateRequest) (betterstack.Heartbeat, error) {
+			return betterstack.Heartbeat{ID: id}, nil
+		},
+	}
+
+	client := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(heartbeat).
+		WithObjects(heartbeat.DeepCopy(), secret.DeepCopy()).
+		Build()
+
+	r := &BetterStackHeartbeatReconciler{Client: client, Scheme: scheme, Clients: &fakeBetterStackHeartbeatClientFactory{heartbeat: service}}
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: heartbeat.Name, Namespace: heartbeat.Namespace}
+	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile")
+	if res.RequeueAfter <= 5*time.Minute || res.RequeueAfter > 330*time.Second {
+		t.Fatalf("expected requeue at the end of the transition, got %s", res.RequeueAfter)
+	}
+	assert.NotNil(t, "period", service.LastUpdateReq.Period)
+	assert.Int(t, "period", *service.LastUpdateReq.Period, 60)
+	assert.NotNil(t, "grace", service.LastUpdateReq.Grace)
+	assert.Int(t, "widened grace", *service.LastUpdateReq.Grace, 270)
+
+	updated := &monitoringv1alpha1.BetterStackHeartbeat{}
+	assert.NoError(t, client.Get(ctx, key, updated), "fetch updated heartbeat")
+	assert.Int(t, "applied period", updated.Status.AppliedPeriodSeconds, 60)
+	assert.NotNil(t, "period transition", updated.Status.PeriodTransition)
+	assert.Int(t, "transition from", updated.Status.PeriodTransition.FromPeriodSeconds, 300)
+
+	// A reconcile during the transition keeps the widened grace.
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile during transition")
+	assert.Int(t, "grace during transition", *service.LastUpdateReq.Grace, 270)
+
+	assert.NoError(t, client.Get(ctx, key, updated), "refetch heartbeat")
+	updated.Status.PeriodTransition.Until = metav1.NewTime(time.Now().Add(-time.Second))
+	assert.NoError(t, client.Status().Update(ctx, updated), "expire transition")
+
+	res, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile after transition")
+	assert.Equal(t, "requeueAfter", res.RequeueAfter, time.Duration(0))
+	assert.Int(t, "tightened grace", *service.LastUpdateReq.Grace, 30)
+
+	assert.NoError(t, client.Get(ctx, key, updated), "fetch tightened heartbeat")
+	assert.Nil(t, "period transition", updated.Status.PeriodTransition)
+}
+
+func TestBuildHeartbeatRequestNormalizesMaintenanceDays(t *testing.T) {
+	req := buildHeartbeatRequest(monitoringv1alpha1.BetterStackHeartbeatSpec{
+		MaintenanceDays: []string{"Monday", "WED", "mon", " friday "},
+	})
+	assert.StringSlice(t, "maintenance days", req.MaintenanceDays, []string{"mon", "wed", "fri"})
+}
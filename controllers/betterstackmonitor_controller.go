@@ -8,25 +8,35 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"k8s.io/utils/ptr"
 
 	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/internal/controller/accounts"
 	"loks0n/betterstack-operator/internal/controller/conditions"
 	"loks0n/betterstack-operator/internal/controller/credentials"
+	"loks0n/betterstack-operator/internal/controller/maintenance"
+	"loks0n/betterstack-operator/internal/controller/metrics"
+	"loks0n/betterstack-operator/internal/controller/refs"
+	"loks0n/betterstack-operator/internal/controller/retries"
+	"loks0n/betterstack-operator/internal/controller/secretwatch"
+	"loks0n/betterstack-operator/internal/controller/shutdown"
 	"loks0n/betterstack-operator/pkg/betterstack"
 
-	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // BetterStackClientFactory provides Better Stack API clients for reconcilers.
@@ -37,7 +47,7 @@ type BetterStackMonitorClientFactory interface {
 type defaultBetterStackMonitorClientFactory struct{}
 
 func (defaultBetterStackMonitorClientFactory) Monitor(baseURL, token string, httpClient *http.Client) betterstack.MonitorClient {
-	client := betterstack.NewClient(baseURL, token, httpClient)
+	client := betterstack.NewClient(baseURL, token, httpClient, betterstack.WithConnectionTrace(metrics.ObserveConnection), betterstack.WithWarningHandler(recordAPIWarning), betterstack.WithRequestObserver(recordAPIRequest))
 	return client.Monitors
 }
 
@@ -47,29 +57,86 @@ type BetterStackMonitorReconciler struct {
 	Scheme     *runtime.Scheme
 	HTTPClient *http.Client
 	Clients    BetterStackMonitorClientFactory
-}
+	Accounts   *accounts.Registry
+	Retries    *retries.Tracker
+	Recorder   record.EventRecorder
 
-const (
-	monitorSecretIndexKey      = "monitoring.betterstack.io/monitor-secret"
-	ReasonMonitorQuotaExceeded = "MonitorQuotaExceeded"
-)
+	// References decides whether accountRef may select a credential in another namespace.
+	References refs.Policy
+
+	// Maintenance, when set, lets the operator-wide maintenance switch pause monitors.
+	Maintenance *maintenance.Switch
+
+	// Drainer, when set, lets in-flight reconciles finish during shutdown.
+	Drainer *shutdown.Drainer
+
+	// MonitorGroupClients looks up the group named by spec.monitorGroupID before a monitor
+	// is created. Defaults to the standard Better Stack client.
+	MonitorGroupClients BetterStackMonitorGroupClientFactory
+
+	// PreflightHTTPClient performs spec.preflightCheck requests. Defaults to a client with a
+	// ten second timeout.
+	PreflightHTTPClient *http.Client
+
+	// RemoteCacheTTL is how long the monitor returned by an update or create stands in for
+	// the remote Get on the next reconcile. Zero always fetches the remote monitor.
+	RemoteCacheTTL time.Duration
+
+	// ClusterName replaces $(CLUSTER_NAME) in spec.name and is recorded in status.clusterName.
+	ClusterName string
+
+	// DefaultBaseURL is the Better Stack API endpoint for resources whose spec and
+	// credential set no baseURL. Empty means the public API.
+	DefaultBaseURL string
+
+	// AlertRoutes applies the BetterStackAlertRoute selecting a monitor to the policy and
+	// contact settings its spec leaves unset.
+	AlertRoutes bool
+
+	regions regionCache
+	remote  remoteMonitorCache
+}
 
 //+kubebuilder:rbac:groups=monitoring.betterstack.io,resources=betterstackmonitors,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=monitoring.betterstack.io,resources=betterstackmonitors/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=monitoring.betterstack.io,resources=betterstackmonitors/finalizers,verbs=update
+//+kubebuilder:rbac:groups=monitoring.betterstack.io,resources=betterstackalertroutes,verbs=get;list;watch
+//+kubebuilder:rbac:groups=monitoring.betterstack.io,resources=betterstackcredentials,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *BetterStackMonitorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, done, ok := r.Drainer.Begin(ctx)
+	if !ok {
+		return ctrl.Result{}, nil
+	}
+	defer done()
+
 	logger := log.FromContext(ctx)
 
 	monitor := &monitoringv1alpha1.BetterStackMonitor{}
 	if err := r.Get(ctx, req.NamespacedName, monitor); err != nil {
 		if apierrors.IsNotFound(err) {
+			r.Retries.Forget("monitor", req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{}, err
 	}
 
+	// Only a sync that fails again keeps the resource listed as backing off. Every other
+	// outcome, including giving up early on credentials or a claimed ID, drops it.
+	backingOff := false
+	defer func() {
+		if !backingOff {
+			r.Retries.Forget("monitor", req.NamespacedName)
+		}
+	}()
+
+	ctx = withReconcileTrace(ctx, monitor)
+	logger = log.FromContext(ctx)
+	ctx, warnings := withAPIWarnings(ctx)
+	defer warnings.emit(r.Recorder, monitor)
+
 	if monitor.DeletionTimestamp.IsZero() {
 		if !controllerutil.ContainsFinalizer(monitor, monitoringv1alpha1.BetterStackMonitorFinalizer) {
 			controllerutil.AddFinalizer(monitor, monitoringv1alpha1.BetterStackMonitorFinalizer)
@@ -82,80 +149,335 @@ func (r *BetterStackMonitorReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return r.handleDelete(ctx, monitor)
 	}
 
-	token, err := credentials.FetchAPIToken(ctx, r.Client, monitor.Namespace, monitor.Spec.APITokenSecretRef)
+	if monitor.Spec.Suspend {
+		err := r.patchStatus(ctx, monitor, func(status *monitoringv1alpha1.BetterStackMonitorStatus) {
+			now := metav1.Now()
+			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionSuspended, metav1.ConditionTrue, monitoringv1alpha1.ReasonSuspended, "Reconciliation suspended via spec.suspend", &now))
+		})
+		return ctrl.Result{}, err
+	}
+	if meta.IsStatusConditionTrue(monitor.Status.Conditions, monitoringv1alpha1.ConditionSuspended) {
+		_ = r.patchStatus(ctx, monitor, func(status *monitoringv1alpha1.BetterStackMonitorStatus) {
+			now := metav1.Now()
+			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionSuspended, metav1.ConditionFalse, monitoringv1alpha1.ReasonResumed, "Reconciliation resumed", &now))
+		})
+	}
+
+	if wait, ok := backoffRemaining(monitor.Status.Backoff, monitor.Generation, time.Now()); ok {
+		logger.V(1).Info("backing off after failed syncs", "failures", monitor.Status.Backoff.Failures, "retryIn", wait)
+		backingOff = true
+		trackStoredBackoff(r.Retries, "monitor", monitor, monitor.Status.Backoff, monitor.Status.Conditions)
+		return ctrl.Result{RequeueAfter: wait}, nil
+	}
+
+	remoteID := monitor.Status.MonitorID
+	adoptedRemote := monitor.Status.Adopted
+	if remoteID == "" {
+		remoteID = monitor.Spec.ExistingMonitorID
+		adoptedRemote = remoteID != ""
+	}
+	if holder, ok, err := r.claimMonitorID(ctx, monitor, remoteID); err != nil {
+		return ctrl.Result{}, err
+	} else if !ok {
+		return r.reportClaimed(ctx, monitor, remoteID, holder)
+	}
+
+	account, err := resolveAccount(ctx, r.Client, r.References, r.DefaultBaseURL, monitor.Namespace, monitor.Spec.AccountRef, monitor.Spec.APITokenSecretRef, monitor.Spec.BaseURL)
 	if err != nil {
 		logger.Error(err, "unable to fetch Better Stack API token")
 		_ = r.patchStatus(ctx, monitor, func(status *monitoringv1alpha1.BetterStackMonitorStatus) {
 			now := metav1.Now()
-			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionCredentials, metav1.ConditionFalse, "TokenUnavailable", err.Error(), &now))
-			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionFalse, "TokenUnavailable", "API credentials not available", &now))
+			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionCredentials, metav1.ConditionFalse, monitoringv1alpha1.ReasonTokenUnavailable, err.Error(), &now))
+			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionFalse, monitoringv1alpha1.ReasonTokenUnavailable, "API credentials not available", &now))
 		})
 		return ctrl.Result{RequeueAfter: requeueIntervalOnError}, nil
 	}
 
 	_ = r.patchStatus(ctx, monitor, func(status *monitoringv1alpha1.BetterStackMonitorStatus) {
 		now := metav1.Now()
-		status.SetCondition(conditions.New(monitoringv1alpha1.ConditionCredentials, metav1.ConditionTrue, "TokenResolved", fmt.Sprintf("Using secret %s/%s", monitor.Namespace, monitor.Spec.APITokenSecretRef.Name), &now))
+		status.SetCondition(conditions.New(monitoringv1alpha1.ConditionCredentials, metav1.ConditionTrue, monitoringv1alpha1.ReasonTokenResolved, fmt.Sprintf("Using %s", account.Source), &now))
 	})
 
-	monitorAPI := r.monitorService(monitor.Spec.BaseURL, token)
+	monitorAPI := r.monitorService(account)
+
+	spec := monitor.Spec
+	spec.Name = expandClusterName(spec.Name, r.ClusterName)
+	alertRoute := ""
+	var alertRouteFields []string
+	if r.AlertRoutes {
+		route, err := r.alertRouteFor(ctx, monitor)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if route != nil {
+			alertRouteFields = applyAlertRoute(&spec, route)
+			alertRoute = route.Name
+		}
+	}
+	if spec.BearerTokenSecretRef != nil {
+		bearerToken, tokenErr := credentials.FetchAPIToken(ctx, r.Client, monitor.Namespace, *spec.BearerTokenSecretRef)
+		if tokenErr != nil {
+			logger.Error(tokenErr, "unable to fetch bearer token for monitor request header")
+			_ = r.patchStatus(ctx, monitor, func(status *monitoringv1alpha1.BetterStackMonitorStatus) {
+				now := metav1.Now()
+				status.SetCondition(conditions.New(monitoringv1alpha1.ConditionSync, metav1.ConditionFalse, monitoringv1alpha1.ReasonBearerTokenUnavailable, tokenErr.Error(), &now))
+				status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionFalse, monitoringv1alpha1.ReasonBearerTokenUnavailable, "Bearer token secret not available", &now))
+			})
+			return ctrl.Result{RequeueAfter: requeueIntervalOnError}, nil
+		}
+		spec.RequestHeaders = withBearerToken(spec.RequestHeaders, bearerToken)
+	}
 
 	var existingMonitor *betterstack.Monitor
-	if monitor.Status.MonitorID != "" {
-		existing, getErr := monitorAPI.Get(ctx, monitor.Status.MonitorID)
+	if cached, ok := r.remote.get(account, remoteID, r.RemoteCacheTTL, time.Now()); ok {
+		logger.V(1).Info("using cached remote monitor", "id", remoteID)
+		existingMonitor = &cached
+	} else if remoteID != "" {
+		existing, getErr := monitorAPI.Get(ctx, remoteID)
 		if getErr != nil && !betterstack.IsNotFound(getErr) {
-			logger.Error(getErr, "unable to fetch existing Better Stack monitor", "id", monitor.Status.MonitorID)
+			logger.Error(getErr, "unable to fetch existing Better Stack monitor", "id", remoteID)
+			// Header IDs come from the remote monitor; sending headers without them makes
+			// Better Stack add duplicates, so wait for a successful fetch instead.
+			if len(spec.RequestHeaders) > 0 {
+				_ = r.patchStatus(ctx, monitor, func(status *monitoringv1alpha1.BetterStackMonitorStatus) {
+					now := metav1.Now()
+					status.SetCondition(conditions.New(monitoringv1alpha1.ConditionSync, metav1.ConditionFalse, monitoringv1alpha1.ReasonRemoteFetchFailed, getErr.Error(), &now))
+					status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionFalse, monitoringv1alpha1.ReasonRemoteFetchFailed, "Unable to fetch remote monitor before updating request headers", &now))
+				})
+				return ctrl.Result{RequeueAfter: requeueIntervalOnError}, nil
+			}
 		} else if getErr == nil {
 			existingMonitor = &existing
 		}
 	}
-	request := buildMonitorRequest(monitor.Spec, existingMonitor)
+	request := buildMonitorRequest(spec, existingMonitor)
+	if r.AlertRoutes {
+		clearAlertRouteFields(&request, monitor.Status.AlertRouteFields, alertRouteFields)
+	}
+	// A name picked by onConflict: Rename is kept only while it still derives from spec.name,
+	// so renaming the monitor later is sent instead of the stored name.
+	remoteName := ""
+	if monitor.Status.RemoteName != "" && monitor.Status.RemoteName == renamedMonitorName(monitor, spec) {
+		remoteName = monitor.Status.RemoteName
+		request.PronounceableName = ptr.To(remoteName)
+	}
+	// The maintenance switch only overrides the request; once it ends the request carries
+	// spec.paused again, including any edits made during the window.
+	underMaintenance := r.Maintenance.Applies(monitor.Labels)
+	if underMaintenance {
+		request.Paused = ptr.To(true)
+	}
+	resumeIn, timedPaused := timedPause(spec.PausedUntil, time.Now())
+	if timedPaused {
+		request.Paused = ptr.To(true)
+	}
+	inWindow, windowBoundary, err := maintenanceWindow(spec.OneTimeMaintenance, time.Now())
+	if err != nil {
+		_ = r.patchStatus(ctx, monitor, func(status *monitoringv1alpha1.BetterStackMonitorStatus) {
+			now := metav1.Now()
+			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionSync, metav1.ConditionFalse, monitoringv1alpha1.ReasonSyncFailed, err.Error(), &now))
+			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionFalse, monitoringv1alpha1.ReasonSyncFailed, "Invalid one-time maintenance window", &now))
+		})
+		return ctrl.Result{}, nil
+	}
+	if inWindow {
+		logger.V(1).Info("monitor paused for one-time maintenance", "until", spec.OneTimeMaintenance.To)
+		request.Paused = ptr.To(true)
+	}
+
+	update := withoutUnmanagedFields(request, spec.UnmanagedFields)
+	var drift []string
+	if existingMonitor != nil {
+		if drift, err = unmanagedDrift(request, existingMonitor.Attributes, spec.UnmanagedFields); err != nil {
+			return ctrl.Result{}, err
+		}
+		if len(drift) > 0 {
+			logger.V(1).Info("unmanaged fields differ from spec", "fields", drift)
+		}
+	}
+
+	if r.regions.rejectedAt(account, spec.Regions, time.Now()) {
+		message := fmt.Sprintf("Regions %s are not all available for this Better Stack account", strings.Join(spec.Regions, ", "))
+		_ = r.patchStatus(ctx, monitor, func(status *monitoringv1alpha1.BetterStackMonitorStatus) {
+			now := metav1.Now()
+			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionSync, metav1.ConditionFalse, monitoringv1alpha1.ReasonRegionUnavailable, message, &now))
+			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionFalse, monitoringv1alpha1.ReasonRegionUnavailable, message, &now))
+		})
+		return ctrl.Result{RequeueAfter: requeueIntervalOnError}, nil
+	}
 
+	previousID := monitor.Status.MonitorID
+	// targetID is the remote monitor the last request was sent to. It outlives remoteID
+	// being cleared so a failed write still drops that monitor from the cache.
+	targetID := remoteID
 	var apiMonitor betterstack.Monitor
-	if monitor.Status.MonitorID != "" {
-		apiMonitor, err = monitorAPI.Update(ctx, monitor.Status.MonitorID, request)
+	tagsIgnored := false
+	if remoteID != "" {
+		apiMonitor, tagsIgnored, err = sendWithoutUnsupportedTags(update, func(req betterstack.MonitorRequest) (betterstack.Monitor, error) {
+			return monitorAPI.Update(ctx, remoteID, req)
+		})
 		if betterstack.IsNotFound(err) {
-			logger.Info("remote monitor missing, creating anew", "id", monitor.Status.MonitorID)
-			monitor.Status.MonitorID = ""
+			logger.Info("remote monitor missing, creating anew", "id", remoteID)
+			r.remote.forget(account, remoteID)
+			remoteID = ""
+			adoptedRemote = false
 			err = nil
 		}
 	}
 
-	if err == nil && monitor.Status.MonitorID == "" {
-		apiMonitor, err = monitorAPI.Create(ctx, request)
+	adopted := false
+	if err == nil && remoteID == "" && monitor.Spec.Adopt {
+		var found adoption
+		found, err = r.resolveAdoption(ctx, monitorAPI, monitor, spec)
+		switch {
+		case err != nil:
+		case found.conflict != "":
+			message := fmt.Sprintf("Remote monitor for %s is already managed by %s; set spec.onConflict to AdoptAnyway or Rename", spec.URL, found.conflict)
+			logger.Info("remote monitor already managed by another resource", "owner", found.conflict)
+			_ = r.patchStatus(ctx, monitor, func(status *monitoringv1alpha1.BetterStackMonitorStatus) {
+				now := metav1.Now()
+				status.SetCondition(conditions.New(monitoringv1alpha1.ConditionSync, metav1.ConditionFalse, monitoringv1alpha1.ReasonRemoteConflict, message, &now))
+				status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionFalse, monitoringv1alpha1.ReasonRemoteConflict, message, &now))
+			})
+			return ctrl.Result{RequeueAfter: requeueIntervalOnError}, nil
+		case found.adopt != nil:
+			if holder, ok, err := r.claimMonitorID(ctx, monitor, found.adopt.ID); err != nil {
+				return ctrl.Result{}, err
+			} else if !ok {
+				return r.reportClaimed(ctx, monitor, found.adopt.ID, holder)
+			}
+			logger.Info("adopting existing remote monitor", "id", found.adopt.ID)
+			if len(spec.RequestHeaders) > 0 {
+				request.RequestHeaders = monitorRequestHeaders(spec.RequestHeaders, found.adopt)
+			}
+			adopted = true
+			adoptedRemote = true
+			targetID = found.adopt.ID
+			apiMonitor, tagsIgnored, err = sendWithoutUnsupportedTags(withoutUnmanagedFields(request, spec.UnmanagedFields), func(req betterstack.MonitorRequest) (betterstack.Monitor, error) {
+				return monitorAPI.Update(ctx, found.adopt.ID, req)
+			})
+		case found.renameTo != "":
+			remoteName = found.renameTo
+			request.PronounceableName = ptr.To(remoteName)
+		}
+	}
+
+	if err == nil && !adopted && remoteID == "" && monitor.Spec.PreflightCheck {
+		if preflightErr := preflightCheck(ctx, r.PreflightHTTPClient, spec); preflightErr != nil {
+			logger.Info("preflight check failed", "url", monitor.Spec.URL, "error", preflightErr.Error())
+			_ = r.patchStatus(ctx, monitor, func(status *monitoringv1alpha1.BetterStackMonitorStatus) {
+				now := metav1.Now()
+				status.SetCondition(conditions.New(monitoringv1alpha1.ConditionSync, metav1.ConditionFalse, monitoringv1alpha1.ReasonPreflightFailed, preflightErr.Error(), &now))
+				status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionFalse, monitoringv1alpha1.ReasonPreflightFailed, "Monitor URL failed preflight check", &now))
+			})
+			return ctrl.Result{RequeueAfter: requeueIntervalOnError}, nil
+		}
+	}
+
+	if err == nil && !adopted && remoteID == "" && spec.MonitorGroupID != "" {
+		if _, groupErr := r.monitorGroupService(account).Get(ctx, spec.MonitorGroupID); betterstack.IsNotFound(groupErr) {
+			message := fmt.Sprintf("Monitor group %s not found in Better Stack", spec.MonitorGroupID)
+			logger.Info("referenced monitor group missing", "monitorGroupID", spec.MonitorGroupID)
+			_ = r.patchStatus(ctx, monitor, func(status *monitoringv1alpha1.BetterStackMonitorStatus) {
+				now := metav1.Now()
+				status.SetCondition(conditions.New(monitoringv1alpha1.ConditionSync, metav1.ConditionFalse, monitoringv1alpha1.ReasonMonitorGroupNotFound, message, &now))
+				status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionFalse, monitoringv1alpha1.ReasonMonitorGroupNotFound, message, &now))
+			})
+			return ctrl.Result{RequeueAfter: requeueIntervalOnError}, nil
+		}
+	}
+
+	if err == nil && !adopted && remoteID == "" {
+		apiMonitor, tagsIgnored, err = sendWithoutUnsupportedTags(request, func(req betterstack.MonitorRequest) (betterstack.Monitor, error) {
+			return monitorAPI.Create(ctx, req)
+		})
 	}
 
 	if err != nil {
 		logger.Error(err, "unable to reconcile Better Stack monitor")
-		syncReason := "SyncFailed"
+		r.remote.forget(account, targetID)
+		syncReason := monitoringv1alpha1.ReasonSyncFailed
 		syncMessage := err.Error()
 		readyMessage := "Monitor reconciliation failed"
 		if isMonitorQuotaExceeded(err) {
-			syncReason = ReasonMonitorQuotaExceeded
+			syncReason = monitoringv1alpha1.ReasonMonitorQuotaExceeded
 			syncMessage = "Better Stack monitor quota reached"
 			readyMessage = "Better Stack monitor quota reached"
+		} else if isRegionUnavailable(err) && len(spec.Regions) > 0 {
+			r.regions.reject(account, spec.Regions, time.Now())
+			syncReason = monitoringv1alpha1.ReasonRegionUnavailable
+			syncMessage = fmt.Sprintf("Regions %s are not all available for this Better Stack account: %s", strings.Join(spec.Regions, ", "), err.Error())
+			readyMessage = "Monitor regions not available"
 		}
+		backoff := nextBackoff(monitor.Status.Backoff, monitor.Generation, time.Now())
 		_ = r.patchStatus(ctx, monitor, func(status *monitoringv1alpha1.BetterStackMonitorStatus) {
 			now := metav1.Now()
+			status.Backoff = backoff
 			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionSync, metav1.ConditionFalse, syncReason, syncMessage, &now))
 			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionFalse, syncReason, readyMessage, &now))
 		})
-		return ctrl.Result{RequeueAfter: requeueIntervalOnError}, nil
+		backingOff = true
+		trackBackoff(r.Retries, "monitor", monitor, backoff, syncReason, syncMessage)
+		return ctrl.Result{RequeueAfter: backoffDelay(backoff.Failures)}, nil
+	}
+
+	r.remote.put(account, apiMonitor, r.RemoteCacheTTL, time.Now())
+
+	if previousID != "" && previousID != apiMonitor.ID {
+		metrics.RemoteRecreated.WithLabelValues("monitor").Inc()
 	}
 
+	deprecated := deprecatedMonitorFields(spec)
+	recordDeprecatedFields(deprecated)
+	syncedMessage := "Monitor synchronized with Better Stack"
+	if tagsIgnored {
+		logger.Info("Better Stack rejected monitor tags; synchronized without them")
+		syncedMessage += "; " + tagsIgnoredMessage
+	}
+	readyMessage := "Monitor synchronized with Better Stack"
+	if apiMonitor.Attributes.IsPaused() {
+		readyMessage += "; checks are paused"
+	}
 	now := metav1.Now()
 	updateErr := r.patchStatus(ctx, monitor, func(status *monitoringv1alpha1.BetterStackMonitorStatus) {
 		status.MonitorID = apiMonitor.ID
+		status.RemoteName = remoteName
+		status.Adopted = adoptedRemote
+		status.UnmanagedDrift = drift
+		if apiMonitor.Attributes.TeamName != "" {
+			status.TeamName = apiMonitor.Attributes.TeamName
+		}
+		status.ClusterName = r.ClusterName
+		status.BaseURL = account.BaseURL
+		status.AlertRoute = alertRoute
+		status.AlertRouteFields = alertRouteFields
 		status.ObservedGeneration = monitor.Generation
 		status.LastSyncedTime = &now
-		status.SetCondition(conditions.New(monitoringv1alpha1.ConditionSync, metav1.ConditionTrue, "MonitorSynced", "Monitor synchronized with Better Stack", &now))
-		status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionTrue, "MonitorSynced", "Monitor synchronized with Better Stack", &now))
+		status.Backoff = nil
+		switch {
+		case !underMaintenance:
+			status.MaintenancePausedSince = nil
+		case status.MaintenancePausedSince == nil:
+			status.MaintenancePausedSince = &now
+		}
+		status.SetCondition(conditions.New(monitoringv1alpha1.ConditionSync, metav1.ConditionTrue, monitoringv1alpha1.ReasonMonitorSynced, syncedMessage, &now))
+		status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionTrue, monitoringv1alpha1.ReasonMonitorSynced, readyMessage, &now))
+		if cond, ok := deprecatedFieldsCondition(status.Conditions, deprecated, &now); ok {
+			status.SetCondition(cond)
+		}
 	})
 	if updateErr != nil {
 		return ctrl.Result{}, updateErr
 	}
 
-	return ctrl.Result{}, nil
+	var result ctrl.Result
+	if timedPaused {
+		result = requeueBefore(result, resumeIn)
+	}
+	if windowBoundary > 0 {
+		result = requeueBefore(result, windowBoundary)
+	}
+	return result, nil
 }
 
 func (r *BetterStackMonitorReconciler) handleDelete(ctx context.Context, monitor *monitoringv1alpha1.BetterStackMonitor) (ctrl.Result, error) {
@@ -165,18 +487,36 @@ func (r *BetterStackMonitorReconciler) handleDelete(ctx context.Context, monitor
 		return ctrl.Result{}, nil
 	}
 
-	if monitor.Status.MonitorID != "" {
-		token, err := credentials.FetchAPIToken(ctx, r.Client, monitor.Namespace, monitor.Spec.APITokenSecretRef)
+	sharedWith, err := r.remoteOwner(ctx, monitor, monitor.Status.MonitorID)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	holder, claimed, err := r.claimMonitorID(ctx, monitor, monitor.Status.MonitorID)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !claimed {
+		logger.Info("leaving remote monitor managed by another namespace", "monitorID", monitor.Status.MonitorID, "owner", holder.String())
+	} else if monitor.Status.Adopted {
+		logger.Info("leaving adopted remote monitor in Better Stack", "monitorID", monitor.Status.MonitorID)
+	} else if sharedWith != "" {
+		logger.Info("leaving remote monitor shared with another resource", "monitorID", monitor.Status.MonitorID, "sharedWith", sharedWith)
+	} else if monitor.Status.MonitorID != "" {
+		account, err := resolveAccount(ctx, r.Client, r.References, r.DefaultBaseURL, monitor.Namespace, monitor.Spec.AccountRef, monitor.Spec.APITokenSecretRef, monitor.Spec.BaseURL)
 		if err != nil {
 			logger.Info("skipping remote monitor deletion due to missing credentials", "monitorID", monitor.Status.MonitorID, "error", err)
+			metrics.FinalizerSkippedRemoteDelete.WithLabelValues("monitor", metrics.SkipReasonCredentialsUnavailable).Inc()
 		} else {
-			service := r.monitorService(monitor.Spec.BaseURL, token)
+			r.remote.forget(account, monitor.Status.MonitorID)
+			service := r.monitorService(account)
 			if err := service.Delete(ctx, monitor.Status.MonitorID); err != nil && !betterstack.IsNotFound(err) {
 				logger.Error(err, "unable to delete Better Stack monitor", "monitorID", monitor.Status.MonitorID)
+				metrics.FinalizerSkippedRemoteDelete.WithLabelValues("monitor", metrics.SkipReasonDeleteFailed).Inc()
 			}
 		}
 	}
 
+	r.Retries.Forget("monitor", client.ObjectKeyFromObject(monitor))
 	controllerutil.RemoveFinalizer(monitor, monitoringv1alpha1.BetterStackMonitorFinalizer)
 	if err := r.Update(ctx, monitor); err != nil {
 		return ctrl.Result{}, err
@@ -185,9 +525,25 @@ func (r *BetterStackMonitorReconciler) handleDelete(ctx context.Context, monitor
 }
 
 func (r *BetterStackMonitorReconciler) patchStatus(ctx context.Context, monitor *monitoringv1alpha1.BetterStackMonitor, mutate func(*monitoringv1alpha1.BetterStackMonitorStatus)) error {
-	base := monitor.DeepCopy()
-	mutate(&monitor.Status)
-	return r.Status().Patch(ctx, monitor, client.MergeFrom(base))
+	return patchObjectStatus(ctx, r.Client, monitor, func(monitor *monitoringv1alpha1.BetterStackMonitor) *monitoringv1alpha1.BetterStackMonitorStatus {
+		return &monitor.Status
+	}, func(status *monitoringv1alpha1.BetterStackMonitorStatus) {
+		mutate(status)
+		status.DebugTrace = debugTrace(ctx, status.DebugTrace, status.Conditions)
+	})
+}
+
+// withBearerToken returns headers with any Authorization entry replaced by a bearer token.
+// The input slice is left untouched so the secret value never reaches the stored spec.
+func withBearerToken(headers []monitoringv1alpha1.BetterStackHeader, token string) []monitoringv1alpha1.BetterStackHeader {
+	out := make([]monitoringv1alpha1.BetterStackHeader, 0, len(headers)+1)
+	for _, h := range headers {
+		if strings.EqualFold(h.Name, "Authorization") {
+			continue
+		}
+		out = append(out, h)
+	}
+	return append(out, monitoringv1alpha1.BetterStackHeader{Name: "Authorization", Value: "Bearer " + token})
 }
 
 func buildMonitorRequest(spec monitoringv1alpha1.BetterStackMonitorSpec, existing *betterstack.Monitor) betterstack.MonitorCreateRequest {
@@ -212,9 +568,11 @@ func buildMonitorRequest(spec monitoringv1alpha1.BetterStackMonitorSpec, existin
 	if len(spec.Regions) > 0 {
 		req.Regions = append([]string(nil), spec.Regions...)
 	}
+	if len(spec.Tags) > 0 {
+		req.Tags = append([]string(nil), spec.Tags...)
+	}
 	if spec.RequestMethod != "" {
-		method := strings.ToLower(spec.RequestMethod)
-		req.HTTPMethod = ptr.To(method)
+		req.HTTPMethod = ptr.To(normalizeHTTPMethod(spec.RequestMethod))
 	}
 	if len(spec.ExpectedStatusCodes) > 0 {
 		req.ExpectedStatusCodes = append([]int(nil), spec.ExpectedStatusCodes...)
@@ -291,7 +649,7 @@ func buildMonitorRequest(spec monitoringv1alpha1.BetterStackMonitorSpec, existin
 		req.IPVersion = ptr.To(spec.IPVersion)
 	}
 	if len(spec.MaintenanceDays) > 0 {
-		req.MaintenanceDays = append([]string(nil), spec.MaintenanceDays...)
+		req.MaintenanceDays = normalizeWeekdays(spec.MaintenanceDays)
 	}
 	if spec.MaintenanceFrom != "" {
 		req.MaintenanceFrom = ptr.To(spec.MaintenanceFrom)
@@ -303,30 +661,7 @@ func buildMonitorRequest(spec monitoringv1alpha1.BetterStackMonitorSpec, existin
 		req.MaintenanceTimezone = ptr.To(spec.MaintenanceTimezone)
 	}
 	if len(spec.RequestHeaders) > 0 {
-		existingHeaders := map[string][]betterstack.MonitorHeader{}
-		if existing != nil {
-			for _, hdr := range existing.Attributes.RequestHeaders {
-				key := strings.ToLower(hdr.Name)
-				existingHeaders[key] = append(existingHeaders[key], hdr)
-			}
-		}
-
-		req.RequestHeaders = make([]betterstack.MonitorRequestHeader, 0, len(spec.RequestHeaders))
-		for _, h := range spec.RequestHeaders {
-			header := betterstack.MonitorRequestHeader{Name: h.Name, Value: h.Value}
-			key := strings.ToLower(h.Name)
-			if list := existingHeaders[key]; len(list) > 0 {
-				hdr := list[0]
-				if len(list) > 1 {
-					existingHeaders[key] = list[1:]
-				} else {
-					delete(existingHeaders, key)
-				}
-				id := hdr.ID
-				header.ID = &id
-			}
-			req.RequestHeaders = append(req.RequestHeaders, header)
-		}
+		req.RequestHeaders = monitorRequestHeaders(spec.RequestHeaders, existing)
 	}
 	if spec.RequestBody != "" {
 		req.RequestBody = ptr.To(spec.RequestBody)
@@ -347,6 +682,9 @@ func buildMonitorRequest(spec monitoringv1alpha1.BetterStackMonitorSpec, existin
 	if spec.ScenarioName != "" {
 		req.ScenarioName = ptr.To(spec.ScenarioName)
 	}
+	if spec.PlaywrightTimeoutSeconds > 0 {
+		req.RequestTimeout = ptr.To(spec.PlaywrightTimeoutSeconds)
+	}
 	if len(spec.AdditionalAttributes) > 0 {
 		req.AdditionalAttributes = make(map[string]any, len(spec.AdditionalAttributes))
 		for k, v := range spec.AdditionalAttributes {
@@ -357,49 +695,81 @@ func buildMonitorRequest(spec monitoringv1alpha1.BetterStackMonitorSpec, existin
 	return req
 }
 
-func (r *BetterStackMonitorReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	ctx := context.Background()
-	if err := mgr.GetFieldIndexer().IndexField(ctx, &monitoringv1alpha1.BetterStackMonitor{}, monitorSecretIndexKey, func(obj client.Object) []string {
-		monitor, ok := obj.(*monitoringv1alpha1.BetterStackMonitor)
-		if !ok {
-			return nil
+// monitorRequestHeaders converts spec headers into request headers, reusing the IDs of
+// same-named headers on existing so Better Stack updates them instead of adding duplicates.
+func monitorRequestHeaders(headers []monitoringv1alpha1.BetterStackHeader, existing *betterstack.Monitor) []betterstack.MonitorRequestHeader {
+	existingHeaders := map[string][]betterstack.MonitorHeader{}
+	if existing != nil {
+		for _, hdr := range existing.Attributes.RequestHeaders {
+			key := strings.ToLower(hdr.Name)
+			existingHeaders[key] = append(existingHeaders[key], hdr)
 		}
-		secretName := monitor.Spec.APITokenSecretRef.Name
-		if secretName == "" {
-			return nil
+	}
+
+	out := make([]betterstack.MonitorRequestHeader, 0, len(headers))
+	for _, h := range headers {
+		header := betterstack.MonitorRequestHeader{Name: h.Name, Value: h.Value}
+		key := strings.ToLower(h.Name)
+		if list := existingHeaders[key]; len(list) > 0 {
+			hdr := list[0]
+			if len(list) > 1 {
+				existingHeaders[key] = list[1:]
+			} else {
+				delete(existingHeaders, key)
+			}
+			id := hdr.ID
+			header.ID = &id
 		}
-		return []string{secretIndexValue(monitor.Namespace, secretName)}
-	}); err != nil {
+		out = append(out, header)
+	}
+	return out
+}
+
+func (r *BetterStackMonitorReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &monitoringv1alpha1.BetterStackMonitor{}, monitorIDIndexKey, indexMonitorID); err != nil {
+		return err
+	}
+	builder, err := secretwatch.Watch(mgr, ctrl.NewControllerManagedBy(mgr).Named("betterstackmonitor").Watches(&monitoringv1alpha1.BetterStackMonitor{}, enqueueSpecChangesFirst()), "monitor", &monitoringv1alpha1.BetterStackMonitor{}, func() client.ObjectList { return &monitoringv1alpha1.BetterStackMonitorList{} }, monitorCredentialRefs)
+	if err != nil {
 		return err
 	}
+	if r.AlertRoutes {
+		builder = builder.Watches(&monitoringv1alpha1.BetterStackAlertRoute{}, handler.EnqueueRequestsFromMapFunc(r.requestsForAlertRoute))
+	}
+	if r.Maintenance != nil {
+		builder = builder.WatchesRawSource(source.Channel(r.Maintenance.Changes(), handler.EnqueueRequestsFromMapFunc(r.requestsForAllMonitors)))
+	}
+	return builder.Complete(r)
+}
 
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&monitoringv1alpha1.BetterStackMonitor{}).
-		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.requestsForSecret)).
-		Complete(r)
+func monitorCredentialRefs(monitor *monitoringv1alpha1.BetterStackMonitor) ([]string, *monitoringv1alpha1.ResourceRef) {
+	secrets := []string{monitor.Spec.APITokenSecretRef.Name}
+	if monitor.Spec.BearerTokenSecretRef != nil {
+		secrets = append(secrets, monitor.Spec.BearerTokenSecretRef.Name)
+	}
+	return secrets, monitor.Spec.AccountRef
 }
 
-func (r *BetterStackMonitorReconciler) monitorService(baseURL, token string) betterstack.MonitorClient {
+func (r *BetterStackMonitorReconciler) monitorService(account credentials.Account) betterstack.MonitorClient {
 	factory := r.Clients
 	if factory == nil {
 		factory = defaultBetterStackMonitorClientFactory{}
 	}
-	return factory.Monitor(baseURL, token, r.HTTPClient)
+	return factory.Monitor(account.BaseURL, account.Token, r.Accounts.HTTPClient(account, r.HTTPClient))
 }
 
-func (r *BetterStackMonitorReconciler) requestsForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
-	secret, ok := obj.(*corev1.Secret)
-	if !ok {
-		return nil
-	}
-	if secret.Namespace == "" || secret.Name == "" {
-		return nil
+func (r *BetterStackMonitorReconciler) monitorGroupService(account credentials.Account) betterstack.MonitorGroupClient {
+	factory := r.MonitorGroupClients
+	if factory == nil {
+		factory = defaultBetterStackMonitorGroupClientFactory{}
 	}
+	return factory.MonitorGroup(account.BaseURL, account.Token, r.Accounts.HTTPClient(account, r.HTTPClient))
+}
 
-	secretKey := secretIndexValue(secret.Namespace, secret.Name)
+func (r *BetterStackMonitorReconciler) requestsForAllMonitors(ctx context.Context, _ client.Object) []reconcile.Request {
 	list := &monitoringv1alpha1.BetterStackMonitorList{}
-	if err := r.List(ctx, list, client.InNamespace(secret.Namespace), client.MatchingFields{monitorSecretIndexKey: secretKey}); err != nil {
-		log.FromContext(ctx).Error(err, "unable to list monitors for secret", "secret", secretKey)
+	if err := r.List(ctx, list); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list monitors")
 		return nil
 	}
 
@@ -4,27 +4,48 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"k8s.io/utils/ptr"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
 	"loks0n/betterstack-operator/internal/controller/credentials"
+	"loks0n/betterstack-operator/internal/controller/maintenance"
+	"loks0n/betterstack-operator/internal/controller/metrics"
+	"loks0n/betterstack-operator/internal/controller/refs"
+	"loks0n/betterstack-operator/internal/controller/shutdown"
 	"loks0n/betterstack-operator/internal/testutil/assert"
 	"loks0n/betterstack-operator/internal/testutil/controllertest"
 	"loks0n/betterstack-operator/pkg/betterstack"
+	"loks0n/betterstack-operator/pkg/betterstack/betterstackfakes"
 )
 
+// newIndexedClientBuilder returns a fake client builder with the remote ID indexes the
+// monitor and monitor group reconcilers register with the manager.
+func newIndexedClientBuilder(scheme *runtime.Scheme) *fake.ClientBuilder {
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&monitoringv1alpha1.BetterStackMonitor{}, monitorIDIndexKey, indexMonitorID).
+		WithIndex(&monitoringv1alpha1.BetterStackMonitorGroup{}, monitorGroupIDIndexKey, indexMonitorGroupID)
+}
+
 type fakeBetterStackMonitorClientFactory struct {
 	monitor            betterstack.MonitorClient
 	monitorCalls       int
@@ -37,81 +58,273 @@ func (f *fakeBetterStackMonitorClientFactory) Monitor(baseURL, token string, _ *
 	f.lastMonitorBaseURL = baseURL
 	f.lastMonitorToken = token
 	if f.monitor == nil {
-		return &fakeMonitorService{}
+		return &betterstackfakes.MonitorClient{}
 	}
 	return f.monitor
 }
 
-type fakeMonitorService struct {
-	getFn    func(ctx context.Context, id string) (betterstack.Monitor, error)
-	updateFn func(ctx context.Context, id string, req betterstack.MonitorUpdateRequest) (betterstack.Monitor, error)
-	createFn func(ctx context.Context, req betterstack.MonitorCreateRequest) (betterstack.Monitor, error)
-	deleteFn func(ctx context.Context, id string) error
-	listFn   func(ctx context.Context) ([]betterstack.Monitor, error)
+func TestReconcileAddsFinalizer(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
 
-	getCalls    int
-	updateCalls int
-	createCalls int
-	deleteCalls int
-	listCalls   int
+	monitor := &monitoringv1alpha1.BetterStackMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "example",
+			Namespace: "default",
+		},
+		Spec: monitoringv1alpha1.BetterStackMonitorSpec{
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+		},
+	}
 
-	lastUpdateReq betterstack.MonitorUpdateRequest
-	lastCreateReq betterstack.MonitorCreateRequest
-}
+	client := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(monitor).
+		WithObjects(monitor.DeepCopy()).
+		Build()
 
-func (s *fakeMonitorService) Get(ctx context.Context, id string) (betterstack.Monitor, error) {
-	s.getCalls++
-	if s.getFn != nil {
-		return s.getFn(ctx, id)
+	r := &BetterStackMonitorReconciler{
+		Client: client,
+		Scheme: scheme,
 	}
-	return betterstack.Monitor{}, nil
+
+	ctx := context.Background()
+	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}})
+	assert.NoError(t, err, "reconcile")
+	assert.Equal(t, "requeueAfter", res.RequeueAfter, time.Duration(0))
+
+	updated := &monitoringv1alpha1.BetterStackMonitor{}
+	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}, updated), "fetch updated monitor")
+	assert.Bool(t, "finalizer present", controllerutil.ContainsFinalizer(updated, monitoringv1alpha1.BetterStackMonitorFinalizer), true)
 }
 
-func (s *fakeMonitorService) Update(ctx context.Context, id string, req betterstack.MonitorUpdateRequest) (betterstack.Monitor, error) {
-	s.updateCalls++
-	s.lastUpdateReq = req
-	if s.updateFn != nil {
-		return s.updateFn(ctx, id, req)
+func TestReconcileHandlesMissingCredentials(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	monitor := &monitoringv1alpha1.BetterStackMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			Generation: 7,
+			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackMonitorSpec{
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+		},
 	}
-	return betterstack.Monitor{}, nil
-}
 
-func (s *fakeMonitorService) Create(ctx context.Context, req betterstack.MonitorCreateRequest) (betterstack.Monitor, error) {
-	s.createCalls++
-	s.lastCreateReq = req
-	if s.createFn != nil {
-		return s.createFn(ctx, req)
+	client := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(monitor).
+		WithObjects(monitor.DeepCopy()).
+		Build()
+
+	r := &BetterStackMonitorReconciler{
+		Client: client,
+		Scheme: scheme,
 	}
-	return betterstack.Monitor{}, nil
+
+	ctx := context.Background()
+	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}})
+	assert.NoError(t, err, "reconcile")
+	assert.Equal(t, "requeueAfter", res.RequeueAfter, requeueIntervalOnError)
+
+	updated := &monitoringv1alpha1.BetterStackMonitor{}
+	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}, updated), "fetch updated monitor")
+
+	creds := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionCredentials)
+	assert.NotNil(t, "credentials condition", creds)
+	assert.Equal(t, "credentials status", creds.Status, metav1.ConditionFalse)
+	assert.String(t, "credentials reason", creds.Reason, monitoringv1alpha1.ReasonTokenUnavailable)
+	ready := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionReady)
+	assert.NotNil(t, "ready condition", ready)
+	assert.Equal(t, "ready status", ready.Status, metav1.ConditionFalse)
+	assert.String(t, "ready reason", ready.Reason, monitoringv1alpha1.ReasonTokenUnavailable)
 }
 
-func (s *fakeMonitorService) Delete(ctx context.Context, id string) error {
-	s.deleteCalls++
-	if s.deleteFn != nil {
-		return s.deleteFn(ctx, id)
+func TestReconcileCreatesMonitorWhenRemoteMissing(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	monitor := &monitoringv1alpha1.BetterStackMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			Generation: 3,
+			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackMonitorSpec{
+			URL:           "https://example.com",
+			MonitorType:   "status",
+			RequestMethod: "get",
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+			BaseURL: "https://api.test",
+		},
+		Status: monitoringv1alpha1.BetterStackMonitorStatus{
+			MonitorID: "remote-123",
+		},
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+
+	client := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(monitor).
+		WithObjects(monitor.DeepCopy(), secret.DeepCopy()).
+		Build()
+
+	service := &betterstackfakes.MonitorClient{
+		GetFn: func(ctx context.Context, id string) (betterstack.Monitor, error) {
+			assert.String(t, "get id", id, "remote-123")
+			return betterstack.Monitor{}, &betterstack.APIError{StatusCode: http.StatusNotFound}
+		},
+		UpdateFn: func(ctx context.Context, id string, req betterstack.MonitorUpdateRequest) (betterstack.Monitor, error) {
+			assert.String(t, "update id", id, "remote-123")
+			return betterstack.Monitor{}, &betterstack.APIError{StatusCode: http.StatusNotFound}
+		},
+		CreateFn: func(ctx context.Context, req betterstack.MonitorCreateRequest) (betterstack.Monitor, error) {
+			assert.NotNil(t, "request url", req.URL)
+			assert.String(t, "request url", *req.URL, "https://example.com")
+			assert.NotNil(t, "request type", req.MonitorType)
+			assert.String(t, "request type", *req.MonitorType, "status")
+			assert.NotNil(t, "request method", req.HTTPMethod)
+			assert.String(t, "request method", *req.HTTPMethod, "get")
+			return betterstack.Monitor{ID: "new-id", Attributes: betterstack.MonitorAttributes{TeamName: "Platform"}}, nil
+		},
+	}
+	factory := &fakeBetterStackMonitorClientFactory{monitor: service}
+
+	r := &BetterStackMonitorReconciler{
+		Client:  client,
+		Scheme:  scheme,
+		Clients: factory,
 	}
-	return nil
+
+	ctx := context.Background()
+	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}})
+	assert.NoError(t, err, "reconcile")
+	assert.Equal(t, "requeueAfter", res.RequeueAfter, time.Duration(0))
+
+	updated := &monitoringv1alpha1.BetterStackMonitor{}
+	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}, updated), "fetch updated monitor")
+	assert.String(t, "monitor id", updated.Status.MonitorID, "new-id")
+	assert.String(t, "team name", updated.Status.TeamName, "Platform")
+	assert.Equal(t, "observed generation", updated.Status.ObservedGeneration, monitor.Generation)
+	ready := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionReady)
+	assert.NotNil(t, "ready condition", ready)
+	assert.Equal(t, "ready status", ready.Status, metav1.ConditionTrue)
+	assert.String(t, "ready reason", ready.Reason, monitoringv1alpha1.ReasonMonitorSynced)
+	assert.Int(t, "monitor factory calls", factory.monitorCalls, 1)
+	assert.String(t, "last token", factory.lastMonitorToken, "abcd")
 }
 
-func (s *fakeMonitorService) List(ctx context.Context) ([]betterstack.Monitor, error) {
-	s.listCalls++
-	if s.listFn != nil {
-		return s.listFn(ctx)
+func TestReconcileFinishesInFlightCallsDuringShutdown(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	monitor := &monitoringv1alpha1.BetterStackMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackMonitorSpec{
+			URL: "https://example.com",
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+
+	client := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(monitor).
+		WithObjects(monitor.DeepCopy(), secret).
+		Build()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	drainer := shutdown.NewDrainer(time.Second)
+	drained := make(chan bool, 1)
+
+	service := &betterstackfakes.MonitorClient{
+		CreateFn: func(callCtx context.Context, req betterstack.MonitorCreateRequest) (betterstack.Monitor, error) {
+			// Simulate SIGTERM arriving while the create is in flight.
+			cancel()
+			go func() { drained <- drainer.Drain() }()
+			assert.NoError(t, callCtx.Err(), "in-flight context")
+			return betterstack.Monitor{ID: "new-id"}, nil
+		},
+	}
+	factory := &fakeBetterStackMonitorClientFactory{monitor: service}
+	r := &BetterStackMonitorReconciler{
+		Client:  client,
+		Scheme:  scheme,
+		Clients: factory,
+		Drainer: drainer,
 	}
-	return nil, nil
+
+	key := types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile")
+	assert.Bool(t, "drained in time", <-drained, true)
+
+	updated := &monitoringv1alpha1.BetterStackMonitor{}
+	assert.NoError(t, client.Get(context.Background(), key, updated), "fetch updated monitor")
+	assert.String(t, "monitor id", updated.Status.MonitorID, "new-id")
+
+	res, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile after drain")
+	assert.Equal(t, "result after drain", res, ctrl.Result{})
+	assert.Int(t, "monitor factory calls", factory.monitorCalls, 1)
 }
 
-var _ betterstack.MonitorClient = (*fakeMonitorService)(nil)
+func TestPatchStatusSkipsCancelledContext(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+	monitor := &monitoringv1alpha1.BetterStackMonitor{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+	}
+	client := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(monitor).
+		WithObjects(monitor.DeepCopy()).
+		Build()
+	r := &BetterStackMonitorReconciler{Client: client, Scheme: scheme}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := r.patchStatus(ctx, monitor, func(status *monitoringv1alpha1.BetterStackMonitorStatus) {
+		status.MonitorID = "stale"
+	})
+	assert.Error(t, err, "patch with cancelled context")
+
+	stored := &monitoringv1alpha1.BetterStackMonitor{}
+	assert.NoError(t, client.Get(context.Background(), types.NamespacedName{Name: "example", Namespace: "default"}, stored), "fetch monitor")
+	assert.String(t, "monitor id", stored.Status.MonitorID, "")
+}
 
-func TestReconcileAddsFinalizer(t *testing.T) {
+func TestReconcileRejectsMissingMonitorGroup(t *testing.T) {
 	scheme := controllertest.NewScheme(t)
 
 	monitor := &monitoringv1alpha1.BetterStackMonitor{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "example",
-			Namespace: "default",
+			Name:       "example",
+			Namespace:  "default",
+			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorFinalizer},
 		},
 		Spec: monitoringv1alpha1.BetterStackMonitorSpec{
+			URL:            "https://example.com",
+			MonitorType:    "status",
+			MonitorGroupID: "404404",
 			APITokenSecretRef: corev1.SecretKeySelector{
 				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
 				Key:                  "token",
@@ -119,55 +332,1005 @@ func TestReconcileAddsFinalizer(t *testing.T) {
 		},
 	}
 
-	client := fake.NewClientBuilder().
-		WithScheme(scheme).
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+
+	client := newIndexedClientBuilder(scheme).
 		WithStatusSubresource(monitor).
-		WithObjects(monitor.DeepCopy()).
+		WithObjects(monitor.DeepCopy(), secret.DeepCopy()).
 		Build()
 
+	service := &betterstackfakes.MonitorClient{}
+	groups := &betterstackfakes.MonitorGroupClient{
+		GetFn: func(ctx context.Context, id string) (betterstack.MonitorGroup, error) {
+			assert.String(t, "group id", id, "404404")
+			return betterstack.MonitorGroup{}, &betterstack.APIError{StatusCode: http.StatusNotFound}
+		},
+	}
 	r := &BetterStackMonitorReconciler{
-		Client: client,
-		Scheme: scheme,
+		Client:              client,
+		Scheme:              scheme,
+		Clients:             &fakeBetterStackMonitorClientFactory{monitor: service},
+		MonitorGroupClients: &fakeBetterStackMonitorGroupClientFactory{group: groups},
 	}
 
 	ctx := context.Background()
 	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}})
 	assert.NoError(t, err, "reconcile")
-	assert.Equal(t, "requeueAfter", res.RequeueAfter, time.Duration(0))
+	assert.Equal(t, "requeueAfter", res.RequeueAfter, requeueIntervalOnError)
+	assert.Int(t, "create calls", service.CreateCalls, 0)
+	assert.Int(t, "group get calls", groups.GetCalls, 1)
 
 	updated := &monitoringv1alpha1.BetterStackMonitor{}
 	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}, updated), "fetch updated monitor")
-	assert.Bool(t, "finalizer present", controllerutil.ContainsFinalizer(updated, monitoringv1alpha1.BetterStackMonitorFinalizer), true)
+	syncCond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionSync)
+	assert.NotNil(t, "sync condition", syncCond)
+	assert.String(t, "sync reason", syncCond.Reason, monitoringv1alpha1.ReasonMonitorGroupNotFound)
+	assert.String(t, "sync message", syncCond.Message, "Monitor group 404404 not found in Better Stack")
+}
+
+func TestReconcileUsesAccountRef(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	monitor := &monitoringv1alpha1.BetterStackMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackMonitorSpec{
+			URL:         "https://example.com",
+			MonitorType: "status",
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+			AccountRef: &monitoringv1alpha1.ResourceRef{Name: "team-b"},
+		},
+	}
+
+	credential := &monitoringv1alpha1.BetterStackCredential{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-b", Namespace: "default"},
+		Spec: monitoringv1alpha1.BetterStackCredentialSpec{
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "team-b-api"},
+				Key:                  "api-key",
+			},
+			BaseURL: "https://team-b.test",
+		},
+	}
+
+	secrets := []*corev1.Secret{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+			Data:       map[string][]byte{"token": []byte("default-token")},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-b-api", Namespace: "default"},
+			Data:       map[string][]byte{"api-key": []byte("team-b-token")},
+		},
+	}
+
+	client := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(monitor).
+		WithObjects(monitor.DeepCopy(), credential.DeepCopy(), secrets[0].DeepCopy(), secrets[1].DeepCopy()).
+		Build()
+
+	service := &betterstackfakes.MonitorClient{
+		CreateFn: func(ctx context.Context, req betterstack.MonitorCreateRequest) (betterstack.Monitor, error) {
+			return betterstack.Monitor{ID: "new-id"}, nil
+		},
+	}
+	factory := &fakeBetterStackMonitorClientFactory{monitor: service}
+
+	r := &BetterStackMonitorReconciler{
+		Client:  client,
+		Scheme:  scheme,
+		Clients: factory,
+	}
+
+	ctx := context.Background()
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}})
+	assert.NoError(t, err, "reconcile")
+	assert.String(t, "last token", factory.lastMonitorToken, "team-b-token")
+	assert.String(t, "last base url", factory.lastMonitorBaseURL, "https://team-b.test")
+
+	updated := &monitoringv1alpha1.BetterStackMonitor{}
+	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}, updated), "fetch updated monitor")
+	creds := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionCredentials)
+	assert.NotNil(t, "credentials condition", creds)
+	assert.String(t, "credentials message", creds.Message, "Using account default/team-b")
+}
+
+func TestReconcileCrossNamespaceAccountRef(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	monitor := &monitoringv1alpha1.BetterStackMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackMonitorSpec{
+			URL:         "https://example.com",
+			MonitorType: "status",
+			AccountRef:  &monitoringv1alpha1.ResourceRef{Name: "shared", Namespace: "platform"},
+		},
+	}
+	credential := &monitoringv1alpha1.BetterStackCredential{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "platform"},
+		Spec: monitoringv1alpha1.BetterStackCredentialSpec{
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "shared-api"},
+				Key:                  "token",
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared-api", Namespace: "platform"},
+		Data:       map[string][]byte{"token": []byte("shared-token")},
+	}
+
+	cases := []struct {
+		name        string
+		policy      refs.Policy
+		wantToken   string
+		wantMessage string
+	}{
+		{
+			name:        "denied by default",
+			wantMessage: "reference to platform/shared from namespace default is not allowed; cross-namespace references require --allow-cross-namespace-refs",
+		},
+		{
+			name:        "allowed",
+			policy:      refs.Policy{AllowCrossNamespace: true},
+			wantToken:   "shared-token",
+			wantMessage: "Using account platform/shared",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := newIndexedClientBuilder(scheme).
+				WithStatusSubresource(monitor).
+				WithObjects(monitor.DeepCopy(), credential.DeepCopy(), secret.DeepCopy()).
+				Build()
+			service := &betterstackfakes.MonitorClient{
+				CreateFn: func(ctx context.Context, req betterstack.MonitorCreateRequest) (betterstack.Monitor, error) {
+					return betterstack.Monitor{ID: "new-id"}, nil
+				},
+			}
+			factory := &fakeBetterStackMonitorClientFactory{monitor: service}
+			r := &BetterStackMonitorReconciler{
+				Client:     client,
+				Scheme:     scheme,
+				Clients:    factory,
+				References: tc.policy,
+			}
+
+			ctx := context.Background()
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}})
+			assert.NoError(t, err, "reconcile")
+			assert.String(t, "last token", factory.lastMonitorToken, tc.wantToken)
+
+			updated := &monitoringv1alpha1.BetterStackMonitor{}
+			assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}, updated), "fetch updated monitor")
+			creds := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionCredentials)
+			assert.NotNil(t, "credentials condition", creds)
+			assert.String(t, "credentials message", creds.Message, tc.wantMessage)
+		})
+	}
+}
+
+func TestReconcileUsesRotatedToken(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	monitor := &monitoringv1alpha1.BetterStackMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackMonitorSpec{
+			URL:         "https://example.com",
+			MonitorType: "status",
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("old-token")},
+	}
+
+	client := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(monitor).
+		WithObjects(monitor.DeepCopy(), secret.DeepCopy()).
+		Build()
+
+	service := &betterstackfakes.MonitorClient{
+		CreateFn: func(ctx context.Context, req betterstack.MonitorCreateRequest) (betterstack.Monitor, error) {
+			return betterstack.Monitor{ID: "new-id"}, nil
+		},
+		UpdateFn: func(ctx context.Context, id string, req betterstack.MonitorUpdateRequest) (betterstack.Monitor, error) {
+			return betterstack.Monitor{ID: id}, nil
+		},
+	}
+	factory := &fakeBetterStackMonitorClientFactory{monitor: service}
+	r := &BetterStackMonitorReconciler{
+		Client:  client,
+		Scheme:  scheme,
+		Clients: factory,
+	}
+
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}}
+	_, err := r.Reconcile(ctx, req)
+	assert.NoError(t, err, "reconcile")
+	assert.String(t, "token before rotation", factory.lastMonitorToken, "old-token")
+
+	// Tokens are read on every reconcile, so the reconcile the secret watch enqueues after a
+	// rotation uses the new token without restarting the operator.
+	rotated := &corev1.Secret{}
+	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: "api", Namespace: "default"}, rotated), "fetch secret")
+	rotated.Data["token"] = []byte("new-token")
+	assert.NoError(t, client.Update(ctx, rotated), "rotate secret")
+
+	_, err = r.Reconcile(ctx, req)
+	assert.NoError(t, err, "reconcile after rotation")
+	assert.String(t, "token after rotation", factory.lastMonitorToken, "new-token")
+}
+
+func TestReconcileSkipsSuspendedMonitor(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	monitor := &monitoringv1alpha1.BetterStackMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackMonitorSpec{
+			URL: "https://example.com",
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+			Suspend: true,
+		},
+		Status: monitoringv1alpha1.BetterStackMonitorStatus{
+			MonitorID: "remote-123",
+		},
+	}
+
+	client := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(monitor).
+		WithObjects(monitor.DeepCopy()).
+		Build()
+
+	factory := &fakeBetterStackMonitorClientFactory{}
+	r := &BetterStackMonitorReconciler{
+		Client:  client,
+		Scheme:  scheme,
+		Clients: factory,
+	}
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}
+	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile")
+	assert.Equal(t, "requeueAfter", res.RequeueAfter, time.Duration(0))
+	assert.Int(t, "monitor factory calls", factory.monitorCalls, 0)
+
+	updated := &monitoringv1alpha1.BetterStackMonitor{}
+	assert.NoError(t, client.Get(ctx, key, updated), "fetch monitor")
+	suspended := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionSuspended)
+	assert.NotNil(t, "suspended condition", suspended)
+	assert.Equal(t, "suspended status", suspended.Status, metav1.ConditionTrue)
+	assert.String(t, "monitor id", updated.Status.MonitorID, "remote-123")
+
+	updated.Spec.Suspend = false
+	assert.NoError(t, client.Update(ctx, updated), "resume monitor")
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+	assert.NoError(t, client.Create(ctx, secret), "create secret")
+
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile resumed")
+	assert.Int(t, "monitor factory calls", factory.monitorCalls, 1)
+
+	resumed := &monitoringv1alpha1.BetterStackMonitor{}
+	assert.NoError(t, client.Get(ctx, key, resumed), "fetch resumed monitor")
+	suspended = controllertest.FindCondition(resumed.Status.Conditions, monitoringv1alpha1.ConditionSuspended)
+	assert.NotNil(t, "suspended condition", suspended)
+	assert.Equal(t, "suspended status", suspended.Status, metav1.ConditionFalse)
+	assert.String(t, "suspended reason", suspended.Reason, monitoringv1alpha1.ReasonResumed)
+}
+
+func TestReconcilePausesMonitorDuringMaintenance(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	monitor := &monitoringv1alpha1.BetterStackMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			Labels:     map[string]string{"tier": "edge"},
+			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackMonitorSpec{
+			URL: "https://example.com",
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+		},
+		Status: monitoringv1alpha1.BetterStackMonitorStatus{
+			MonitorID: "remote-123",
+		},
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+
+	client := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(monitor).
+		WithObjects(monitor.DeepCopy(), secret.DeepCopy()).
+		Build()
+
+	service := &betterstackfakes.MonitorClient{
+		UpdateFn: func(ctx context.Context, id string, req betterstack.MonitorUpdateRequest) (betterstack.Monitor, error) {
+			return betterstack.Monitor{ID: id}, nil
+		},
+	}
+	switcher := maintenance.NewSwitch()
+	r := &BetterStackMonitorReconciler{
+		Client:      client,
+		Scheme:      scheme,
+		Clients:     &fakeBetterStackMonitorClientFactory{monitor: service},
+		Maintenance: switcher,
+	}
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}
+
+	switcher.Enable(labels.SelectorFromSet(labels.Set{"tier": "edge"}))
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile during maintenance")
+	assert.NotNil(t, "paused", service.LastUpdateReq.Paused)
+	assert.Bool(t, "paused", *service.LastUpdateReq.Paused, true)
+
+	updated := &monitoringv1alpha1.BetterStackMonitor{}
+	assert.NoError(t, client.Get(ctx, key, updated), "fetch monitor")
+	assert.NotNil(t, "maintenance recorded", updated.Status.MaintenancePausedSince)
+	assert.Int(t, "annotations", len(updated.Annotations), 0)
+	since := updated.Status.MaintenancePausedSince
+
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "second reconcile during maintenance")
+	assert.NoError(t, client.Get(ctx, key, updated), "fetch monitor again")
+	assert.Bool(t, "maintenance start kept", updated.Status.MaintenancePausedSince.Equal(since), true)
+
+	updated.Spec.Paused = true
+	assert.NoError(t, client.Update(ctx, updated), "pause during maintenance")
+
+	switcher.Disable()
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile after maintenance")
+	assert.Bool(t, "paused edit kept", *service.LastUpdateReq.Paused, true)
+
+	restored := &monitoringv1alpha1.BetterStackMonitor{}
+	assert.NoError(t, client.Get(ctx, key, restored), "fetch restored monitor")
+	assert.Nil(t, "maintenance cleared", restored.Status.MaintenancePausedSince)
+
+	restored.Spec.Paused = false
+	assert.NoError(t, client.Update(ctx, restored), "resume")
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile after resume")
+	assert.Bool(t, "resumed", *service.LastUpdateReq.Paused, false)
+}
+
+func TestReconcilePausesMonitorUntilPausedUntil(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	until := metav1.NewTime(time.Now().Add(time.Hour))
+	monitor := &monitoringv1alpha1.BetterStackMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackMonitorSpec{
+			URL:         "https://example.com",
+			PausedUntil: &until,
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+		},
+		Status: monitoringv1alpha1.BetterStackMonitorStatus{
+			MonitorID: "remote-123",
+		},
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+
+	client := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(monitor).
+		WithObjects(monitor.DeepCopy(), secret.DeepCopy()).
+		Build()
+
+	service := &betterstackfakes.MonitorClient{
+		UpdateFn: func(ctx context.Context, id string, req betterstack.MonitorUpdateRequest) (betterstack.Monitor, error) {
+			return betterstack.Monitor{ID: id, Attributes: betterstack.MonitorAttributes{Paused: ptr.Deref(req.Paused, false)}}, nil
+		},
+	}
+	r := &BetterStackMonitorReconciler{
+		Client:  client,
+		Scheme:  scheme,
+		Clients: &fakeBetterStackMonitorClientFactory{monitor: service},
+	}
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}
+
+	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile while paused")
+	assert.EqualPtr(t, "paused", service.LastUpdateReq.Paused, true)
+	if res.RequeueAfter <= 0 || res.RequeueAfter > time.Hour {
+		t.Fatalf("expected requeue before the pause expires, got %s", res.RequeueAfter)
+	}
+
+	expired := &monitoringv1alpha1.BetterStackMonitor{}
+	assert.NoError(t, client.Get(ctx, key, expired), "fetch monitor")
+	ready := controllertest.FindCondition(expired.Status.Conditions, monitoringv1alpha1.ConditionReady)
+	assert.NotNil(t, "ready condition", ready)
+	assert.String(t, "ready message while paused", ready.Message, "Monitor synchronized with Better Stack; checks are paused")
+	expired.Spec.PausedUntil = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+	assert.NoError(t, client.Update(ctx, expired), "expire pause")
+
+	res, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile after pause expired")
+	assert.EqualPtr(t, "paused", service.LastUpdateReq.Paused, false)
+	assert.Equal(t, "requeueAfter", res.RequeueAfter, time.Duration(0))
+
+	assert.NoError(t, client.Get(ctx, key, expired), "fetch resumed monitor")
+	ready = controllertest.FindCondition(expired.Status.Conditions, monitoringv1alpha1.ConditionReady)
+	assert.String(t, "ready message after resume", ready.Message, "Monitor synchronized with Better Stack")
+}
+
+func TestMaintenanceWindow(t *testing.T) {
+	now := time.Date(2024, 6, 1, 21, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name       string
+		from, to   string
+		timezone   string
+		wantActive bool
+		wantWait   time.Duration
+		wantErr    bool
+	}{
+		{name: "before", from: "2024-06-01T22:00", to: "2024-06-01T23:30", wantWait: time.Hour},
+		{name: "during", from: "2024-06-01T20:00", to: "2024-06-01T21:30:00", wantActive: true, wantWait: 30 * time.Minute},
+		{name: "after", from: "2024-06-01T18:00", to: "2024-06-01T19:00"},
+		{name: "timezone", from: "2024-06-01T21:30", to: "2024-06-01T23:30", timezone: "Europe/London", wantActive: true, wantWait: 90 * time.Minute},
+		{name: "end before start", from: "2024-06-01T22:00", to: "2024-06-01T21:00", wantErr: true},
+		{name: "unknown timezone", from: "2024-06-01T22:00", to: "2024-06-01T23:00", timezone: "Mars/Olympus", wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			active, wait, err := maintenanceWindow(&monitoringv1alpha1.BetterStackMaintenanceWindow{From: tc.from, To: tc.to, Timezone: tc.timezone}, now)
+			if tc.wantErr {
+				assert.Error(t, err, "maintenance window")
+				return
+			}
+			assert.NoError(t, err, "maintenance window")
+			assert.Bool(t, "active", active, tc.wantActive)
+			assert.Equal(t, "wait", wait, tc.wantWait)
+		})
+	}
+}
+
+func TestReconcilePausesMonitorDuringOneTimeMaintenance(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	now := time.Now().UTC()
+	monitor := &monitoringv1alpha1.BetterStackMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackMonitorSpec{
+			URL: "https://example.com",
+			OneTimeMaintenance: &monitoringv1alpha1.BetterStackMaintenanceWindow{
+				From: now.Add(-time.Hour).Format("2006-01-02T15:04:05"),
+				To:   now.Add(time.Hour).Format("2006-01-02T15:04:05"),
+			},
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+		},
+		Status: monitoringv1alpha1.BetterStackMonitorStatus{
+			MonitorID: "remote-123",
+		},
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+
+	client := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(monitor).
+		WithObjects(monitor.DeepCopy(), secret.DeepCopy()).
+		Build()
+
+	service := &betterstackfakes.MonitorClient{
+		UpdateFn: func(ctx context.Context, id string, req betterstack.MonitorUpdateRequest) (betterstack.Monitor, error) {
+			return betterstack.Monitor{ID: id}, nil
+		},
+	}
+	r := &BetterStackMonitorReconciler{
+		Client:  client,
+		Scheme:  scheme,
+		Clients: &fakeBetterStackMonitorClientFactory{monitor: service},
+	}
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}
+
+	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile during window")
+	assert.EqualPtr(t, "paused", service.LastUpdateReq.Paused, true)
+	if res.RequeueAfter <= 0 || res.RequeueAfter > time.Hour {
+		t.Fatalf("expected requeue before the window ends, got %s", res.RequeueAfter)
+	}
+
+	ended := &monitoringv1alpha1.BetterStackMonitor{}
+	assert.NoError(t, client.Get(ctx, key, ended), "fetch monitor")
+	ended.Spec.OneTimeMaintenance.To = now.Add(-time.Minute).Format("2006-01-02T15:04:05")
+	assert.NoError(t, client.Update(ctx, ended), "end window")
+
+	res, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile after window")
+	assert.EqualPtr(t, "paused", service.LastUpdateReq.Paused, false)
+	assert.Equal(t, "requeueAfter", res.RequeueAfter, time.Duration(0))
+}
+
+func TestMaintenanceSwitchSelector(t *testing.T) {
+	switcher := maintenance.NewSwitch()
+	switcher.Enable(labels.SelectorFromSet(labels.Set{"tier": "edge"}))
+
+	assert.Bool(t, "selected", switcher.Applies(map[string]string{"tier": "edge"}), true)
+	assert.Bool(t, "unselected", switcher.Applies(map[string]string{"tier": "core"}), false)
+
+	var disabled *maintenance.Switch
+	assert.Bool(t, "nil switch", disabled.Applies(map[string]string{"tier": "edge"}), false)
+}
+
+func TestReconcileFailsPreflightCheck(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer target.Close()
+
+	monitor := &monitoringv1alpha1.BetterStackMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackMonitorSpec{
+			URL:            target.URL,
+			MonitorType:    "status",
+			PreflightCheck: true,
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+		},
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+
+	client := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(monitor).
+		WithObjects(monitor.DeepCopy(), secret.DeepCopy()).
+		Build()
+
+	service := &betterstackfakes.MonitorClient{}
+	r := &BetterStackMonitorReconciler{
+		Client:  client,
+		Scheme:  scheme,
+		Clients: &fakeBetterStackMonitorClientFactory{monitor: service},
+	}
+
+	ctx := context.Background()
+	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}})
+	assert.NoError(t, err, "reconcile")
+	assert.Equal(t, "requeueAfter", res.RequeueAfter, requeueIntervalOnError)
+	assert.Int(t, "create calls", service.CreateCalls, 0)
+
+	updated := &monitoringv1alpha1.BetterStackMonitor{}
+	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}, updated), "fetch monitor")
+	synced := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionSync)
+	assert.NotNil(t, "sync condition", synced)
+	assert.String(t, "sync reason", synced.Reason, monitoringv1alpha1.ReasonPreflightFailed)
+	if !strings.Contains(synced.Message, "503") {
+		assert.Failf(t, "expected response code in message, got %q", synced.Message)
+	}
+
+	target.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	service.CreateFn = func(ctx context.Context, req betterstack.MonitorCreateRequest) (betterstack.Monitor, error) {
+		return betterstack.Monitor{ID: "new-id"}, nil
+	}
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}})
+	assert.NoError(t, err, "reconcile after endpoint recovers")
+	assert.Int(t, "create calls", service.CreateCalls, 1)
+}
+
+func TestPreflightCheckHonoursVerifySSL(t *testing.T) {
+	target := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer target.Close()
+
+	ctx := context.Background()
+	spec := monitoringv1alpha1.BetterStackMonitorSpec{URL: target.URL, MonitorType: "status"}
+	if err := preflightCheck(ctx, nil, spec); err == nil {
+		assert.Failf(t, "expected a self-signed certificate to fail verification")
+	}
+
+	spec.VerifySSL = ptr.To(false)
+	assert.NoError(t, preflightCheck(ctx, nil, spec), "preflight without verification")
+}
+
+func TestPreflightCheckHonoursFollowRedirects(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/moved" {
+			http.Redirect(w, r, "/gone", http.StatusFound)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer target.Close()
+
+	ctx := context.Background()
+	spec := monitoringv1alpha1.BetterStackMonitorSpec{URL: target.URL + "/moved", MonitorType: "status"}
+	err := preflightCheck(ctx, nil, spec)
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		assert.Failf(t, "expected the followed redirect to return 404, got %v", err)
+	}
+
+	spec.FollowRedirects = ptr.To(false)
+	spec.ExpectedStatusCodes = []int{http.StatusFound}
+	assert.NoError(t, preflightCheck(ctx, nil, spec), "preflight without following redirects")
+}
+
+func TestReconcileRendersBearerTokenHeader(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	monitor := &monitoringv1alpha1.BetterStackMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackMonitorSpec{
+			URL: "https://example.com",
+			RequestHeaders: []monitoringv1alpha1.BetterStackHeader{
+				{Name: "X-Env", Value: "prod"},
+				{Name: "authorization", Value: "Basic stale"},
+			},
+			BearerTokenSecretRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "probe"},
+				Key:                  "token",
+			},
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+		},
+		Status: monitoringv1alpha1.BetterStackMonitorStatus{
+			MonitorID: "remote-123",
+		},
+	}
+
+	secrets := []*corev1.Secret{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+			Data:       map[string][]byte{"token": []byte("abcd")},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "probe", Namespace: "default"},
+			Data:       map[string][]byte{"token": []byte("s3cret")},
+		},
+	}
+
+	client := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(monitor).
+		WithObjects(monitor.DeepCopy(), secrets[0].DeepCopy(), secrets[1].DeepCopy()).
+		Build()
+
+	service := &betterstackfakes.MonitorClient{
+		GetFn: func(ctx context.Context, id string) (betterstack.Monitor, error) {
+			return betterstack.Monitor{ID: id, Attributes: betterstack.MonitorAttributes{
+				RequestHeaders: []betterstack.MonitorHeader{{ID: "hdr-auth", Name: "Authorization", Value: "Bearer s3cret"}},
+			}}, nil
+		},
+		UpdateFn: func(ctx context.Context, id string, req betterstack.MonitorUpdateRequest) (betterstack.Monitor, error) {
+			return betterstack.Monitor{ID: id}, nil
+		},
+	}
+	r := &BetterStackMonitorReconciler{
+		Client:  client,
+		Scheme:  scheme,
+		Clients: &fakeBetterStackMonitorClientFactory{monitor: service},
+	}
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile")
+
+	headers := service.LastUpdateReq.RequestHeaders
+	assert.Int(t, "header count", len(headers), 2)
+	assert.String(t, "first header", headers[0].Name, "X-Env")
+	assert.String(t, "auth header name", headers[1].Name, "Authorization")
+	assert.String(t, "auth header value", headers[1].Value, "Bearer s3cret")
+	assert.NotNil(t, "auth header id", headers[1].ID)
+	assert.String(t, "auth header id", *headers[1].ID, "hdr-auth")
+
+	stored := &monitoringv1alpha1.BetterStackMonitor{}
+	assert.NoError(t, client.Get(ctx, key, stored), "fetch monitor")
+	assert.Int(t, "stored header count", len(stored.Spec.RequestHeaders), 2)
+	assert.String(t, "stored header value", stored.Spec.RequestHeaders[1].Value, "Basic stale")
+}
+
+func TestReconcileHandlesUpdateError(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	monitor := &monitoringv1alpha1.BetterStackMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			Generation: 4,
+			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackMonitorSpec{
+			URL:         "https://example.com",
+			MonitorType: "status",
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+			BaseURL: "https://api.test",
+		},
+		Status: monitoringv1alpha1.BetterStackMonitorStatus{MonitorID: "remote-123"},
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+
+	client := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(monitor).
+		WithObjects(monitor.DeepCopy(), secret.DeepCopy()).
+		Build()
+	service := &betterstackfakes.MonitorClient{
+		GetFn: func(ctx context.Context, id string) (betterstack.Monitor, error) {
+			return betterstack.Monitor{ID: id}, nil
+		},
+		UpdateFn: func(ctx context.Context, id string, req betterstack.MonitorUpdateRequest) (betterstack.Monitor, error) {
+			assert.String(t, "update id", id, "remote-123")
+			return betterstack.Monitor{}, &betterstack.APIError{StatusCode: http.StatusInternalServerError, Message: "boom"}
+		},
+	}
+	factory := &fakeBetterStackMonitorClientFactory{monitor: service}
+
+	r := &BetterStackMonitorReconciler{Client: client, Scheme: scheme, Clients: factory}
+
+	ctx := context.Background()
+	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}})
+	assert.NoError(t, err, "reconcile")
+	assert.Equal(t, "requeueAfter", res.RequeueAfter, requeueIntervalOnError)
+
+	updated := &monitoringv1alpha1.BetterStackMonitor{}
+	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}, updated), "fetch updated monitor")
+	assert.String(t, "monitor id", updated.Status.MonitorID, "remote-123")
+
+	syncCond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionSync)
+	assert.NotNil(t, "sync condition", syncCond)
+	assert.Equal(t, "sync status", syncCond.Status, metav1.ConditionFalse)
+	assert.String(t, "sync reason", syncCond.Reason, monitoringv1alpha1.ReasonSyncFailed)
+	readyCond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionReady)
+	assert.NotNil(t, "ready condition", readyCond)
+	assert.Equal(t, "ready status", readyCond.Status, metav1.ConditionFalse)
+	assert.String(t, "ready reason", readyCond.Reason, monitoringv1alpha1.ReasonSyncFailed)
+}
+
+func TestReconcileWaitsForRemoteFetchBeforeUpdatingHeaders(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	monitor := &monitoringv1alpha1.BetterStackMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			Generation: 2,
+			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackMonitorSpec{
+			URL:            "https://example.com",
+			MonitorType:    "status",
+			RequestHeaders: []monitoringv1alpha1.BetterStackHeader{{Name: "X-Env", Value: "prod"}},
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+		},
+		Status: monitoringv1alpha1.BetterStackMonitorStatus{MonitorID: "remote-123"},
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+
+	client := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(monitor).
+		WithObjects(monitor.DeepCopy(), secret.DeepCopy()).
+		Build()
+
+	getFails := true
+	service := &betterstackfakes.MonitorClient{
+		GetFn: func(ctx context.Context, id string) (betterstack.Monitor, error) {
+			if getFails {
+				return betterstack.Monitor{}, &betterstack.APIError{StatusCode: http.StatusBadGateway, Message: "upstream"}
+			}
+			monitor := betterstack.Monitor{ID: id}
+			monitor.Attributes.RequestHeaders = []betterstack.MonitorHeader{{ID: "hdr-1", Name: "X-Env", Value: "staging"}}
+			return monitor, nil
+		},
+		UpdateFn: func(ctx context.Context, id string, req betterstack.MonitorUpdateRequest) (betterstack.Monitor, error) {
+			return betterstack.Monitor{ID: id}, nil
+		},
+	}
+	factory := &fakeBetterStackMonitorClientFactory{monitor: service}
+	r := &BetterStackMonitorReconciler{Client: client, Scheme: scheme, Clients: factory}
+
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}}
+	res, err := r.Reconcile(ctx, req)
+	assert.NoError(t, err, "reconcile with failed get")
+	assert.Equal(t, "requeueAfter", res.RequeueAfter, requeueIntervalOnError)
+	assert.Int(t, "update calls after failed get", service.UpdateCalls, 0)
+
+	updated := &monitoringv1alpha1.BetterStackMonitor{}
+	assert.NoError(t, client.Get(ctx, req.NamespacedName, updated), "fetch monitor")
+	syncCond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionSync)
+	assert.NotNil(t, "sync condition", syncCond)
+	assert.String(t, "sync reason", syncCond.Reason, monitoringv1alpha1.ReasonRemoteFetchFailed)
+
+	getFails = false
+	_, err = r.Reconcile(ctx, req)
+	assert.NoError(t, err, "reconcile after get recovers")
+	assert.Int(t, "update calls", service.UpdateCalls, 1)
+	assert.Int(t, "request headers", len(service.LastUpdateReq.RequestHeaders), 1)
+	assert.NotNil(t, "header id", service.LastUpdateReq.RequestHeaders[0].ID)
+	assert.String(t, "header id", *service.LastUpdateReq.RequestHeaders[0].ID, "hdr-1")
+}
+
+func TestReconcileHandlesCreateError(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	monitor := &monitoringv1alpha1.BetterStackMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			Generation: 2,
+			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackMonitorSpec{
+			URL:         "https://example.com",
+			MonitorType: "status",
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+			BaseURL: "https://api.test",
+		},
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+
+	client := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(monitor).
+		WithObjects(monitor.DeepCopy(), secret.DeepCopy()).
+		Build()
+	service := &betterstackfakes.MonitorClient{
+		CreateFn: func(ctx context.Context, req betterstack.MonitorCreateRequest) (betterstack.Monitor, error) {
+			return betterstack.Monitor{}, &betterstack.APIError{StatusCode: http.StatusInternalServerError, Message: "boom"}
+		},
+	}
+	factory := &fakeBetterStackMonitorClientFactory{monitor: service}
+
+	r := &BetterStackMonitorReconciler{Client: client, Scheme: scheme, Clients: factory}
+
+	ctx := context.Background()
+	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}})
+	assert.NoError(t, err, "reconcile")
+	assert.Equal(t, "requeueAfter", res.RequeueAfter, requeueIntervalOnError)
+
+	updated := &monitoringv1alpha1.BetterStackMonitor{}
+	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}, updated), "fetch updated monitor")
+	assert.String(t, "monitor id", updated.Status.MonitorID, "")
+
+	syncCond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionSync)
+	assert.NotNil(t, "sync condition", syncCond)
+	assert.Equal(t, "sync status", syncCond.Status, metav1.ConditionFalse)
+	assert.String(t, "sync reason", syncCond.Reason, monitoringv1alpha1.ReasonSyncFailed)
 }
 
-func TestReconcileHandlesMissingCredentials(t *testing.T) {
+func TestReconcileHandlesQuotaExceeded(t *testing.T) {
 	scheme := controllertest.NewScheme(t)
 
 	monitor := &monitoringv1alpha1.BetterStackMonitor{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:       "example",
 			Namespace:  "default",
-			Generation: 7,
+			Generation: 4,
 			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorFinalizer},
 		},
 		Spec: monitoringv1alpha1.BetterStackMonitorSpec{
+			URL:         "https://example.com",
+			MonitorType: "status",
 			APITokenSecretRef: corev1.SecretKeySelector{
 				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
 				Key:                  "token",
 			},
+			BaseURL: "https://api.test",
 		},
 	}
 
-	client := fake.NewClientBuilder().
-		WithScheme(scheme).
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+
+	client := newIndexedClientBuilder(scheme).
 		WithStatusSubresource(monitor).
-		WithObjects(monitor.DeepCopy()).
+		WithObjects(monitor.DeepCopy(), secret.DeepCopy()).
 		Build()
 
-	r := &BetterStackMonitorReconciler{
-		Client: client,
-		Scheme: scheme,
+	service := &betterstackfakes.MonitorClient{
+		CreateFn: func(ctx context.Context, req betterstack.MonitorCreateRequest) (betterstack.Monitor, error) {
+			return betterstack.Monitor{}, &betterstack.APIError{StatusCode: http.StatusForbidden, Message: "Monitor quota reached. Please upgrade."}
+		},
 	}
+	factory := &fakeBetterStackMonitorClientFactory{monitor: service}
+
+	r := &BetterStackMonitorReconciler{Client: client, Scheme: scheme, Clients: factory}
 
 	ctx := context.Background()
 	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}})
@@ -177,39 +1340,37 @@ func TestReconcileHandlesMissingCredentials(t *testing.T) {
 	updated := &monitoringv1alpha1.BetterStackMonitor{}
 	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}, updated), "fetch updated monitor")
 
-	creds := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionCredentials)
-	assert.NotNil(t, "credentials condition", creds)
-	assert.Equal(t, "credentials status", creds.Status, metav1.ConditionFalse)
-	assert.String(t, "credentials reason", creds.Reason, "TokenUnavailable")
-	ready := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionReady)
-	assert.NotNil(t, "ready condition", ready)
-	assert.Equal(t, "ready status", ready.Status, metav1.ConditionFalse)
-	assert.String(t, "ready reason", ready.Reason, "TokenUnavailable")
+	syncCond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionSync)
+	assert.NotNil(t, "sync condition", syncCond)
+	assert.Equal(t, "sync status", syncCond.Status, metav1.ConditionFalse)
+	assert.String(t, "sync reason", syncCond.Reason, monitoringv1alpha1.ReasonMonitorQuotaExceeded)
+	assert.String(t, "sync message", syncCond.Message, "Better Stack monitor quota reached")
+
+	readyCond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionReady)
+	assert.NotNil(t, "ready condition", readyCond)
+	assert.Equal(t, "ready status", readyCond.Status, metav1.ConditionFalse)
+	assert.String(t, "ready reason", readyCond.Reason, monitoringv1alpha1.ReasonMonitorQuotaExceeded)
+	assert.String(t, "ready message", readyCond.Message, "Better Stack monitor quota reached")
 }
 
-func TestReconcileCreatesMonitorWhenRemoteMissing(t *testing.T) {
+func TestReconcileHandlesRegionUnavailable(t *testing.T) {
 	scheme := controllertest.NewScheme(t)
 
 	monitor := &monitoringv1alpha1.BetterStackMonitor{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:       "example",
 			Namespace:  "default",
-			Generation: 3,
 			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorFinalizer},
 		},
 		Spec: monitoringv1alpha1.BetterStackMonitorSpec{
-			URL:           "https://example.com",
-			MonitorType:   "status",
-			RequestMethod: "get",
+			URL:     "https://example.com",
+			Regions: []string{"us", "as"},
 			APITokenSecretRef: corev1.SecretKeySelector{
 				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
 				Key:                  "token",
 			},
 			BaseURL: "https://api.test",
 		},
-		Status: monitoringv1alpha1.BetterStackMonitorStatus{
-			MonitorID: "remote-123",
-		},
 	}
 
 	secret := &corev1.Secret{
@@ -217,76 +1378,66 @@ func TestReconcileCreatesMonitorWhenRemoteMissing(t *testing.T) {
 		Data:       map[string][]byte{"token": []byte("abcd")},
 	}
 
-	client := fake.NewClientBuilder().
-		WithScheme(scheme).
+	client := newIndexedClientBuilder(scheme).
 		WithStatusSubresource(monitor).
 		WithObjects(monitor.DeepCopy(), secret.DeepCopy()).
 		Build()
 
-	service := &fakeMonitorService{
-		getFn: func(ctx context.Context, id string) (betterstack.Monitor, error) {
-			assert.String(t, "get id", id, "remote-123")
-			return betterstack.Monitor{}, &betterstack.APIError{StatusCode: http.StatusNotFound}
-		},
-		updateFn: func(ctx context.Context, id string, req betterstack.MonitorUpdateRequest) (betterstack.Monitor, error) {
-			assert.String(t, "update id", id, "remote-123")
-			return betterstack.Monitor{}, &betterstack.APIError{StatusCode: http.StatusNotFound}
-		},
-		createFn: func(ctx context.Context, req betterstack.MonitorCreateRequest) (betterstack.Monitor, error) {
-			assert.NotNil(t, "request url", req.URL)
-			assert.String(t, "request url", *req.URL, "https://example.com")
-			assert.NotNil(t, "request type", req.MonitorType)
-			assert.String(t, "request type", *req.MonitorType, "status")
-			assert.NotNil(t, "request method", req.HTTPMethod)
-			assert.String(t, "request method", *req.HTTPMethod, "get")
-			return betterstack.Monitor{ID: "new-id"}, nil
+	service := &betterstackfakes.MonitorClient{
+		CreateFn: func(ctx context.Context, req betterstack.MonitorCreateRequest) (betterstack.Monitor, error) {
+			if slices.Contains(req.Regions, "as") {
+				return betterstack.Monitor{}, &betterstack.APIError{StatusCode: http.StatusUnprocessableEntity, Message: `{"errors":{"regions":["are not included in your plan"]}}`}
+			}
+			return betterstack.Monitor{ID: "remote-1"}, nil
 		},
 	}
-	factory := &fakeBetterStackMonitorClientFactory{monitor: service}
-
-	r := &BetterStackMonitorReconciler{
-		Client:  client,
-		Scheme:  scheme,
-		Clients: factory,
-	}
+	r := &BetterStackMonitorReconciler{Client: client, Scheme: scheme, Clients: &fakeBetterStackMonitorClientFactory{monitor: service}}
 
 	ctx := context.Background()
-	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}})
-	assert.NoError(t, err, "reconcile")
-	assert.Equal(t, "requeueAfter", res.RequeueAfter, time.Duration(0))
+	key := types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}
+	for attempt := 1; attempt <= 2; attempt++ {
+		expireBackoff(t, client, key)
+		res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		assert.NoError(t, err, "reconcile")
+		assert.Bool(t, "requeueAfter within backoff", res.RequeueAfter >= requeueIntervalOnError && res.RequeueAfter <= 2*requeueIntervalOnError, true)
+
+		updated := &monitoringv1alpha1.BetterStackMonitor{}
+		assert.NoError(t, client.Get(ctx, key, updated), "fetch updated monitor")
+		syncCond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionSync)
+		assert.NotNil(t, "sync condition", syncCond)
+		assert.String(t, "sync reason", syncCond.Reason, monitoringv1alpha1.ReasonRegionUnavailable)
+	}
+	// The rejection is cached, so the second attempt does not call Better Stack again.
+	assert.Int(t, "create calls", service.CreateCalls, 1)
 
 	updated := &monitoringv1alpha1.BetterStackMonitor{}
-	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}, updated), "fetch updated monitor")
-	assert.String(t, "monitor id", updated.Status.MonitorID, "new-id")
-	assert.Equal(t, "observed generation", updated.Status.ObservedGeneration, monitor.Generation)
-	ready := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionReady)
-	assert.NotNil(t, "ready condition", ready)
-	assert.Equal(t, "ready status", ready.Status, metav1.ConditionTrue)
-	assert.String(t, "ready reason", ready.Reason, "MonitorSynced")
-	assert.Int(t, "monitor factory calls", factory.monitorCalls, 1)
-	assert.String(t, "last token", factory.lastMonitorToken, "abcd")
+	assert.NoError(t, client.Get(ctx, key, updated), "fetch monitor")
+	updated.Spec.Regions = []string{"us"}
+	assert.NoError(t, client.Update(ctx, updated), "drop unavailable region")
+
+	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile with available regions")
+	assert.Equal(t, "requeueAfter", res.RequeueAfter, time.Duration(0))
+	assert.Int(t, "create calls", service.CreateCalls, 2)
 }
 
-func TestReconcileHandlesUpdateError(t *testing.T) {
+func TestReconcileUsesCachedRemoteMonitor(t *testing.T) {
 	scheme := controllertest.NewScheme(t)
 
 	monitor := &monitoringv1alpha1.BetterStackMonitor{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:       "example",
 			Namespace:  "default",
-			Generation: 4,
 			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorFinalizer},
 		},
 		Spec: monitoringv1alpha1.BetterStackMonitorSpec{
-			URL:         "https://example.com",
-			MonitorType: "status",
+			URL: "https://example.com",
 			APITokenSecretRef: corev1.SecretKeySelector{
 				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
 				Key:                  "token",
 			},
 			BaseURL: "https://api.test",
 		},
-		Status: monitoringv1alpha1.BetterStackMonitorStatus{MonitorID: "remote-123"},
 	}
 
 	secret := &corev1.Secret{
@@ -294,61 +1445,73 @@ func TestReconcileHandlesUpdateError(t *testing.T) {
 		Data:       map[string][]byte{"token": []byte("abcd")},
 	}
 
-	client := fake.NewClientBuilder().
-		WithScheme(scheme).
+	client := newIndexedClientBuilder(scheme).
 		WithStatusSubresource(monitor).
 		WithObjects(monitor.DeepCopy(), secret.DeepCopy()).
 		Build()
-	service := &fakeMonitorService{
-		getFn: func(ctx context.Context, id string) (betterstack.Monitor, error) {
-			return betterstack.Monitor{ID: id}, nil
+
+	remote := betterstack.Monitor{ID: "remote-1", Attributes: betterstack.MonitorAttributes{URL: "https://example.com"}}
+	service := &betterstackfakes.MonitorClient{
+		GetFn: func(ctx context.Context, id string) (betterstack.Monitor, error) {
+			return remote, nil
 		},
-		updateFn: func(ctx context.Context, id string, req betterstack.MonitorUpdateRequest) (betterstack.Monitor, error) {
-			assert.String(t, "update id", id, "remote-123")
-			return betterstack.Monitor{}, &betterstack.APIError{StatusCode: http.StatusInternalServerError, Message: "boom"}
+		CreateFn: func(ctx context.Context, req betterstack.MonitorCreateRequest) (betterstack.Monitor, error) {
+			return remote, nil
+		},
+		UpdateFn: func(ctx context.Context, id string, req betterstack.MonitorUpdateRequest) (betterstack.Monitor, error) {
+			return remote, nil
 		},
 	}
-	factory := &fakeBetterStackMonitorClientFactory{monitor: service}
-
-	r := &BetterStackMonitorReconciler{Client: client, Scheme: scheme, Clients: factory}
+	r := &BetterStackMonitorReconciler{
+		Client:         client,
+		Scheme:         scheme,
+		Clients:        &fakeBetterStackMonitorClientFactory{monitor: service},
+		RemoteCacheTTL: time.Minute,
+	}
 
 	ctx := context.Background()
-	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}})
-	assert.NoError(t, err, "reconcile")
-	assert.Equal(t, "requeueAfter", res.RequeueAfter, requeueIntervalOnError)
-
-	updated := &monitoringv1alpha1.BetterStackMonitor{}
-	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}, updated), "fetch updated monitor")
-	assert.String(t, "monitor id", updated.Status.MonitorID, "remote-123")
+	key := types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}
+	for attempt := 1; attempt <= 3; attempt++ {
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		assert.NoError(t, err, "reconcile")
+	}
+	assert.Int(t, "create calls", service.CreateCalls, 1)
+	assert.Int(t, "get calls", service.GetCalls, 0)
 
-	syncCond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionSync)
-	assert.NotNil(t, "sync condition", syncCond)
-	assert.Equal(t, "sync status", syncCond.Status, metav1.ConditionFalse)
-	assert.String(t, "sync reason", syncCond.Reason, "SyncFailed")
-	readyCond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionReady)
-	assert.NotNil(t, "ready condition", readyCond)
-	assert.Equal(t, "ready status", readyCond.Status, metav1.ConditionFalse)
-	assert.String(t, "ready reason", readyCond.Reason, "SyncFailed")
+	// A failed write drops the entry, so the next reconcile reads Better Stack again.
+	service.UpdateFn = func(ctx context.Context, id string, req betterstack.MonitorUpdateRequest) (betterstack.Monitor, error) {
+		return betterstack.Monitor{}, &betterstack.APIError{StatusCode: http.StatusInternalServerError, Message: "boom"}
+	}
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile with failing update")
+	expireBackoff(t, client, key)
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile after failed update")
+	assert.Int(t, "get calls", service.GetCalls, 1)
+
+	r.RemoteCacheTTL = 0
+	expireBackoff(t, client, key)
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile without cache")
+	assert.Int(t, "get calls", service.GetCalls, 2)
 }
 
-func TestReconcileHandlesCreateError(t *testing.T) {
+func TestReconcileIgnoresUnsupportedTags(t *testing.T) {
 	scheme := controllertest.NewScheme(t)
 
 	monitor := &monitoringv1alpha1.BetterStackMonitor{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:       "example",
 			Namespace:  "default",
-			Generation: 2,
 			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorFinalizer},
 		},
 		Spec: monitoringv1alpha1.BetterStackMonitorSpec{
-			URL:         "https://example.com",
-			MonitorType: "status",
+			URL:  "https://example.com",
+			Tags: []string{"team:sre"},
 			APITokenSecretRef: corev1.SecretKeySelector{
 				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
 				Key:                  "token",
 			},
-			BaseURL: "https://api.test",
 		},
 	}
 
@@ -357,53 +1520,53 @@ func TestReconcileHandlesCreateError(t *testing.T) {
 		Data:       map[string][]byte{"token": []byte("abcd")},
 	}
 
-	client := fake.NewClientBuilder().
-		WithScheme(scheme).
+	client := newIndexedClientBuilder(scheme).
 		WithStatusSubresource(monitor).
 		WithObjects(monitor.DeepCopy(), secret.DeepCopy()).
 		Build()
-	service := &fakeMonitorService{
-		createFn: func(ctx context.Context, req betterstack.MonitorCreateRequest) (betterstack.Monitor, error) {
-			return betterstack.Monitor{}, &betterstack.APIError{StatusCode: http.StatusInternalServerError, Message: "boom"}
+
+	service := &betterstackfakes.MonitorClient{
+		CreateFn: func(ctx context.Context, req betterstack.MonitorCreateRequest) (betterstack.Monitor, error) {
+			if len(req.Tags) > 0 {
+				return betterstack.Monitor{}, &betterstack.APIError{StatusCode: http.StatusBadRequest, Message: "Unknown attribute: tags"}
+			}
+			return betterstack.Monitor{ID: "remote-1"}, nil
 		},
 	}
-	factory := &fakeBetterStackMonitorClientFactory{monitor: service}
-
-	r := &BetterStackMonitorReconciler{Client: client, Scheme: scheme, Clients: factory}
+	r := &BetterStackMonitorReconciler{Client: client, Scheme: scheme, Clients: &fakeBetterStackMonitorClientFactory{monitor: service}}
 
 	ctx := context.Background()
-	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}})
+	key := types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}
+	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
 	assert.NoError(t, err, "reconcile")
-	assert.Equal(t, "requeueAfter", res.RequeueAfter, requeueIntervalOnError)
+	assert.Equal(t, "requeueAfter", res.RequeueAfter, time.Duration(0))
+	assert.Int(t, "create calls", service.CreateCalls, 2)
 
 	updated := &monitoringv1alpha1.BetterStackMonitor{}
-	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}, updated), "fetch updated monitor")
-	assert.String(t, "monitor id", updated.Status.MonitorID, "")
-
+	assert.NoError(t, client.Get(ctx, key, updated), "fetch updated monitor")
+	assert.String(t, "monitor id", updated.Status.MonitorID, "remote-1")
 	syncCond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionSync)
 	assert.NotNil(t, "sync condition", syncCond)
-	assert.Equal(t, "sync status", syncCond.Status, metav1.ConditionFalse)
-	assert.String(t, "sync reason", syncCond.Reason, "SyncFailed")
+	assert.String(t, "sync reason", syncCond.Reason, monitoringv1alpha1.ReasonMonitorSynced)
+	assert.String(t, "sync message", syncCond.Message, "Monitor synchronized with Better Stack; "+tagsIgnoredMessage)
 }
 
-func TestReconcileHandlesQuotaExceeded(t *testing.T) {
+func TestReconcileReportsDeprecatedFields(t *testing.T) {
 	scheme := controllertest.NewScheme(t)
 
 	monitor := &monitoringv1alpha1.BetterStackMonitor{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:       "example",
 			Namespace:  "default",
-			Generation: 4,
 			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorFinalizer},
 		},
 		Spec: monitoringv1alpha1.BetterStackMonitorSpec{
-			URL:         "https://example.com",
-			MonitorType: "status",
+			URL:                "https://example.com",
+			ExpectedStatusCode: 204,
 			APITokenSecretRef: corev1.SecretKeySelector{
 				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
 				Key:                  "token",
 			},
-			BaseURL: "https://api.test",
 		},
 	}
 
@@ -412,40 +1575,46 @@ func TestReconcileHandlesQuotaExceeded(t *testing.T) {
 		Data:       map[string][]byte{"token": []byte("abcd")},
 	}
 
-	client := fake.NewClientBuilder().
-		WithScheme(scheme).
+	client := newIndexedClientBuilder(scheme).
 		WithStatusSubresource(monitor).
 		WithObjects(monitor.DeepCopy(), secret.DeepCopy()).
 		Build()
 
-	service := &fakeMonitorService{
-		createFn: func(ctx context.Context, req betterstack.MonitorCreateRequest) (betterstack.Monitor, error) {
-			return betterstack.Monitor{}, &betterstack.APIError{StatusCode: http.StatusForbidden, Message: "Monitor quota reached. Please upgrade."}
+	service := &betterstackfakes.MonitorClient{
+		CreateFn: func(ctx context.Context, req betterstack.MonitorCreateRequest) (betterstack.Monitor, error) {
+			return betterstack.Monitor{ID: "remote-1"}, nil
+		},
+		UpdateFn: func(ctx context.Context, id string, req betterstack.MonitorUpdateRequest) (betterstack.Monitor, error) {
+			return betterstack.Monitor{ID: id}, nil
 		},
 	}
-	factory := &fakeBetterStackMonitorClientFactory{monitor: service}
-
-	r := &BetterStackMonitorReconciler{Client: client, Scheme: scheme, Clients: factory}
+	r := &BetterStackMonitorReconciler{Client: client, Scheme: scheme, Clients: &fakeBetterStackMonitorClientFactory{monitor: service}}
 
 	ctx := context.Background()
-	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}})
+	key := types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}
+	before := testutil.ToFloat64(metrics.DeprecatedFieldUsage.WithLabelValues("expectedStatusCode"))
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
 	assert.NoError(t, err, "reconcile")
-	assert.Equal(t, "requeueAfter", res.RequeueAfter, requeueIntervalOnError)
+	assert.Equal(t, "deprecated field usage", testutil.ToFloat64(metrics.DeprecatedFieldUsage.WithLabelValues("expectedStatusCode"))-before, 1.0)
 
 	updated := &monitoringv1alpha1.BetterStackMonitor{}
-	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}, updated), "fetch updated monitor")
-
-	syncCond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionSync)
-	assert.NotNil(t, "sync condition", syncCond)
-	assert.Equal(t, "sync status", syncCond.Status, metav1.ConditionFalse)
-	assert.String(t, "sync reason", syncCond.Reason, ReasonMonitorQuotaExceeded)
-	assert.String(t, "sync message", syncCond.Message, "Better Stack monitor quota reached")
-
-	readyCond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionReady)
-	assert.NotNil(t, "ready condition", readyCond)
-	assert.Equal(t, "ready status", readyCond.Status, metav1.ConditionFalse)
-	assert.String(t, "ready reason", readyCond.Reason, ReasonMonitorQuotaExceeded)
-	assert.String(t, "ready message", readyCond.Message, "Better Stack monitor quota reached")
+	assert.NoError(t, client.Get(ctx, key, updated), "fetch updated monitor")
+	cond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionDeprecatedFields)
+	assert.NotNil(t, "deprecated fields condition", cond)
+	assert.Equal(t, "deprecated fields status", cond.Status, metav1.ConditionTrue)
+	assert.String(t, "deprecated fields message", cond.Message, "spec.expectedStatusCode is deprecated, use spec.expectedStatusCodes")
+
+	updated.Spec.ExpectedStatusCode = 0
+	updated.Spec.ExpectedStatusCodes = []int{204}
+	assert.NoError(t, client.Update(ctx, updated), "migrate to expectedStatusCodes")
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile after migration")
+
+	assert.NoError(t, client.Get(ctx, key, updated), "fetch migrated monitor")
+	cond = controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionDeprecatedFields)
+	assert.NotNil(t, "deprecated fields condition", cond)
+	assert.Equal(t, "deprecated fields status", cond.Status, metav1.ConditionFalse)
+	assert.String(t, "deprecated fields reason", cond.Reason, monitoringv1alpha1.ReasonNoDeprecatedFields)
 }
 
 func TestReconcileHandlesUpdateQuotaExceeded(t *testing.T) {
@@ -475,17 +1644,16 @@ func TestReconcileHandlesUpdateQuotaExceeded(t *testing.T) {
 		Data:       map[string][]byte{"token": []byte("abcd")},
 	}
 
-	client := fake.NewClientBuilder().
-		WithScheme(scheme).
+	client := newIndexedClientBuilder(scheme).
 		WithStatusSubresource(monitor).
 		WithObjects(monitor.DeepCopy(), secret.DeepCopy()).
 		Build()
 
-	service := &fakeMonitorService{
-		getFn: func(ctx context.Context, id string) (betterstack.Monitor, error) {
+	service := &betterstackfakes.MonitorClient{
+		GetFn: func(ctx context.Context, id string) (betterstack.Monitor, error) {
 			return betterstack.Monitor{ID: id}, nil
 		},
-		updateFn: func(ctx context.Context, id string, req betterstack.MonitorUpdateRequest) (betterstack.Monitor, error) {
+		UpdateFn: func(ctx context.Context, id string, req betterstack.MonitorUpdateRequest) (betterstack.Monitor, error) {
 			return betterstack.Monitor{}, &betterstack.APIError{StatusCode: http.StatusForbidden, Message: "Monitor quota exceeded"}
 		},
 	}
@@ -504,13 +1672,13 @@ func TestReconcileHandlesUpdateQuotaExceeded(t *testing.T) {
 	syncCond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionSync)
 	assert.NotNil(t, "sync condition", syncCond)
 	assert.Equal(t, "sync status", syncCond.Status, metav1.ConditionFalse)
-	assert.String(t, "sync reason", syncCond.Reason, ReasonMonitorQuotaExceeded)
+	assert.String(t, "sync reason", syncCond.Reason, monitoringv1alpha1.ReasonMonitorQuotaExceeded)
 	assert.String(t, "sync message", syncCond.Message, "Better Stack monitor quota reached")
 
 	readyCond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionReady)
 	assert.NotNil(t, "ready condition", readyCond)
 	assert.Equal(t, "ready status", readyCond.Status, metav1.ConditionFalse)
-	assert.String(t, "ready reason", readyCond.Reason, ReasonMonitorQuotaExceeded)
+	assert.String(t, "ready reason", readyCond.Reason, monitoringv1alpha1.ReasonMonitorQuotaExceeded)
 	assert.String(t, "ready message", readyCond.Message, "Better Stack monitor quota reached")
 }
 
@@ -540,14 +1708,13 @@ func TestReconcileHandlesDeletion(t *testing.T) {
 		Data:       map[string][]byte{"token": []byte("abcd")},
 	}
 
-	client := fake.NewClientBuilder().
-		WithScheme(scheme).
+	client := newIndexedClientBuilder(scheme).
 		WithStatusSubresource(monitor).
 		WithObjects(monitor.DeepCopy(), secret.DeepCopy()).
 		Build()
 	deleted := false
-	service := &fakeMonitorService{
-		deleteFn: func(ctx context.Context, id string) error {
+	service := &betterstackfakes.MonitorClient{
+		DeleteFn: func(ctx context.Context, id string) error {
 			assert.String(t, "delete id", id, "remote-123")
 			deleted = true
 			return nil
@@ -592,12 +1759,11 @@ func TestReconcileHandlesDeletionMissingCredentials(t *testing.T) {
 		Status: monitoringv1alpha1.BetterStackMonitorStatus{MonitorID: "remote-123"},
 	}
 
-	client := fake.NewClientBuilder().
-		WithScheme(scheme).
+	client := newIndexedClientBuilder(scheme).
 		WithStatusSubresource(monitor).
 		WithObjects(monitor.DeepCopy()).
 		Build()
-	factory := &fakeBetterStackMonitorClientFactory{monitor: &fakeMonitorService{}}
+	factory := &fakeBetterStackMonitorClientFactory{monitor: &betterstackfakes.MonitorClient{}}
 
 	r := &BetterStackMonitorReconciler{Client: client, Scheme: scheme, Clients: factory}
 
@@ -643,13 +1809,12 @@ func TestReconcileHandlesDeletionRemoteNotFound(t *testing.T) {
 		Data:       map[string][]byte{"token": []byte("abcd")},
 	}
 
-	client := fake.NewClientBuilder().
-		WithScheme(scheme).
+	client := newIndexedClientBuilder(scheme).
 		WithStatusSubresource(monitor).
 		WithObjects(monitor.DeepCopy(), secret.DeepCopy()).
 		Build()
-	service := &fakeMonitorService{
-		deleteFn: func(ctx context.Context, id string) error {
+	service := &betterstackfakes.MonitorClient{
+		DeleteFn: func(ctx context.Context, id string) error {
 			return &betterstack.APIError{StatusCode: http.StatusNotFound}
 		},
 	}
@@ -679,8 +1844,7 @@ func TestFetchAPITokenValidation(t *testing.T) {
 		Data:       map[string][]byte{"token": []byte("abcd")},
 	}
 
-	client := fake.NewClientBuilder().
-		WithScheme(scheme).
+	client := newIndexedClientBuilder(scheme).
 		WithObjects(secret.DeepCopy()).
 		Build()
 
@@ -734,15 +1898,14 @@ func TestReconcileReturnsErrorWhenStatusPatchFails(t *testing.T) {
 		Data:       map[string][]byte{"token": []byte("abcd")},
 	}
 
-	baseClient := fake.NewClientBuilder().
-		WithScheme(scheme).
+	baseClient := newIndexedClientBuilder(scheme).
 		WithStatusSubresource(monitor).
 		WithObjects(monitor.DeepCopy(), secret.DeepCopy()).
 		Build()
 
 	failingClient := &controllertest.FailingStatusClient{Client: baseClient, FailOn: 2}
-	service := &fakeMonitorService{
-		createFn: func(ctx context.Context, req betterstack.MonitorCreateRequest) (betterstack.Monitor, error) {
+	service := &betterstackfakes.MonitorClient{
+		CreateFn: func(ctx context.Context, req betterstack.MonitorCreateRequest) (betterstack.Monitor, error) {
 			return betterstack.Monitor{ID: "new-id"}, nil
 		},
 	}
@@ -758,108 +1921,173 @@ func TestReconcileReturnsErrorWhenStatusPatchFails(t *testing.T) {
 	assert.Int(t, "status attempts", failingClient.Calls(), 2)
 }
 
-func TestBuildMonitorRequest(t *testing.T) {
-	spec := monitoringv1alpha1.BetterStackMonitorSpec{
-		URL:                       "https://example.com",
-		Name:                      "Example",
-		MonitorType:               "status",
-		TeamName:                  "SRE",
-		CheckFrequencyMinutes:     3,
-		Regions:                   []string{"us", "eu"},
-		RequestMethod:             "post",
-		ExpectedStatusCodes:       []int{201, 202},
-		RequiredKeyword:           "healthy",
-		Paused:                    true,
-		Email:                     ptr.To(false),
-		SMS:                       ptr.To(true),
-		Call:                      ptr.To(false),
-		Push:                      ptr.To(true),
-		CriticalAlert:             ptr.To(true),
-		FollowRedirects:           ptr.To(true),
-		VerifySSL:                 ptr.To(false),
-		RememberCookies:           ptr.To(true),
-		PolicyID:                  "policy-1",
-		ExpirationPolicyID:        "exp-1",
-		MonitorGroupID:            "group-1",
-		TeamWaitSeconds:           120,
-		DomainExpirationDays:      14,
-		SSLExpirationDays:         30,
-		Port:                      443,
-		RequestTimeoutSeconds:     30,
-		RecoveryPeriodSeconds:     300,
-		ConfirmationPeriodSeconds: 60,
-		IPVersion:                 "ipv6",
-		MaintenanceDays:           []string{"mon", "tue"},
-		MaintenanceFrom:           "01:00:00",
-		MaintenanceTo:             "02:00:00",
-		MaintenanceTimezone:       "UTC",
-		RequestHeaders: []monitoringv1alpha1.BetterStackHeader{{
-			Name:  "Content-Type",
-			Value: "application/json",
-		}},
-		RequestBody:          "{}",
-		AuthUsername:         "user",
-		AuthPassword:         "pass",
-		EnvironmentVariables: map[string]string{"TOKEN": "value"},
-		PlaywrightScript:     "console.log('ok')",
-		ScenarioName:         "Scenario",
-		AdditionalAttributes: map[string]string{"custom": "value"},
-	}
-
-	want := map[string]any{
-		"url":                   spec.URL,
-		"pronounceable_name":    spec.Name,
-		"monitor_type":          spec.MonitorType,
-		"team_name":             spec.TeamName,
-		"check_frequency":       spec.CheckFrequencyMinutes * 60,
-		"regions":               spec.Regions,
-		"http_method":           "post",
-		"expected_status_codes": spec.ExpectedStatusCodes,
-		"required_keyword":      spec.RequiredKeyword,
-		"paused":                true,
-		"email":                 false,
-		"sms":                   true,
-		"call":                  false,
-		"push":                  true,
-		"critical_alert":        true,
-		"follow_redirects":      true,
-		"verify_ssl":            false,
-		"remember_cookies":      true,
-		"policy_id":             spec.PolicyID,
-		"expiration_policy_id":  spec.ExpirationPolicyID,
-		"monitor_group_id":      spec.MonitorGroupID,
-		"team_wait":             spec.TeamWaitSeconds,
-		"domain_expiration":     spec.DomainExpirationDays,
-		"ssl_expiration":        spec.SSLExpirationDays,
-		"port":                  "443",
-		"request_timeout":       spec.RequestTimeoutSeconds,
-		"recovery_period":       spec.RecoveryPeriodSeconds,
-		"confirmation_period":   spec.ConfirmationPeriodSeconds,
-		"ip_version":            spec.IPVersion,
-		"maintenance_days":      spec.MaintenanceDays,
-		"maintenance_from":      spec.MaintenanceFrom,
-		"maintenance_to":        spec.MaintenanceTo,
-		"maintenance_timezone":  spec.MaintenanceTimezone,
-		"request_headers":       []map[string]string{{"name": "Content-Type", "value": "application/json"}},
-		"request_body":          spec.RequestBody,
-		"auth_username":         spec.AuthUsername,
-		"auth_password":         spec.AuthPassword,
-		"environment_variables": spec.EnvironmentVariables,
-		"playwright_script":     spec.PlaywrightScript,
-		"scenario_name":         spec.ScenarioName,
-		"custom":                "value",
-	}
-	wantedJSON, err := json.Marshal(want)
-	assert.NoError(t, err, "marshal want")
-	wanted := map[string]any{}
-	assert.NoError(t, json.Unmarshal(wantedJSON, &wanted), "unmarshal want")
+// monitorSpecFieldsNotInRequest lists spec fields that configure the operator rather than
+// the remote monitor.
+var monitorSpecFieldsNotInRequest = map[string]bool{
+	"BearerTokenSecretRef": true,
+	"PreflightCheck":       true,
+	"Adopt":                true,
+	"OnConflict":           true,
+	"ExistingMonitorID":    true,
+	"PausedUntil":          true,
+	"OneTimeMaintenance":   true,
+	"UnmanagedFields":      true,
+	"BaseURL":              true,
+	"APITokenSecretRef":    true,
+	"AccountRef":           true,
+	"Suspend":              true,
+}
+
+type monitorSpec = monitoringv1alpha1.BetterStackMonitorSpec
+
+// monitorRequestFieldCases maps every spec field that reaches the API onto the request
+// attribute it sets. Leaving a field at its zero value must omit the attribute unless
+// unset says otherwise; clear, when present, is the field's explicit "off" value and must
+// send cleared (nil meaning omitted).
+var monitorRequestFieldCases = []struct {
+	field   string
+	key     string
+	set     monitorSpec
+	want    any
+	unset   any
+	clear   *monitorSpec
+	cleared any
+}{
+	{field: "URL", key: "url", set: monitorSpec{URL: "https://example.com"}, want: "https://example.com"},
+	{field: "Name", key: "pronounceable_name", set: monitorSpec{Name: "Example"}, want: "Example"},
+	{field: "MonitorType", key: "monitor_type", set: monitorSpec{MonitorType: "status"}, want: "status"},
+	{field: "TeamName", key: "team_name", set: monitorSpec{TeamName: "SRE"}, want: "SRE"},
+	{field: "CheckFrequencyMinutes", key: "check_frequency", set: monitorSpec{CheckFrequencyMinutes: 3}, want: 180},
+	{field: "Regions", key: "regions", set: monitorSpec{Regions: []string{"us", "eu"}}, want: []string{"us", "eu"},
+		clear: &monitorSpec{Regions: []string{}}},
+	{field: "Tags", key: "tags", set: monitorSpec{Tags: []string{"team:sre", "tier:1"}}, want: []string{"team:sre", "tier:1"},
+		clear: &monitorSpec{Tags: []string{}}},
+	{field: "RequestMethod", key: "http_method", set: monitorSpec{RequestMethod: "POST"}, want: "post"},
+	{field: "ExpectedStatusCode", key: "expected_status_codes", set: monitorSpec{ExpectedStatusCode: 204}, want: []int{204}},
+	{field: "ExpectedStatusCodes", key: "expected_status_codes", set: monitorSpec{ExpectedStatusCodes: []int{201, 202}}, want: []int{201, 202},
+		clear: &monitorSpec{ExpectedStatusCodes: []int{}}},
+	{field: "RequiredKeyword", key: "required_keyword", set: monitorSpec{RequiredKeyword: "healthy"}, want: "healthy"},
+	{field: "Paused", key: "paused", set: monitorSpec{Paused: true}, want: true, unset: false},
+	{field: "Email", key: "email", set: monitorSpec{Email: ptr.To(true)}, want: true,
+		clear: &monitorSpec{Email: ptr.To(false)}, cleared: false},
+	{field: "SMS", key: "sms", set: monitorSpec{SMS: ptr.To(true)}, want: true,
+		clear: &monitorSpec{SMS: ptr.To(false)}, cleared: false},
+	{field: "Call", key: "call", set: monitorSpec{Call: ptr.To(true)}, want: true,
+		clear: &monitorSpec{Call: ptr.To(false)}, cleared: false},
+	{field: "Push", key: "push", set: monitorSpec{Push: ptr.To(true)}, want: true,
+		clear: &monitorSpec{Push: ptr.To(false)}, cleared: false},
+	{field: "CriticalAlert", key: "critical_alert", set: monitorSpec{CriticalAlert: ptr.To(true)}, want: true,
+		clear: &monitorSpec{CriticalAlert: ptr.To(false)}, cleared: false},
+	{field: "FollowRedirects", key: "follow_redirects", set: monitorSpec{FollowRedirects: ptr.To(true)}, want: true,
+		clear: &monitorSpec{FollowRedirects: ptr.To(false)}, cleared: false},
+	{field: "VerifySSL", key: "verify_ssl", set: monitorSpec{VerifySSL: ptr.To(true)}, want: true,
+		clear: &monitorSpec{VerifySSL: ptr.To(false)}, cleared: false},
+	{field: "RememberCookies", key: "remember_cookies", set: monitorSpec{RememberCookies: ptr.To(true)}, want: true,
+		clear: &monitorSpec{RememberCookies: ptr.To(false)}, cleared: false},
+	{field: "PolicyID", key: "policy_id", set: monitorSpec{PolicyID: "policy-1"}, want: "policy-1"},
+	{field: "ExpirationPolicyID", key: "expiration_policy_id", set: monitorSpec{ExpirationPolicyID: "exp-1"}, want: "exp-1"},
+	{field: "MonitorGroupID", key: "monitor_group_id", set: monitorSpec{MonitorGroupID: "group-1"}, want: "group-1"},
+	{field: "TeamWaitSeconds", key: "team_wait", set: monitorSpec{TeamWaitSeconds: 120}, want: 120},
+	{field: "DomainExpirationDays", key: "domain_expiration", set: monitorSpec{DomainExpirationDays: 14}, want: 14},
+	{field: "SSLExpirationDays", key: "ssl_expiration", set: monitorSpec{SSLExpirationDays: 30}, want: 30},
+	{field: "Port", key: "port", set: monitorSpec{Port: 443}, want: "443"},
+	{field: "RequestTimeoutSeconds", key: "request_timeout", set: monitorSpec{RequestTimeoutSeconds: 30}, want: 30},
+	{field: "PlaywrightTimeoutSeconds", key: "request_timeout", set: monitorSpec{PlaywrightTimeoutSeconds: 45}, want: 45},
+	{field: "RecoveryPeriodSeconds", key: "recovery_period", set: monitorSpec{RecoveryPeriodSeconds: 300}, want: 300},
+	{field: "ConfirmationPeriodSeconds", key: "confirmation_period", set: monitorSpec{ConfirmationPeriodSeconds: 60}, want: 60},
+	{field: "IPVersion", key: "ip_version", set: monitorSpec{IPVersion: "ipv6"}, want: "ipv6"},
+	{field: "MaintenanceDays", key: "maintenance_days", set: monitorSpec{MaintenanceDays: []string{"mon", "tue"}}, want: []string{"mon", "tue"},
+		clear: &monitorSpec{MaintenanceDays: []string{}}},
+	{field: "MaintenanceFrom", key: "maintenance_from", set: monitorSpec{MaintenanceFrom: "01:00:00"}, want: "01:00:00"},
+	{field: "MaintenanceTo", key: "maintenance_to", set: monitorSpec{MaintenanceTo: "02:00:00"}, want: "02:00:00"},
+	{field: "MaintenanceTimezone", key: "maintenance_timezone", set: monitorSpec{MaintenanceTimezone: "UTC"}, want: "UTC"},
+	{field: "RequestHeaders", key: "request_headers",
+		set:   monitorSpec{RequestHeaders: []monitoringv1alpha1.BetterStackHeader{{Name: "Content-Type", Value: "application/json"}}},
+		want:  []map[string]string{{"name": "Content-Type", "value": "application/json"}},
+		clear: &monitorSpec{RequestHeaders: []monitoringv1alpha1.BetterStackHeader{}}},
+	{field: "RequestBody", key: "request_body", set: monitorSpec{RequestBody: "{}"}, want: "{}"},
+	{field: "AuthUsername", key: "auth_username", set: monitorSpec{AuthUsername: "user"}, want: "user"},
+	{field: "AuthPassword", key: "auth_password", set: monitorSpec{AuthPassword: "pass"}, want: "pass"},
+	{field: "EnvironmentVariables", key: "environment_variables", set: monitorSpec{EnvironmentVariables: map[string]string{"TOKEN": "value"}}, want: map[string]string{"TOKEN": "value"},
+		clear: &monitorSpec{EnvironmentVariables: map[string]string{}}},
+	{field: "PlaywrightScript", key: "playwright_script", set: monitorSpec{PlaywrightScript: "console.log('ok')"}, want: "console.log('ok')"},
+	{field: "ScenarioName", key: "scenario_name", set: monitorSpec{ScenarioName: "Scenario"}, want: "Scenario"},
+	{field: "AdditionalAttributes", key: "custom", set: monitorSpec{AdditionalAttributes: map[string]string{"custom": "value"}}, want: "value",
+		clear: &monitorSpec{AdditionalAttributes: map[string]string{}}},
+}
+
+func TestBuildMonitorRequestFields(t *testing.T) {
+	for _, tc := range monitorRequestFieldCases {
+		t.Run(tc.field, func(t *testing.T) {
+			assertRequestAttribute(t, "unset", monitorRequestJSON(t, monitorSpec{}), tc.key, tc.unset)
+
+			set := monitorRequestJSON(t, tc.set)
+			assertRequestAttribute(t, "set", set, tc.key, tc.want)
+			for key := range set {
+				if key != tc.key && key != "paused" {
+					t.Fatalf("setting %s also sent %q", tc.field, key)
+				}
+			}
+
+			if tc.clear != nil {
+				assertRequestAttribute(t, "cleared", monitorRequestJSON(t, *tc.clear), tc.key, tc.cleared)
+			}
+		})
+	}
+}
+
+func TestBuildMonitorRequestCoversRequestFields(t *testing.T) {
+	keys := map[string]bool{}
+	fields := map[string]bool{}
+	for _, tc := range monitorRequestFieldCases {
+		keys[tc.key] = true
+		fields[tc.field] = true
+	}
+
+	requestType := reflect.TypeOf(betterstack.MonitorRequest{})
+	for i := 0; i < requestType.NumField(); i++ {
+		name, _, _ := strings.Cut(requestType.Field(i).Tag.Get("json"), ",")
+		if name != "-" && !keys[name] {
+			t.Errorf("request attribute %q (MonitorRequest.%s) has no case in monitorRequestFieldCases", name, requestType.Field(i).Name)
+		}
+	}
 
-	gotReq := buildMonitorRequest(spec, nil)
-	encoded, err := json.Marshal(gotReq)
+	specType := reflect.TypeOf(monitoringv1alpha1.BetterStackMonitorSpec{})
+	for i := 0; i < specType.NumField(); i++ {
+		name := specType.Field(i).Name
+		if !fields[name] && !monitorSpecFieldsNotInRequest[name] {
+			t.Errorf("spec field %s has no case in monitorRequestFieldCases", name)
+		}
+	}
+}
+
+func monitorRequestJSON(t *testing.T, spec monitoringv1alpha1.BetterStackMonitorSpec) map[string]any {
+	t.Helper()
+	encoded, err := json.Marshal(buildMonitorRequest(spec, nil))
 	assert.NoError(t, err, "marshal request")
 	got := map[string]any{}
 	assert.NoError(t, json.Unmarshal(encoded, &got), "unmarshal request")
-	assert.Int(t, "diff len", len(diffMaps(got, wanted)), 0)
+	return got
+}
+
+// assertRequestAttribute compares an attribute after a JSON round trip of want, so Go
+// literals compare equal to decoded values. A nil want asserts the attribute is omitted.
+func assertRequestAttribute(t *testing.T, stage string, got map[string]any, key string, want any) {
+	t.Helper()
+	value, ok := got[key]
+	if want == nil {
+		if ok {
+			t.Fatalf("%s: expected %q to be omitted, got %#v", stage, key, value)
+		}
+		return
+	}
+	encoded, err := json.Marshal(want)
+	assert.NoError(t, err, "marshal want")
+	var normalized any
+	assert.NoError(t, json.Unmarshal(encoded, &normalized), "unmarshal want")
+	if !ok || !reflect.DeepEqual(value, normalized) {
+		t.Fatalf("%s: expected %q to be %#v, got %#v", stage, key, normalized, value)
+	}
 }
 
 func TestBuildMonitorRequestConvertsTimeoutForServerMonitors(t *testing.T) {
@@ -874,6 +2102,23 @@ func TestBuildMonitorRequestConvertsTimeoutForServerMonitors(t *testing.T) {
 	assert.Int(t, "timeout", *req.RequestTimeout, 3000)
 }
 
+func TestBuildMonitorRequestUsesPlaywrightTimeout(t *testing.T) {
+	spec := monitoringv1alpha1.BetterStackMonitorSpec{
+		URL:                      "https://example.com",
+		MonitorType:              "playwright",
+		PlaywrightScript:         "await page.goto('https://example.com')",
+		ScenarioName:             "Checkout",
+		RequestTimeoutSeconds:    10,
+		PlaywrightTimeoutSeconds: 45,
+	}
+
+	req := buildMonitorRequest(spec, nil)
+	assert.NotNil(t, "request timeout", req.RequestTimeout)
+	assert.Int(t, "timeout", *req.RequestTimeout, 45)
+	assert.NotNil(t, "scenario name", req.ScenarioName)
+	assert.String(t, "scenario name", *req.ScenarioName, "Checkout")
+}
+
 func TestBuildMonitorRequestAssignsHeaderIDsWhenPresent(t *testing.T) {
 	existingHeaderID := "hdr-123"
 	existing := &betterstack.Monitor{
@@ -918,3 +2163,122 @@ func diffMaps(got, want map[string]any) map[string][2]any {
 	}
 	return diff
 }
+
+// expireBackoff moves the monitor's next retry into the past, as if its backoff had elapsed.
+func expireBackoff(t *testing.T, c client.Client, key types.NamespacedName) {
+	t.Helper()
+	monitor := &monitoringv1alpha1.BetterStackMonitor{}
+	assert.NoError(t, c.Get(context.Background(), key, monitor), "fetch monitor")
+	if monitor.Status.Backoff == nil {
+		return
+	}
+	monitor.Status.Backoff.NextRetryTime = metav1.NewTime(time.Now().Add(-time.Second))
+	assert.NoError(t, c.Status().Update(context.Background(), monitor), "expire backoff")
+}
+
+func TestReconcileAdoptsExistingMonitorID(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	monitor := &monitoringv1alpha1.BetterStackMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			Generation: 1,
+			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackMonitorSpec{
+			URL:               "https://example.com",
+			MonitorType:       "status",
+			ExistingMonitorID: "remote-42",
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+		},
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+
+	client := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(monitor).
+		WithObjects(monitor.DeepCopy(), secret.DeepCopy()).
+		Build()
+
+	service := &betterstackfakes.MonitorClient{
+		GetFn: func(ctx context.Context, id string) (betterstack.Monitor, error) {
+			return betterstack.Monitor{ID: id}, nil
+		},
+		UpdateFn: func(ctx context.Context, id string, req betterstack.MonitorUpdateRequest) (betterstack.Monitor, error) {
+			assert.String(t, "update id", id, "remote-42")
+			return betterstack.Monitor{ID: id}, nil
+		},
+	}
+
+	r := &BetterStackMonitorReconciler{Client: client, Scheme: scheme, Clients: &fakeBetterStackMonitorClientFactory{monitor: service}}
+
+	ctx := context.Background()
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}})
+	assert.NoError(t, err, "reconcile")
+	assert.Int(t, "update calls", service.UpdateCalls, 1)
+	assert.Int(t, "create calls", service.CreateCalls, 0)
+	assert.Int(t, "list calls", service.ListCalls, 0)
+
+	updated := &monitoringv1alpha1.BetterStackMonitor{}
+	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}, updated), "fetch updated monitor")
+	assert.String(t, "monitor id", updated.Status.MonitorID, "remote-42")
+	assert.Bool(t, "adopted", updated.Status.Adopted, true)
+}
+
+func TestReconcileRefusesMonitorIDSyncedInOtherNamespace(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	newMonitor := func(namespace string, created time.Time, statusID string) *monitoringv1alpha1.BetterStackMonitor {
+		return &monitoringv1alpha1.BetterStackMonitor{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "example",
+				Namespace:         namespace,
+				Generation:        1,
+				CreationTimestamp: metav1.NewTime(created),
+				Finalizers:        []string{monitoringv1alpha1.BetterStackMonitorFinalizer},
+			},
+			Spec: monitoringv1alpha1.BetterStackMonitorSpec{
+				URL:               "https://example.com",
+				MonitorType:       "status",
+				ExistingMonitorID: "remote-42",
+				APITokenSecretRef: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+					Key:                  "token",
+				},
+			},
+			Status: monitoringv1alpha1.BetterStackMonitorStatus{MonitorID: statusID},
+		}
+	}
+	// The owner synced the monitor; the older resource only names it, as an operator that
+	// has just restarted would find them.
+	owner := newMonitor("team-b", time.Now(), "remote-42")
+	hijacker := newMonitor("team-a", time.Now().Add(-time.Hour), "")
+
+	client := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(owner, hijacker).
+		WithObjects(owner.DeepCopy(), hijacker.DeepCopy()).
+		Build()
+
+	service := &betterstackfakes.MonitorClient{}
+	r := &BetterStackMonitorReconciler{Client: client, Scheme: scheme, Clients: &fakeBetterStackMonitorClientFactory{monitor: service}}
+
+	ctx := context.Background()
+	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: hijacker.Name, Namespace: hijacker.Namespace}})
+	assert.NoError(t, err, "reconcile")
+	assert.Equal(t, "requeueAfter", res.RequeueAfter, requeueIntervalOnError)
+	assert.Int(t, "update calls", service.UpdateCalls, 0)
+
+	updated := &monitoringv1alpha1.BetterStackMonitor{}
+	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: hijacker.Name, Namespace: hijacker.Namespace}, updated), "fetch monitor")
+	assert.String(t, "monitor id", updated.Status.MonitorID, "")
+	ready := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionReady)
+	assert.NotNil(t, "ready condition", ready)
+	assert.String(t, "ready reason", ready.Reason, monitoringv1alpha1.ReasonRemoteIDClaimed)
+}
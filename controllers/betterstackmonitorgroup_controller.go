@@ -4,25 +4,30 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"k8s.io/utils/ptr"
 
 	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/internal/controller/accounts"
 	"loks0n/betterstack-operator/internal/controller/conditions"
 	"loks0n/betterstack-operator/internal/controller/credentials"
+	"loks0n/betterstack-operator/internal/controller/metrics"
+	"loks0n/betterstack-operator/internal/controller/refs"
+	"loks0n/betterstack-operator/internal/controller/retries"
+	"loks0n/betterstack-operator/internal/controller/secretwatch"
+	"loks0n/betterstack-operator/internal/controller/shutdown"
 	"loks0n/betterstack-operator/pkg/betterstack"
 
-	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
-	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 // BetterStackMonitorGroupClientFactory provides Better Stack API clients for reconcilers.
@@ -33,7 +38,7 @@ type BetterStackMonitorGroupClientFactory interface {
 type defaultBetterStackMonitorGroupClientFactory struct{}
 
 func (defaultBetterStackMonitorGroupClientFactory) MonitorGroup(baseURL, token string, httpClient *http.Client) betterstack.MonitorGroupClient {
-	client := betterstack.NewClient(baseURL, token, httpClient)
+	client := betterstack.NewClient(baseURL, token, httpClient, betterstack.WithConnectionTrace(metrics.ObserveConnection), betterstack.WithWarningHandler(recordAPIWarning), betterstack.WithRequestObserver(recordAPIRequest))
 	return client.MonitorGroups
 }
 
@@ -43,26 +48,62 @@ type BetterStackMonitorGroupReconciler struct {
 	Scheme     *runtime.Scheme
 	HTTPClient *http.Client
 	Clients    BetterStackMonitorGroupClientFactory
-}
+	Accounts   *accounts.Registry
+	Retries    *retries.Tracker
+	Recorder   record.EventRecorder
+	Drainer    *shutdown.Drainer
+
+	// References decides whether accountRef may select a credential in another namespace.
+	References refs.Policy
 
-const monitorGroupSecretIndexKey = "monitoring.betterstack.io/monitorgroup-secret"
+	// ClusterName replaces $(CLUSTER_NAME) in spec.name and is recorded in status.clusterName.
+	ClusterName string
+
+	// DefaultBaseURL is the Better Stack API endpoint for resources whose spec and
+	// credential set no baseURL. Empty means the public API.
+	DefaultBaseURL string
+}
 
 //+kubebuilder:rbac:groups=monitoring.betterstack.io,resources=betterstackmonitorgroups,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=monitoring.betterstack.io,resources=betterstackmonitorgroups/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=monitoring.betterstack.io,resources=betterstackmonitorgroups/finalizers,verbs=update
+//+kubebuilder:rbac:groups=monitoring.betterstack.io,resources=betterstackmonitors,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups=monitoring.betterstack.io,resources=betterstackcredentials,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *BetterStackMonitorGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, done, ok := r.Drainer.Begin(ctx)
+	if !ok {
+		return ctrl.Result{}, nil
+	}
+	defer done()
+
 	logger := log.FromContext(ctx)
 
 	group := &monitoringv1alpha1.BetterStackMonitorGroup{}
 	if err := r.Get(ctx, req.NamespacedName, group); err != nil {
 		if apierrors.IsNotFound(err) {
+			r.Retries.Forget("monitorgroup", req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{}, err
 	}
 
+	// Only a sync that fails again keeps the resource listed as backing off. Every other
+	// outcome, including giving up early on credentials or a claimed ID, drops it.
+	backingOff := false
+	defer func() {
+		if !backingOff {
+			r.Retries.Forget("monitorgroup", req.NamespacedName)
+		}
+	}()
+
+	ctx = withReconcileTrace(ctx, group)
+	logger = log.FromContext(ctx)
+	ctx, warnings := withAPIWarnings(ctx)
+	defer warnings.emit(r.Recorder, group)
+
 	if group.DeletionTimestamp.IsZero() {
 		if !controllerutil.ContainsFinalizer(group, monitoringv1alpha1.BetterStackMonitorGroupFinalizer) {
 			controllerutil.AddFinalizer(group, monitoringv1alpha1.BetterStackMonitorGroupFinalizer)
@@ -75,63 +116,170 @@ func (r *BetterStackMonitorGroupReconciler) Reconcile(ctx context.Context, req c
 		return r.handleDelete(ctx, group)
 	}
 
-	token, err := credentials.FetchAPIToken(ctx, r.Client, group.Namespace, group.Spec.APITokenSecretRef)
+	if group.Spec.Suspend {
+		err := r.patchStatus(ctx, group, func(status *monitoringv1alpha1.BetterStackMonitorGroupStatus) {
+			now := metav1.Now()
+			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionSuspended, metav1.ConditionTrue, monitoringv1alpha1.ReasonSuspended, "Reconciliation suspended via spec.suspend", &now))
+		})
+		return ctrl.Result{}, err
+	}
+	if meta.IsStatusConditionTrue(group.Status.Conditions, monitoringv1alpha1.ConditionSuspended) {
+		_ = r.patchStatus(ctx, group, func(status *monitoringv1alpha1.BetterStackMonitorGroupStatus) {
+			now := metav1.Now()
+			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionSuspended, metav1.ConditionFalse, monitoringv1alpha1.ReasonResumed, "Reconciliation resumed", &now))
+		})
+	}
+
+	if wait, ok := backoffRemaining(group.Status.Backoff, group.Generation, time.Now()); ok {
+		logger.V(1).Info("backing off after failed syncs", "failures", group.Status.Backoff.Failures, "retryIn", wait)
+		backingOff = true
+		trackStoredBackoff(r.Retries, "monitorgroup", group, group.Status.Backoff, group.Status.Conditions)
+		return ctrl.Result{RequeueAfter: wait}, nil
+	}
+
+	remoteID := group.Status.MonitorGroupID
+	if remoteID == "" {
+		remoteID = group.Spec.ExistingMonitorGroupID
+	}
+	if holder, ok, _, err := r.claimMonitorGroupID(ctx, group, remoteID); err != nil {
+		return ctrl.Result{}, err
+	} else if !ok {
+		return r.reportClaimed(ctx, group, remoteID, holder)
+	}
+
+	account, err := resolveAccount(ctx, r.Client, r.References, r.DefaultBaseURL, group.Namespace, group.Spec.AccountRef, group.Spec.APITokenSecretRef, group.Spec.BaseURL)
 	if err != nil {
 		logger.Error(err, "unable to fetch Better Stack API token")
 		_ = r.patchStatus(ctx, group, func(status *monitoringv1alpha1.BetterStackMonitorGroupStatus) {
 			now := metav1.Now()
-			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionCredentials, metav1.ConditionFalse, "TokenUnavailable", err.Error(), &now))
-			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionFalse, "TokenUnavailable", "API credentials not available", &now))
+			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionCredentials, metav1.ConditionFalse, monitoringv1alpha1.ReasonTokenUnavailable, err.Error(), &now))
+			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionFalse, monitoringv1alpha1.ReasonTokenUnavailable, "API credentials not available", &now))
 		})
 		return ctrl.Result{RequeueAfter: requeueIntervalOnError}, nil
 	}
 
 	_ = r.patchStatus(ctx, group, func(status *monitoringv1alpha1.BetterStackMonitorGroupStatus) {
 		now := metav1.Now()
-		status.SetCondition(conditions.New(monitoringv1alpha1.ConditionCredentials, metav1.ConditionTrue, "TokenResolved", fmt.Sprintf("Using secret %s/%s", group.Namespace, group.Spec.APITokenSecretRef.Name), &now))
+		status.SetCondition(conditions.New(monitoringv1alpha1.ConditionCredentials, metav1.ConditionTrue, monitoringv1alpha1.ReasonTokenResolved, fmt.Sprintf("Using %s", account.Source), &now))
 	})
 
-	service := r.monitorGroupService(group.Spec.BaseURL, token)
-	request := buildMonitorGroupRequest(group.Spec)
+	service := r.monitorGroupService(account)
+	spec := group.Spec
+	spec.Name = expandClusterName(spec.Name, r.ClusterName)
+	request := buildMonitorGroupRequest(spec)
 
+	previousID := group.Status.MonitorGroupID
 	var apiGroup betterstack.MonitorGroup
-	if group.Status.MonitorGroupID != "" {
-		apiGroup, err = service.Update(ctx, group.Status.MonitorGroupID, betterstack.MonitorGroupUpdateRequest(request))
+	var immutable, recreated []immutableFieldChange
+	var replacedID string
+	if remoteID != "" {
+		apiGroup, err = service.Update(ctx, remoteID, betterstack.MonitorGroupUpdateRequest(request))
 		if betterstack.IsNotFound(err) {
-			logger.Info("remote monitor group missing, creating anew", "id", group.Status.MonitorGroupID)
-			group.Status.MonitorGroupID = ""
+			logger.Info("remote monitor group missing, creating anew", "id", remoteID)
+			remoteID = ""
 			err = nil
 		}
+		if err == nil && remoteID != "" {
+			immutable = immutableMonitorGroupChanges(group.Spec, apiGroup.Attributes)
+		}
+		adopted := group.Spec.ExistingMonitorGroupID != "" && remoteID == group.Spec.ExistingMonitorGroupID
+		if len(immutable) > 0 && group.Spec.AllowRecreate && adopted {
+			logger.Info("not recreating adopted remote monitor group", "id", remoteID)
+		} else if len(immutable) > 0 && group.Spec.AllowRecreate {
+			logger.Info("recreating remote monitor group to apply immutable fields", "id", remoteID)
+			if err = service.Delete(ctx, remoteID); err == nil {
+				replacedID, remoteID = remoteID, ""
+				recreated, immutable = immutable, nil
+			}
+		}
 	}
 
-	if err == nil && group.Status.MonitorGroupID == "" {
+	if err == nil && remoteID == "" {
 		apiGroup, err = service.Create(ctx, betterstack.MonitorGroupCreateRequest(request))
 	}
 
 	if err != nil {
 		logger.Error(err, "unable to reconcile Better Stack monitor group")
+		backoff := nextBackoff(group.Status.Backoff, group.Generation, time.Now())
 		_ = r.patchStatus(ctx, group, func(status *monitoringv1alpha1.BetterStackMonitorGroupStatus) {
 			now := metav1.Now()
-			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionSync, metav1.ConditionFalse, "SyncFailed", err.Error(), &now))
-			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionFalse, "SyncFailed", "Monitor group reconciliation failed", &now))
+			status.Backoff = backoff
+			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionSync, metav1.ConditionFalse, monitoringv1alpha1.ReasonSyncFailed, err.Error(), &now))
+			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionFalse, monitoringv1alpha1.ReasonSyncFailed, "Monitor group reconciliation failed", &now))
 		})
-		return ctrl.Result{RequeueAfter: requeueIntervalOnError}, nil
+		backingOff = true
+		trackBackoff(r.Retries, "monitorgroup", group, backoff, monitoringv1alpha1.ReasonSyncFailed, err.Error())
+		return ctrl.Result{RequeueAfter: backoffDelay(backoff.Failures)}, nil
+	}
+
+	if previousID != "" && previousID != apiGroup.ID {
+		metrics.RemoteRecreated.WithLabelValues("monitorgroup").Inc()
 	}
 
 	now := metav1.Now()
 	if err := r.patchStatus(ctx, group, func(status *monitoringv1alpha1.BetterStackMonitorGroupStatus) {
 		status.MonitorGroupID = apiGroup.ID
+		status.ClusterName = r.ClusterName
+		status.BaseURL = account.BaseURL
+		if replacedID != "" {
+			status.ReplacedMonitorGroupID = replacedID
+		}
 		status.ObservedGeneration = group.Generation
 		status.LastSyncedTime = &now
-		status.SetCondition(conditions.New(monitoringv1alpha1.ConditionSync, metav1.ConditionTrue, "MonitorGroupSynced", "Monitor group synchronized with Better Stack", &now))
-		status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionTrue, "MonitorGroupSynced", "Monitor group synchronized with Better Stack", &now))
+		status.Backoff = nil
+		status.SetCondition(conditions.New(monitoringv1alpha1.ConditionSync, metav1.ConditionTrue, monitoringv1alpha1.ReasonMonitorGroupSynced, "Monitor group synchronized with Better Stack", &now))
+		if condition, ok := immutableFieldCondition(status.Conditions, immutable, recreated, &now); ok {
+			status.SetCondition(condition)
+		}
+		if len(immutable) > 0 {
+			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionFalse, monitoringv1alpha1.ReasonImmutableFieldChanged, "Monitor group differs from the spec in fields Better Stack cannot update", &now))
+		} else {
+			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionTrue, monitoringv1alpha1.ReasonMonitorGroupSynced, "Monitor group synchronized with Better Stack", &now))
+		}
 	}); err != nil {
 		return ctrl.Result{}, err
 	}
 
+	if replaced := group.Status.ReplacedMonitorGroupID; replaced != "" {
+		moved, err := r.repointMonitors(ctx, group.Namespace, replaced, apiGroup.ID)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		logger.Info("moved monitors to the recreated monitor group", "from", replaced, "to", apiGroup.ID, "monitors", moved)
+		if err := r.patchStatus(ctx, group, func(status *monitoringv1alpha1.BetterStackMonitorGroupStatus) {
+			status.ReplacedMonitorGroupID = ""
+		}); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	return ctrl.Result{}, nil
 }
 
+// repointMonitors moves monitors in namespace whose spec.monitorGroupID is from to the
+// group to, returning how many were changed. The replaced ID is kept in status until this
+// succeeds, so a failed patch is retried on the next reconcile.
+func (r *BetterStackMonitorGroupReconciler) repointMonitors(ctx context.Context, namespace, from, to string) (int, error) {
+	var monitors monitoringv1alpha1.BetterStackMonitorList
+	if err := r.List(ctx, &monitors, client.InNamespace(namespace)); err != nil {
+		return 0, err
+	}
+	moved := 0
+	for i := range monitors.Items {
+		monitor := &monitors.Items[i]
+		if monitor.Spec.MonitorGroupID != from {
+			continue
+		}
+		patch := client.MergeFrom(monitor.DeepCopy())
+		monitor.Spec.MonitorGroupID = to
+		if err := r.Patch(ctx, monitor, patch); err != nil {
+			return moved, fmt.Errorf("move monitor %s to monitor group %s: %w", monitor.Name, to, err)
+		}
+		moved++
+	}
+	return moved, nil
+}
+
 func (r *BetterStackMonitorGroupReconciler) handleDelete(ctx context.Context, group *monitoringv1alpha1.BetterStackMonitorGroup) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
@@ -139,18 +287,31 @@ func (r *BetterStackMonitorGroupReconciler) handleDelete(ctx context.Context, gr
 		return ctrl.Result{}, nil
 	}
 
-	if group.Status.MonitorGroupID != "" {
-		token, err := credentials.FetchAPIToken(ctx, r.Client, group.Namespace, group.Spec.APITokenSecretRef)
+	holder, claimed, shared, err := r.claimMonitorGroupID(ctx, group, group.Status.MonitorGroupID)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !claimed {
+		logger.Info("leaving remote monitor group managed by another namespace", "monitorGroupID", group.Status.MonitorGroupID, "owner", holder.String())
+	} else if group.Status.MonitorGroupID == group.Spec.ExistingMonitorGroupID && group.Status.MonitorGroupID != "" {
+		logger.Info("leaving adopted remote monitor group in Better Stack", "monitorGroupID", group.Status.MonitorGroupID)
+	} else if shared {
+		logger.Info("leaving remote monitor group shared with another resource", "monitorGroupID", group.Status.MonitorGroupID)
+	} else if group.Status.MonitorGroupID != "" {
+		account, err := resolveAccount(ctx, r.Client, r.References, r.DefaultBaseURL, group.Namespace, group.Spec.AccountRef, group.Spec.APITokenSecretRef, group.Spec.BaseURL)
 		if err != nil {
 			logger.Info("skipping remote monitor group deletion due to missing credentials", "monitorGroupID", group.Status.MonitorGroupID, "error", err)
+			metrics.FinalizerSkippedRemoteDelete.WithLabelValues("monitorgroup", metrics.SkipReasonCredentialsUnavailable).Inc()
 		} else {
-			service := r.monitorGroupService(group.Spec.BaseURL, token)
+			service := r.monitorGroupService(account)
 			if err := service.Delete(ctx, group.Status.MonitorGroupID); err != nil && !betterstack.IsNotFound(err) {
 				logger.Error(err, "unable to delete Better Stack monitor group", "monitorGroupID", group.Status.MonitorGroupID)
+				metrics.FinalizerSkippedRemoteDelete.WithLabelValues("monitorgroup", metrics.SkipReasonDeleteFailed).Inc()
 			}
 		}
 	}
 
+	r.Retries.Forget("monitorgroup", client.ObjectKeyFromObject(group))
 	controllerutil.RemoveFinalizer(group, monitoringv1alpha1.BetterStackMonitorGroupFinalizer)
 	if err := r.Update(ctx, group); err != nil {
 		return ctrl.Result{}, err
@@ -159,62 +320,35 @@ func (r *BetterStackMonitorGroupReconciler) handleDelete(ctx context.Context, gr
 }
 
 func (r *BetterStackMonitorGroupReconciler) patchStatus(ctx context.Context, group *monitoringv1alpha1.BetterStackMonitorGroup, mutate func(*monitoringv1alpha1.BetterStackMonitorGroupStatus)) error {
-	base := group.DeepCopy()
-	mutate(&group.Status)
-	return r.Status().Patch(ctx, group, client.MergeFrom(base))
+	return patchObjectStatus(ctx, r.Client, group, func(group *monitoringv1alpha1.BetterStackMonitorGroup) *monitoringv1alpha1.BetterStackMonitorGroupStatus {
+		return &group.Status
+	}, func(status *monitoringv1alpha1.BetterStackMonitorGroupStatus) {
+		mutate(status)
+		status.DebugTrace = debugTrace(ctx, status.DebugTrace, status.Conditions)
+	})
 }
 
 func (r *BetterStackMonitorGroupReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	ctx := context.Background()
-	if err := mgr.GetFieldIndexer().IndexField(ctx, &monitoringv1alpha1.BetterStackMonitorGroup{}, monitorGroupSecretIndexKey, func(obj client.Object) []string {
-		group, ok := obj.(*monitoringv1alpha1.BetterStackMonitorGroup)
-		if !ok {
-			return nil
-		}
-		secretName := group.Spec.APITokenSecretRef.Name
-		if secretName == "" {
-			return nil
-		}
-		return []string{secretIndexValue(group.Namespace, secretName)}
-	}); err != nil {
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &monitoringv1alpha1.BetterStackMonitorGroup{}, monitorGroupIDIndexKey, indexMonitorGroupID); err != nil {
+		return err
+	}
+	builder, err := secretwatch.Watch(mgr, ctrl.NewControllerManagedBy(mgr).Named("betterstackmonitorgroup").Watches(&monitoringv1alpha1.BetterStackMonitorGroup{}, enqueueSpecChangesFirst()), "monitorgroup", &monitoringv1alpha1.BetterStackMonitorGroup{}, func() client.ObjectList { return &monitoringv1alpha1.BetterStackMonitorGroupList{} }, groupCredentialRefs)
+	if err != nil {
 		return err
 	}
+	return builder.Complete(r)
+}
 
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&monitoringv1alpha1.BetterStackMonitorGroup{}).
-		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.requestsForSecret)).
-		Complete(r)
+func groupCredentialRefs(group *monitoringv1alpha1.BetterStackMonitorGroup) ([]string, *monitoringv1alpha1.ResourceRef) {
+	return []string{group.Spec.APITokenSecretRef.Name}, group.Spec.AccountRef
 }
 
-func (r *BetterStackMonitorGroupReconciler) monitorGroupService(baseURL, token string) betterstack.MonitorGroupClient {
+func (r *BetterStackMonitorGroupReconciler) monitorGroupService(account credentials.Account) betterstack.MonitorGroupClient {
 	factory := r.Clients
 	if factory == nil {
 		factory = defaultBetterStackMonitorGroupClientFactory{}
 	}
-	return factory.MonitorGroup(baseURL, token, r.HTTPClient)
-}
-
-func (r *BetterStackMonitorGroupReconciler) requestsForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
-	secret, ok := obj.(*corev1.Secret)
-	if !ok {
-		return nil
-	}
-	if secret.Namespace == "" || secret.Name == "" {
-		return nil
-	}
-
-	secretKey := secretIndexValue(secret.Namespace, secret.Name)
-	list := &monitoringv1alpha1.BetterStackMonitorGroupList{}
-	if err := r.List(ctx, list, client.InNamespace(secret.Namespace), client.MatchingFields{monitorGroupSecretIndexKey: secretKey}); err != nil {
-		log.FromContext(ctx).Error(err, "unable to list monitor groups for secret", "secret", secretKey)
-		return nil
-	}
-
-	requests := make([]reconcile.Request, 0, len(list.Items))
-	for _, group := range list.Items {
-		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: group.Namespace, Name: group.Name}})
-	}
-	return requests
+	return factory.MonitorGroup(account.BaseURL, account.Token, r.Accounts.HTTPClient(account, r.HTTPClient))
 }
 
 func buildMonitorGroupRequest(spec monitoringv1alpha1.BetterStackMonitorGroupSpec) betterstack.MonitorGroupRequest {
@@ -232,6 +366,12 @@ func buildMonitorGroupRequest(spec monitoringv1alpha1.BetterStackMonitorGroupSpe
 	if spec.Paused != nil {
 		req.Paused = spec.Paused
 	}
+	if len(spec.AdditionalAttributes) > 0 {
+		req.AdditionalAttributes = make(map[string]any, len(spec.AdditionalAttributes))
+		for k, v := range spec.AdditionalAttributes {
+			req.AdditionalAttributes[k] = v
+		}
+	}
 
 	return req
 }
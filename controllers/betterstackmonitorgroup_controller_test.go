@@ -2,25 +2,35 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"k8s.io/utils/ptr"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
-	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/internal/controller/metrics"
+	"loks0n/betterstack-operator/internal/controller/retries"
 	"loks0n/betterstack-operator/internal/testutil/assert"
 	"loks0n/betterstack-operator/internal/testutil/controllertest"
+	"loks0n/betterstack-operator/internal/testutil/httpmock"
 	"loks0n/betterstack-operator/pkg/betterstack"
+	"loks0n/betterstack-operator/pkg/betterstack/betterstackfakes"
 )
 
 type fakeBetterStackMonitorGroupClientFactory struct {
@@ -35,83 +45,11 @@ func (f *fakeBetterStackMonitorGroupClientFactory) MonitorGroup(baseURL, token s
 	f.lastBaseURL = baseURL
 	f.lastToken = token
 	if f.group == nil {
-		return &fakeMonitorGroupService{}
+		return &betterstackfakes.MonitorGroupClient{}
 	}
 	return f.group
 }
 
-type fakeMonitorGroupService struct {
-	createFn func(ctx context.Context, req betterstack.MonitorGroupCreateRequest) (betterstack.MonitorGroup, error)
-	updateFn func(ctx context.Context, id string, req betterstack.MonitorGroupUpdateRequest) (betterstack.MonitorGroup, error)
-	deleteFn func(ctx context.Context, id string) error
-	getFn    func(ctx context.Context, id string) (betterstack.MonitorGroup, error)
-
-	listFn    func(ctx context.Context) ([]betterstack.MonitorGroup, error)
-	listMonFn func(ctx context.Context, groupID string) ([]betterstack.Monitor, error)
-
-	createCalls  int
-	updateCalls  int
-	deleteCalls  int
-	getCalls     int
-	listCalls    int
-	listMonCalls int
-
-	lastCreateReq betterstack.MonitorGroupCreateRequest
-	lastUpdateReq betterstack.MonitorGroupUpdateRequest
-}
-
-func (s *fakeMonitorGroupService) Create(ctx context.Context, req betterstack.MonitorGroupCreateRequest) (betterstack.MonitorGroup, error) {
-	s.createCalls++
-	s.lastCreateReq = req
-	if s.createFn != nil {
-		return s.createFn(ctx, req)
-	}
-	return betterstack.MonitorGroup{}, nil
-}
-
-func (s *fakeMonitorGroupService) Update(ctx context.Context, id string, req betterstack.MonitorGroupUpdateRequest) (betterstack.MonitorGroup, error) {
-	s.updateCalls++
-	s.lastUpdateReq = req
-	if s.updateFn != nil {
-		return s.updateFn(ctx, id, req)
-	}
-	return betterstack.MonitorGroup{}, nil
-}
-
-func (s *fakeMonitorGroupService) Delete(ctx context.Context, id string) error {
-	s.deleteCalls++
-	if s.deleteFn != nil {
-		return s.deleteFn(ctx, id)
-	}
-	return nil
-}
-
-func (s *fakeMonitorGroupService) Get(ctx context.Context, id string) (betterstack.MonitorGroup, error) {
-	s.getCalls++
-	if s.getFn != nil {
-		return s.getFn(ctx, id)
-	}
-	return betterstack.MonitorGroup{}, nil
-}
-
-func (s *fakeMonitorGroupService) List(ctx context.Context) ([]betterstack.MonitorGroup, error) {
-	s.listCalls++
-	if s.listFn != nil {
-		return s.listFn(ctx)
-	}
-	return nil, nil
-}
-
-func (s *fakeMonitorGroupService) ListMonitors(ctx context.Context, groupID string) ([]betterstack.Monitor, error) {
-	s.listMonCalls++
-	if s.listMonFn != nil {
-		return s.listMonFn(ctx, groupID)
-	}
-	return nil, nil
-}
-
-var _ betterstack.MonitorGroupClient = (*fakeMonitorGroupService)(nil)
-
 func TestMonitorGroupReconcileAddsFinalizer(t *testing.T) {
 	scheme := controllertest.NewScheme(t)
 
@@ -128,8 +66,7 @@ func TestMonitorGroupReconcileAddsFinalizer(t *testing.T) {
 		},
 	}
 
-	client := fake.NewClientBuilder().
-		WithScheme(scheme).
+	client := newIndexedClientBuilder(scheme).
 		WithStatusSubresource(group).
 		WithObjects(group.DeepCopy()).
 		Build()
@@ -167,8 +104,7 @@ func TestMonitorGroupReconcileMissingCredentials(t *testing.T) {
 		},
 	}
 
-	client := fake.NewClientBuilder().
-		WithScheme(scheme).
+	client := newIndexedClientBuilder(scheme).
 		WithStatusSubresource(group).
 		WithObjects(group.DeepCopy()).
 		Build()
@@ -189,12 +125,12 @@ func TestMonitorGroupReconcileMissingCredentials(t *testing.T) {
 	creds := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionCredentials)
 	assert.NotNil(t, "credentials condition", creds)
 	assert.Equal(t, "credentials status", creds.Status, metav1.ConditionFalse)
-	assert.String(t, "credentials reason", creds.Reason, "TokenUnavailable")
+	assert.String(t, "credentials reason", creds.Reason, monitoringv1alpha1.ReasonTokenUnavailable)
 
 	ready := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionReady)
 	assert.NotNil(t, "ready condition", ready)
 	assert.Equal(t, "ready status", ready.Status, metav1.ConditionFalse)
-	assert.String(t, "ready reason", ready.Reason, "TokenUnavailable")
+	assert.String(t, "ready reason", ready.Reason, monitoringv1alpha1.ReasonTokenUnavailable)
 }
 
 func TestMonitorGroupReconcileCreatesGroup(t *testing.T) {
@@ -222,14 +158,13 @@ func TestMonitorGroupReconcileCreatesGroup(t *testing.T) {
 		Data:       map[string][]byte{"token": []byte("abcd")},
 	}
 
-	client := fake.NewClientBuilder().
-		WithScheme(scheme).
+	client := newIndexedClientBuilder(scheme).
 		WithStatusSubresource(group).
 		WithObjects(group.DeepCopy(), secret.DeepCopy()).
 		Build()
 
-	service := &fakeMonitorGroupService{
-		createFn: func(ctx context.Context, req betterstack.MonitorGroupCreateRequest) (betterstack.MonitorGroup, error) {
+	service := &betterstackfakes.MonitorGroupClient{
+		CreateFn: func(ctx context.Context, req betterstack.MonitorGroupCreateRequest) (betterstack.MonitorGroup, error) {
 			assert.NotNil(t, "request name", req.Name)
 			assert.String(t, "request name", *req.Name, "Backend services")
 			assert.NotNil(t, "request team", req.TeamName)
@@ -260,7 +195,193 @@ func TestMonitorGroupReconcileCreatesGroup(t *testing.T) {
 	syncCond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionSync)
 	assert.NotNil(t, "sync condition", syncCond)
 	assert.Equal(t, "sync status", syncCond.Status, metav1.ConditionTrue)
-	assert.String(t, "sync reason", syncCond.Reason, "MonitorGroupSynced")
+	assert.String(t, "sync reason", syncCond.Reason, monitoringv1alpha1.ReasonMonitorGroupSynced)
+}
+
+func TestMonitorGroupReconcileExpandsClusterName(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	group := &monitoringv1alpha1.BetterStackMonitorGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorGroupFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackMonitorGroupSpec{
+			Name: "Backend services ($(CLUSTER_NAME))",
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+		},
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+
+	client := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(group).
+		WithObjects(group.DeepCopy(), secret.DeepCopy()).
+		Build()
+
+	service := &betterstackfakes.MonitorGroupClient{
+		CreateFn: func(ctx context.Context, req betterstack.MonitorGroupCreateRequest) (betterstack.MonitorGroup, error) {
+			assert.NotNil(t, "request name", req.Name)
+			assert.String(t, "request name", *req.Name, "Backend services (prod-eu)")
+			return betterstack.MonitorGroup{ID: "group-123"}, nil
+		},
+	}
+
+	r := &BetterStackMonitorGroupReconciler{
+		Client:      client,
+		Scheme:      scheme,
+		Clients:     &fakeBetterStackMonitorGroupClientFactory{group: service},
+		ClusterName: "prod-eu",
+	}
+
+	ctx := context.Background()
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: group.Name, Namespace: group.Namespace}})
+	assert.NoError(t, err, "reconcile")
+	assert.Int(t, "create calls", service.CreateCalls, 1)
+
+	updated := &monitoringv1alpha1.BetterStackMonitorGroup{}
+	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: group.Name, Namespace: group.Namespace}, updated), "fetch updated group")
+	assert.String(t, "cluster name", updated.Status.ClusterName, "prod-eu")
+	assert.String(t, "spec name", updated.Spec.Name, "Backend services ($(CLUSTER_NAME))")
+}
+
+func TestExpandClusterName(t *testing.T) {
+	assert.String(t, "expanded", expandClusterName("API $(CLUSTER_NAME)", "prod"), "API prod")
+	assert.String(t, "without reference", expandClusterName("API", "prod"), "API")
+	assert.String(t, "without cluster name", expandClusterName("API $(CLUSTER_NAME)", ""), "API $(CLUSTER_NAME)")
+}
+
+func TestMonitorGroupReconcileRecordsDeprecationWarnings(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	group := &monitoringv1alpha1.BetterStackMonitorGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorGroupFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackMonitorGroupSpec{
+			Name:    "Backend services",
+			BaseURL: "https://api.test",
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+		},
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+
+	client := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(group).
+		WithObjects(group.DeepCopy(), secret.DeepCopy()).
+		Build()
+
+	recorder := record.NewFakeRecorder(10)
+	r := &BetterStackMonitorGroupReconciler{
+		Client:   client,
+		Scheme:   scheme,
+		Recorder: recorder,
+		HTTPClient: &http.Client{Transport: httpmock.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.JSONResponse(http.StatusCreated, `{"data":{"id":"group-123","type":"monitor_group","attributes":{"name":"Backend services"}}}`)
+			resp.Header.Add("Warning", `299 - "team_name is deprecated"`)
+			resp.Header.Set("Sunset", "Wed, 01 Jul 2026 00:00:00 GMT")
+			return resp, nil
+		})},
+	}
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: group.Name, Namespace: group.Namespace}})
+	assert.NoError(t, err, "reconcile")
+
+	select {
+	case event := <-recorder.Events:
+		assert.String(t, "event", event, "Warning DeprecatedAPI Better Stack API POST /monitor-groups: team_name is deprecated (sunset Wed, 01 Jul 2026 00:00:00 GMT)")
+	default:
+		t.Fatalf("expected a deprecation warning event")
+	}
+}
+
+func TestMonitorGroupReconcileRecordsDebugTrace(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	group := &monitoringv1alpha1.BetterStackMonitorGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "example",
+			Namespace:   "default",
+			Finalizers:  []string{monitoringv1alpha1.BetterStackMonitorGroupFinalizer},
+			Annotations: map[string]string{monitoringv1alpha1.DebugAnnotation: "true"},
+		},
+		Spec: monitoringv1alpha1.BetterStackMonitorGroupSpec{
+			Name:    "Backend services",
+			BaseURL: "https://api.test",
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+		},
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+
+	client := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(group).
+		WithObjects(group.DeepCopy(), secret.DeepCopy()).
+		Build()
+
+	r := &BetterStackMonitorGroupReconciler{
+		Client: client,
+		Scheme: scheme,
+		HTTPClient: &http.Client{Transport: httpmock.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Method == http.MethodPost {
+				return httpmock.JSONResponse(http.StatusCreated, `{"data":{"id":"group-123","type":"monitor_group","attributes":{"name":"Backend services"}}}`), nil
+			}
+			return httpmock.JSONResponse(http.StatusOK, `{"data":{"id":"group-123","type":"monitor_group","attributes":{"name":"Backend services"}}}`), nil
+		})},
+	}
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: group.Name, Namespace: group.Namespace}
+	for i := 0; i < monitoringv1alpha1.MaxDebugTraceSteps; i++ {
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		assert.NoError(t, err, "reconcile")
+	}
+
+	updated := &monitoringv1alpha1.BetterStackMonitorGroup{}
+	assert.NoError(t, client.Get(ctx, key, updated), "fetch updated group")
+	steps := updated.Status.DebugTrace
+	assert.Int(t, "trace length", len(steps), monitoringv1alpha1.MaxDebugTraceSteps)
+	var api, ready int
+	for _, step := range steps {
+		switch {
+		case step.Kind == monitoringv1alpha1.DebugStepAPI && strings.HasPrefix(step.Message, "PATCH /monitor-groups/group-123: 200"):
+			api++
+		case step.Kind == monitoringv1alpha1.DebugStepStatus && strings.HasPrefix(step.Message, "Ready=True MonitorGroupSynced"):
+			ready++
+		}
+	}
+	if api == 0 || ready == 0 {
+		t.Fatalf("expected API and Ready steps in the trace, got %+v", steps)
+	}
+
+	delete(updated.Annotations, monitoringv1alpha1.DebugAnnotation)
+	assert.NoError(t, client.Update(ctx, updated), "remove debug annotation")
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile without debug annotation")
+	assert.NoError(t, client.Get(ctx, key, updated), "fetch group")
+	assert.Int(t, "trace length", len(updated.Status.DebugTrace), 0)
 }
 
 func TestMonitorGroupReconcileUpdatesGroup(t *testing.T) {
@@ -296,14 +417,13 @@ func TestMonitorGroupReconcileUpdatesGroup(t *testing.T) {
 		Data:       map[string][]byte{"token": []byte("abcd")},
 	}
 
-	client := fake.NewClientBuilder().
-		WithScheme(scheme).
+	client := newIndexedClientBuilder(scheme).
 		WithStatusSubresource(group).
 		WithObjects(group.DeepCopy(), secret.DeepCopy()).
 		Build()
 
-	service := &fakeMonitorGroupService{
-		updateFn: func(ctx context.Context, id string, req betterstack.MonitorGroupUpdateRequest) (betterstack.MonitorGroup, error) {
+	service := &betterstackfakes.MonitorGroupClient{
+		UpdateFn: func(ctx context.Context, id string, req betterstack.MonitorGroupUpdateRequest) (betterstack.MonitorGroup, error) {
 			assert.String(t, "update id", id, "group-123")
 			assert.NotNil(t, "update name", req.Name)
 			assert.String(t, "update name", *req.Name, "Backend")
@@ -336,6 +456,125 @@ func TestMonitorGroupReconcileUpdatesGroup(t *testing.T) {
 	assert.Equal(t, "observed generation", updated.Status.ObservedGeneration, int64(4))
 }
 
+func TestMonitorGroupReconcileDetectsTeamNameChange(t *testing.T) {
+	cases := []struct {
+		name          string
+		allowRecreate bool
+		adopted       bool
+		wantID        string
+		wantDeletes   int
+		wantStatus    metav1.ConditionStatus
+		wantReason    string
+		wantReady     metav1.ConditionStatus
+	}{
+		{
+			name:       "reported",
+			wantID:     "group-123",
+			wantStatus: metav1.ConditionTrue,
+			wantReason: monitoringv1alpha1.ReasonImmutableFieldChanged,
+			wantReady:  metav1.ConditionFalse,
+		},
+		{
+			name:          "recreated",
+			allowRecreate: true,
+			wantID:        "group-456",
+			wantDeletes:   1,
+			wantStatus:    metav1.ConditionFalse,
+			wantReason:    monitoringv1alpha1.ReasonRemoteRecreated,
+			wantReady:     metav1.ConditionTrue,
+		},
+		{
+			name:          "adopted",
+			allowRecreate: true,
+			adopted:       true,
+			wantID:        "group-123",
+			wantStatus:    metav1.ConditionTrue,
+			wantReason:    monitoringv1alpha1.ReasonImmutableFieldChanged,
+			wantReady:     metav1.ConditionFalse,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := controllertest.NewScheme(t)
+			group := &monitoringv1alpha1.BetterStackMonitorGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "example",
+					Namespace:  "default",
+					Finalizers: []string{monitoringv1alpha1.BetterStackMonitorGroupFinalizer},
+				},
+				Spec: monitoringv1alpha1.BetterStackMonitorGroupSpec{
+					Name:          "Backend",
+					TeamName:      "Team B",
+					AllowRecreate: tc.allowRecreate,
+					APITokenSecretRef: corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+						Key:                  "token",
+					},
+				},
+				Status: monitoringv1alpha1.BetterStackMonitorGroupStatus{MonitorGroupID: "group-123"},
+			}
+			if tc.adopted {
+				group.Spec.ExistingMonitorGroupID = "group-123"
+			}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+				Data:       map[string][]byte{"token": []byte("abcd")},
+			}
+			member := &monitoringv1alpha1.BetterStackMonitor{
+				ObjectMeta: metav1.ObjectMeta{Name: "member", Namespace: "default"},
+				Spec:       monitoringv1alpha1.BetterStackMonitorSpec{URL: "https://example.com", MonitorGroupID: "group-123"},
+			}
+			foreign := member.DeepCopy()
+			foreign.Namespace = "other"
+			client := newIndexedClientBuilder(scheme).
+				WithStatusSubresource(group).
+				WithObjects(group.DeepCopy(), secret.DeepCopy(), member, foreign).
+				Build()
+
+			service := &betterstackfakes.MonitorGroupClient{
+				UpdateFn: func(ctx context.Context, id string, req betterstack.MonitorGroupUpdateRequest) (betterstack.MonitorGroup, error) {
+					return betterstack.MonitorGroup{ID: id, Attributes: betterstack.MonitorGroupAttributes{Name: "Backend", TeamName: "Team A"}}, nil
+				},
+				DeleteFn: func(ctx context.Context, id string) error {
+					assert.String(t, "delete id", id, "group-123")
+					return nil
+				},
+				CreateFn: func(ctx context.Context, req betterstack.MonitorGroupCreateRequest) (betterstack.MonitorGroup, error) {
+					return betterstack.MonitorGroup{ID: "group-456", Attributes: betterstack.MonitorGroupAttributes{Name: "Backend", TeamName: "Team B"}}, nil
+				},
+			}
+			r := &BetterStackMonitorGroupReconciler{
+				Client:  client,
+				Scheme:  scheme,
+				Clients: &fakeBetterStackMonitorGroupClientFactory{group: service},
+			}
+
+			ctx := context.Background()
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: group.Name, Namespace: group.Namespace}})
+			assert.NoError(t, err, "reconcile")
+			assert.Int(t, "delete calls", service.DeleteCalls, tc.wantDeletes)
+
+			updated := &monitoringv1alpha1.BetterStackMonitorGroup{}
+			assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: group.Name, Namespace: group.Namespace}, updated), "fetch updated group")
+			assert.String(t, "group id", updated.Status.MonitorGroupID, tc.wantID)
+			immutable := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionImmutableFieldChanged)
+			assert.NotNil(t, "immutable condition", immutable)
+			assert.Equal(t, "immutable status", immutable.Status, tc.wantStatus)
+			assert.String(t, "immutable reason", immutable.Reason, tc.wantReason)
+			ready := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionReady)
+			assert.NotNil(t, "ready condition", ready)
+			assert.Equal(t, "ready status", ready.Status, tc.wantReady)
+			assert.String(t, "replaced id", updated.Status.ReplacedMonitorGroupID, "")
+
+			moved := &monitoringv1alpha1.BetterStackMonitor{}
+			assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: "member", Namespace: "default"}, moved), "fetch member monitor")
+			assert.String(t, "member group", moved.Spec.MonitorGroupID, tc.wantID)
+			assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: "member", Namespace: "other"}, moved), "fetch foreign monitor")
+			assert.String(t, "foreign group", moved.Spec.MonitorGroupID, "group-123")
+		})
+	}
+}
+
 func TestMonitorGroupReconcileUpdateMissingCreatesGroup(t *testing.T) {
 	scheme := controllertest.NewScheme(t)
 
@@ -363,17 +602,16 @@ func TestMonitorGroupReconcileUpdateMissingCreatesGroup(t *testing.T) {
 		Data:       map[string][]byte{"token": []byte("abcd")},
 	}
 
-	client := fake.NewClientBuilder().
-		WithScheme(scheme).
+	client := newIndexedClientBuilder(scheme).
 		WithStatusSubresource(group).
 		WithObjects(group.DeepCopy(), secret.DeepCopy()).
 		Build()
 
-	service := &fakeMonitorGroupService{
-		updateFn: func(ctx context.Context, id string, req betterstack.MonitorGroupUpdateRequest) (betterstack.MonitorGroup, error) {
+	service := &betterstackfakes.MonitorGroupClient{
+		UpdateFn: func(ctx context.Context, id string, req betterstack.MonitorGroupUpdateRequest) (betterstack.MonitorGroup, error) {
 			return betterstack.MonitorGroup{}, &betterstack.APIError{StatusCode: http.StatusNotFound}
 		},
-		createFn: func(ctx context.Context, req betterstack.MonitorGroupCreateRequest) (betterstack.MonitorGroup, error) {
+		CreateFn: func(ctx context.Context, req betterstack.MonitorGroupCreateRequest) (betterstack.MonitorGroup, error) {
 			return betterstack.MonitorGroup{ID: "new-group"}, nil
 		},
 	}
@@ -386,6 +624,8 @@ func TestMonitorGroupReconcileUpdateMissingCreatesGroup(t *testing.T) {
 		Clients: factory,
 	}
 
+	recreated := testutil.ToFloat64(metrics.RemoteRecreated.WithLabelValues("monitorgroup"))
+
 	ctx := context.Background()
 	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: group.Name, Namespace: group.Namespace}})
 	assert.NoError(t, err, "reconcile")
@@ -394,6 +634,7 @@ func TestMonitorGroupReconcileUpdateMissingCreatesGroup(t *testing.T) {
 	updated := &monitoringv1alpha1.BetterStackMonitorGroup{}
 	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: group.Name, Namespace: group.Namespace}, updated), "fetch updated group")
 	assert.String(t, "group id", updated.Status.MonitorGroupID, "new-group")
+	assert.Equal(t, "recreated metric", testutil.ToFloat64(metrics.RemoteRecreated.WithLabelValues("monitorgroup"))-recreated, 1.0)
 }
 
 func TestMonitorGroupReconcileHandlesUpdateError(t *testing.T) {
@@ -423,14 +664,13 @@ func TestMonitorGroupReconcileHandlesUpdateError(t *testing.T) {
 		Data:       map[string][]byte{"token": []byte("abcd")},
 	}
 
-	client := fake.NewClientBuilder().
-		WithScheme(scheme).
+	client := newIndexedClientBuilder(scheme).
 		WithStatusSubresource(group).
 		WithObjects(group.DeepCopy(), secret.DeepCopy()).
 		Build()
 
-	service := &fakeMonitorGroupService{
-		updateFn: func(ctx context.Context, id string, req betterstack.MonitorGroupUpdateRequest) (betterstack.MonitorGroup, error) {
+	service := &betterstackfakes.MonitorGroupClient{
+		UpdateFn: func(ctx context.Context, id string, req betterstack.MonitorGroupUpdateRequest) (betterstack.MonitorGroup, error) {
 			return betterstack.MonitorGroup{}, fmt.Errorf("api failure")
 		},
 	}
@@ -449,12 +689,12 @@ func TestMonitorGroupReconcileHandlesUpdateError(t *testing.T) {
 	syncCond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionSync)
 	assert.NotNil(t, "sync condition", syncCond)
 	assert.Equal(t, "sync status", syncCond.Status, metav1.ConditionFalse)
-	assert.String(t, "sync reason", syncCond.Reason, "SyncFailed")
+	assert.String(t, "sync reason", syncCond.Reason, monitoringv1alpha1.ReasonSyncFailed)
 
 	ready := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionReady)
 	assert.NotNil(t, "ready condition", ready)
 	assert.Equal(t, "ready status", ready.Status, metav1.ConditionFalse)
-	assert.String(t, "ready reason", ready.Reason, "SyncFailed")
+	assert.String(t, "ready reason", ready.Reason, monitoringv1alpha1.ReasonSyncFailed)
 }
 
 func TestMonitorGroupReconcileHandlesCreateError(t *testing.T) {
@@ -481,14 +721,13 @@ func TestMonitorGroupReconcileHandlesCreateError(t *testing.T) {
 		Data:       map[string][]byte{"token": []byte("abcd")},
 	}
 
-	client := fake.NewClientBuilder().
-		WithScheme(scheme).
+	client := newIndexedClientBuilder(scheme).
 		WithStatusSubresource(group).
 		WithObjects(group.DeepCopy(), secret.DeepCopy()).
 		Build()
 
-	service := &fakeMonitorGroupService{
-		createFn: func(ctx context.Context, req betterstack.MonitorGroupCreateRequest) (betterstack.MonitorGroup, error) {
+	service := &betterstackfakes.MonitorGroupClient{
+		CreateFn: func(ctx context.Context, req betterstack.MonitorGroupCreateRequest) (betterstack.MonitorGroup, error) {
 			return betterstack.MonitorGroup{}, fmt.Errorf("create failed")
 		},
 	}
@@ -507,12 +746,12 @@ func TestMonitorGroupReconcileHandlesCreateError(t *testing.T) {
 	syncCond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionSync)
 	assert.NotNil(t, "sync condition", syncCond)
 	assert.Equal(t, "sync status", syncCond.Status, metav1.ConditionFalse)
-	assert.String(t, "sync reason", syncCond.Reason, "SyncFailed")
+	assert.String(t, "sync reason", syncCond.Reason, monitoringv1alpha1.ReasonSyncFailed)
 
 	ready := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionReady)
 	assert.NotNil(t, "ready condition", ready)
 	assert.Equal(t, "ready status", ready.Status, metav1.ConditionFalse)
-	assert.String(t, "ready reason", ready.Reason, "SyncFailed")
+	assert.String(t, "ready reason", ready.Reason, monitoringv1alpha1.ReasonSyncFailed)
 }
 
 func TestMonitorGroupReconcileStatusPatchFailure(t *testing.T) {
@@ -539,15 +778,14 @@ func TestMonitorGroupReconcileStatusPatchFailure(t *testing.T) {
 		Data:       map[string][]byte{"token": []byte("abcd")},
 	}
 
-	baseClient := fake.NewClientBuilder().
-		WithScheme(scheme).
+	baseClient := newIndexedClientBuilder(scheme).
 		WithStatusSubresource(group).
 		WithObjects(group.DeepCopy(), secret.DeepCopy()).
 		Build()
 
 	failingClient := &controllertest.FailingStatusClient{Client: baseClient, FailOn: 2}
-	service := &fakeMonitorGroupService{
-		createFn: func(ctx context.Context, req betterstack.MonitorGroupCreateRequest) (betterstack.MonitorGroup, error) {
+	service := &betterstackfakes.MonitorGroupClient{
+		CreateFn: func(ctx context.Context, req betterstack.MonitorGroupCreateRequest) (betterstack.MonitorGroup, error) {
 			return betterstack.MonitorGroup{ID: "group-123"}, nil
 		},
 	}
@@ -589,15 +827,14 @@ func TestMonitorGroupHandleDelete(t *testing.T) {
 		Data:       map[string][]byte{"token": []byte("abcd")},
 	}
 
-	client := fake.NewClientBuilder().
-		WithScheme(scheme).
+	client := newIndexedClientBuilder(scheme).
 		WithStatusSubresource(group).
 		WithObjects(group.DeepCopy(), secret.DeepCopy()).
 		Build()
 
 	deleted := false
-	service := &fakeMonitorGroupService{
-		deleteFn: func(ctx context.Context, id string) error {
+	service := &betterstackfakes.MonitorGroupClient{
+		DeleteFn: func(ctx context.Context, id string) error {
 			deleted = true
 			assert.String(t, "delete id", id, "group-123")
 			return nil
@@ -646,20 +883,22 @@ func TestMonitorGroupHandleDeleteMissingCredentials(t *testing.T) {
 		},
 	}
 
-	client := fake.NewClientBuilder().
-		WithScheme(scheme).
+	client := newIndexedClientBuilder(scheme).
 		WithStatusSubresource(group).
 		WithObjects(group.DeepCopy()).
 		Build()
 
-	factory := &fakeBetterStackMonitorGroupClientFactory{group: &fakeMonitorGroupService{}}
+	factory := &fakeBetterStackMonitorGroupClientFactory{group: &betterstackfakes.MonitorGroupClient{}}
 
 	r := &BetterStackMonitorGroupReconciler{Client: client, Scheme: scheme, Clients: factory}
 
+	skipped := testutil.ToFloat64(metrics.FinalizerSkippedRemoteDelete.WithLabelValues("monitorgroup", metrics.SkipReasonCredentialsUnavailable))
+
 	ctx := context.Background()
 	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: group.Name, Namespace: group.Namespace}})
 	assert.NoError(t, err, "reconcile")
 	assert.Equal(t, "requeueAfter", res.RequeueAfter, time.Duration(0))
+	assert.Equal(t, "skipped delete metric", testutil.ToFloat64(metrics.FinalizerSkippedRemoteDelete.WithLabelValues("monitorgroup", metrics.SkipReasonCredentialsUnavailable))-skipped, 1.0)
 
 	updated := &monitoringv1alpha1.BetterStackMonitorGroup{}
 	err = client.Get(ctx, types.NamespacedName{Name: group.Name, Namespace: group.Namespace}, updated)
@@ -699,14 +938,13 @@ func TestMonitorGroupHandleDeleteRemoteNotFound(t *testing.T) {
 		Data:       map[string][]byte{"token": []byte("abcd")},
 	}
 
-	client := fake.NewClientBuilder().
-		WithScheme(scheme).
+	client := newIndexedClientBuilder(scheme).
 		WithStatusSubresource(group).
 		WithObjects(group.DeepCopy(), secret.DeepCopy()).
 		Build()
 
-	service := &fakeMonitorGroupService{
-		deleteFn: func(ctx context.Context, id string) error {
+	service := &betterstackfakes.MonitorGroupClient{
+		DeleteFn: func(ctx context.Context, id string) error {
 			return &betterstack.APIError{StatusCode: http.StatusNotFound}
 		},
 	}
@@ -736,6 +974,9 @@ func TestBuildMonitorGroupRequest(t *testing.T) {
 		TeamName:  "Team A",
 		SortIndex: ptr.To(sortIndex),
 		Paused:    ptr.To(paused),
+		AdditionalAttributes: map[string]string{
+			"custom": "value",
+		},
 	}
 
 	req := buildMonitorGroupRequest(spec)
@@ -747,10 +988,507 @@ func TestBuildMonitorGroupRequest(t *testing.T) {
 	assert.Equal(t, "sort index", *req.SortIndex, sortIndex)
 	assert.NotNil(t, "paused", req.Paused)
 	assert.Bool(t, "paused", *req.Paused, true)
+	assert.Equal(t, "additional attribute", req.AdditionalAttributes["custom"], any("value"))
 
 	emptyReq := buildMonitorGroupRequest(monitoringv1alpha1.BetterStackMonitorGroupSpec{})
 	assert.Nil(t, "empty name", emptyReq.Name)
 	assert.Nil(t, "empty team", emptyReq.TeamName)
 	assert.Nil(t, "empty sort", emptyReq.SortIndex)
 	assert.Nil(t, "empty paused", emptyReq.Paused)
+	assert.Int(t, "empty additional attributes", len(emptyReq.AdditionalAttributes), 0)
+}
+
+func TestBackoffDelay(t *testing.T) {
+	cases := map[int]time.Duration{
+		1:  time.Minute,
+		2:  2 * time.Minute,
+		4:  8 * time.Minute,
+		5:  16 * time.Minute,
+		12: maxRequeueIntervalOnError,
+	}
+	for failures, want := range cases {
+		assert.Equal(t, fmt.Sprintf("delay after %d failures", failures), backoffDelay(failures), want)
+	}
+}
+
+func TestMonitorGroupReconcileBacksOffAcrossRestarts(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	group := &monitoringv1alpha1.BetterStackMonitorGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			Generation: 1,
+			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorGroupFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackMonitorGroupSpec{
+			Name: "Example",
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+
+	client := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(group).
+		WithObjects(group.DeepCopy(), secret.DeepCopy()).
+		Build()
+
+	service := &betterstackfakes.MonitorGroupClient{
+		CreateFn: func(ctx context.Context, req betterstack.MonitorGroupCreateRequest) (betterstack.MonitorGroup, error) {
+			return betterstack.MonitorGroup{}, &betterstack.APIError{StatusCode: http.StatusInternalServerError, Message: "boom"}
+		},
+	}
+	// Each reconcile uses a fresh reconciler, as after an operator restart; only the
+	// status carries the failures over.
+	reconcile := func() ctrl.Result {
+		t.Helper()
+		r := &BetterStackMonitorGroupReconciler{Client: client, Scheme: scheme, Clients: &fakeBetterStackMonitorGroupClientFactory{group: service}}
+		res, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: group.Name, Namespace: group.Namespace}})
+		assert.NoError(t, err, "reconcile")
+		return res
+	}
+	fetch := func() *monitoringv1alpha1.BetterStackMonitorGroup {
+		t.Helper()
+		updated := &monitoringv1alpha1.BetterStackMonitorGroup{}
+		assert.NoError(t, client.Get(context.Background(), types.NamespacedName{Name: group.Name, Namespace: group.Namespace}, updated), "fetch group")
+		return updated
+	}
+	expire := func() {
+		t.Helper()
+		updated := fetch()
+		updated.Status.Backoff.NextRetryTime = metav1.NewTime(time.Now().Add(-time.Second))
+		assert.NoError(t, client.Status().Update(context.Background(), updated), "expire backoff")
+	}
+
+	res := reconcile()
+	assert.Equal(t, "first requeue", res.RequeueAfter, time.Minute)
+	assert.Int(t, "failures", fetch().Status.Backoff.Failures, 1)
+
+	res = reconcile()
+	assert.Int(t, "create calls while backing off", service.CreateCalls, 1)
+	assert.Bool(t, "requeue for the rest of the backoff", res.RequeueAfter > 0 && res.RequeueAfter <= time.Minute, true)
+
+	expire()
+	res = reconcile()
+	assert.Int(t, "create calls after backoff", service.CreateCalls, 2)
+	assert.Equal(t, "second requeue", res.RequeueAfter, 2*time.Minute)
+	assert.Int(t, "failures", fetch().Status.Backoff.Failures, 2)
+
+	// A spec change is retried right away and starts the count over.
+	edited := fetch()
+	edited.Spec.Name = "Renamed"
+	edited.Generation = 2
+	assert.NoError(t, client.Update(context.Background(), edited), "edit spec")
+	edited = fetch()
+	res = reconcile()
+	assert.Int(t, "create calls after spec change", service.CreateCalls, 3)
+	assert.Equal(t, "requeue after spec change", res.RequeueAfter, time.Minute)
+	assert.Equal(t, "backoff generation", fetch().Status.Backoff.Generation, edited.Generation)
+
+	service.CreateFn = func(ctx context.Context, req betterstack.MonitorGroupCreateRequest) (betterstack.MonitorGroup, error) {
+		return betterstack.MonitorGroup{ID: "42"}, nil
+	}
+	expire()
+	reconcile()
+	assert.Nil(t, "backoff after success", fetch().Status.Backoff)
+}
+
+func TestMonitorGroupReconcileKeepsConditionsAddedConcurrently(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	group := &monitoringv1alpha1.BetterStackMonitorGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorGroupFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackMonitorGroupSpec{
+			Name: "Backend services",
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+
+	// Another controller adds its condition after the operator read the group but before
+	// its first status patch lands.
+	foreign := metav1.Condition{Type: "example.com/Approved", Status: metav1.ConditionTrue, Reason: "ChangeApproved", Message: "Approved", LastTransitionTime: metav1.Now()}
+	injected := false
+	client := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(group).
+		WithObjects(group.DeepCopy(), secret.DeepCopy()).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourcePatch: func(ctx context.Context, c ctrlclient.Client, subResource string, obj ctrlclient.Object, patch ctrlclient.Patch, opts ...ctrlclient.SubResourcePatchOption) error {
+				if !injected {
+					injected = true
+					current := &monitoringv1alpha1.BetterStackMonitorGroup{}
+					if err := c.Get(ctx, ctrlclient.ObjectKeyFromObject(obj), current); err != nil {
+						return err
+					}
+					current.Status.Conditions = append(current.Status.Conditions, foreign)
+					if err := c.Status().Update(ctx, current); err != nil {
+						return err
+					}
+				}
+				return c.Status().Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+
+	service := &betterstackfakes.MonitorGroupClient{
+		CreateFn: func(ctx context.Context, req betterstack.MonitorGroupCreateRequest) (betterstack.MonitorGroup, error) {
+			return betterstack.MonitorGroup{ID: "group-123"}, nil
+		},
+	}
+	r := &BetterStackMonitorGroupReconciler{Client: client, Scheme: scheme, Clients: &fakeBetterStackMonitorGroupClientFactory{group: service}}
+
+	ctx := context.Background()
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: group.Name, Namespace: group.Namespace}})
+	assert.NoError(t, err, "reconcile")
+
+	updated := &monitoringv1alpha1.BetterStackMonitorGroup{}
+	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: group.Name, Namespace: group.Namespace}, updated), "fetch updated group")
+	assert.String(t, "group id", updated.Status.MonitorGroupID, "group-123")
+	kept := controllertest.FindCondition(updated.Status.Conditions, foreign.Type)
+	assert.NotNil(t, "foreign condition", kept)
+	assert.String(t, "foreign reason", kept.Reason, foreign.Reason)
+	readyCond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionReady)
+	assert.NotNil(t, "ready condition", readyCond)
+	assert.Equal(t, "ready status", readyCond.Status, metav1.ConditionTrue)
+}
+
+func TestMonitorGroupReconcileRecordsSyncedGenerationAfterConflict(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	group := &monitoringv1alpha1.BetterStackMonitorGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			Generation: 1,
+			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorGroupFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackMonitorGroupSpec{
+			Name: "Backend services",
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+
+	// The spec is edited while the group is being synced, so the first status patch
+	// conflicts and the retry reads the newer generation.
+	edited := false
+	client := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(group).
+		WithObjects(group.DeepCopy(), secret.DeepCopy()).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourcePatch: func(ctx context.Context, c ctrlclient.Client, subResource string, obj ctrlclient.Object, patch ctrlclient.Patch, opts ...ctrlclient.SubResourcePatchOption) error {
+				if !edited {
+					edited = true
+					current := &monitoringv1alpha1.BetterStackMonitorGroup{}
+					if err := c.Get(ctx, ctrlclient.ObjectKeyFromObject(obj), current); err != nil {
+						return err
+					}
+					current.Spec.Name = "Renamed services"
+					current.Generation = 2
+					if err := c.Update(ctx, current); err != nil {
+						return err
+					}
+				}
+				return c.Status().Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+
+	var synced string
+	service := &betterstackfakes.MonitorGroupClient{
+		CreateFn: func(ctx context.Context, req betterstack.MonitorGroupCreateRequest) (betterstack.MonitorGroup, error) {
+			synced = *req.Name
+			return betterstack.MonitorGroup{ID: "group-123"}, nil
+		},
+	}
+	r := &BetterStackMonitorGroupReconciler{Client: client, Scheme: scheme, Clients: &fakeBetterStackMonitorGroupClientFactory{group: service}}
+
+	ctx := context.Background()
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: group.Name, Namespace: group.Namespace}})
+	assert.NoError(t, err, "reconcile")
+	assert.String(t, "synced name", synced, "Backend services")
+
+	updated := &monitoringv1alpha1.BetterStackMonitorGroup{}
+	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: group.Name, Namespace: group.Namespace}, updated), "fetch updated group")
+	assert.Equal(t, "stored generation", updated.Generation, int64(2))
+	assert.Equal(t, "observed generation", updated.Status.ObservedGeneration, int64(1))
+	assert.String(t, "group id", updated.Status.MonitorGroupID, "group-123")
+}
+
+func TestMonitorGroupReconcileRefusesIDClaimedByOtherNamespace(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	newGroup := func(namespace string) *monitoringv1alpha1.BetterStackMonitorGroup {
+		return &monitoringv1alpha1.BetterStackMonitorGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "backend",
+				Namespace:  namespace,
+				Generation: 1,
+				Finalizers: []string{monitoringv1alpha1.BetterStackMonitorGroupFinalizer},
+			},
+			Spec: monitoringv1alpha1.BetterStackMonitorGroupSpec{
+				Name:                   "Backend " + namespace,
+				ExistingMonitorGroupID: "group-1",
+				APITokenSecretRef: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+					Key:                  "token",
+				},
+			},
+		}
+	}
+	newSecret := func(namespace string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: namespace},
+			Data:       map[string][]byte{"token": []byte("abcd")},
+		}
+	}
+	first, second := newGroup("team-a"), newGroup("team-b")
+
+	client := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(first, second).
+		WithObjects(first.DeepCopy(), second.DeepCopy(), newSecret("team-a"), newSecret("team-b")).
+		Build()
+
+	var updatedNames []string
+	service := &betterstackfakes.MonitorGroupClient{
+		UpdateFn: func(ctx context.Context, id string, req betterstack.MonitorGroupUpdateRequest) (betterstack.MonitorGroup, error) {
+			assert.String(t, "update id", id, "group-1")
+			updatedNames = append(updatedNames, ptr.Deref(req.Name, ""))
+			return betterstack.MonitorGroup{ID: id}, nil
+		},
+	}
+
+	r := &BetterStackMonitorGroupReconciler{
+		Client:  client,
+		Scheme:  scheme,
+		Clients: &fakeBetterStackMonitorGroupClientFactory{group: service},
+	}
+
+	ctx := context.Background()
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: ctrlclient.ObjectKeyFromObject(first)})
+	assert.NoError(t, err, "reconcile first group")
+
+	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: ctrlclient.ObjectKeyFromObject(second)})
+	assert.NoError(t, err, "reconcile second group")
+	assert.Equal(t, "requeueAfter", res.RequeueAfter, requeueIntervalOnError)
+	assert.StringSlice(t, "updated names", updatedNames, []string{"Backend team-a"})
+
+	updated := &monitoringv1alpha1.BetterStackMonitorGroup{}
+	assert.NoError(t, client.Get(ctx, ctrlclient.ObjectKeyFromObject(second), updated), "fetch second group")
+	assert.String(t, "group id", updated.Status.MonitorGroupID, "")
+	ready := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionReady)
+	assert.NotNil(t, "ready condition", ready)
+	assert.String(t, "ready reason", ready.Reason, monitoringv1alpha1.ReasonRemoteIDClaimed)
+
+	// Deleting the first group releases its claim, so the second may take the group over.
+	stale := &monitoringv1alpha1.BetterStackMonitorGroup{}
+	assert.NoError(t, client.Get(ctx, ctrlclient.ObjectKeyFromObject(first), stale), "fetch first group")
+	stale.Finalizers = nil
+	assert.NoError(t, client.Update(ctx, stale), "remove first finalizer")
+	assert.NoError(t, client.Delete(ctx, stale), "delete first group")
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: ctrlclient.ObjectKeyFromObject(first)})
+	assert.NoError(t, err, "reconcile deleted group")
+
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: ctrlclient.ObjectKeyFromObject(second)})
+	assert.NoError(t, err, "reconcile second group again")
+	assert.StringSlice(t, "updated names", updatedNames, []string{"Backend team-a", "Backend team-b"})
+	assert.NoError(t, client.Get(ctx, ctrlclient.ObjectKeyFromObject(second), updated), "fetch adopted group")
+	assert.String(t, "group id", updated.Status.MonitorGroupID, "group-1")
+}
+
+func TestMonitorGroupReconcileListsBackoffOnEndpoint(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	group := &monitoringv1alpha1.BetterStackMonitorGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			Generation: 1,
+			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorGroupFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackMonitorGroupSpec{
+			Name: "Example",
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+
+	client := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(group).
+		WithObjects(group.DeepCopy(), secret.DeepCopy()).
+		Build()
+
+	failing := true
+	service := &betterstackfakes.MonitorGroupClient{
+		CreateFn: func(ctx context.Context, req betterstack.MonitorGroupCreateRequest) (betterstack.MonitorGroup, error) {
+			if failing {
+				return betterstack.MonitorGroup{}, &betterstack.APIError{StatusCode: http.StatusInternalServerError, Message: "boom"}
+			}
+			return betterstack.MonitorGroup{ID: "group-1"}, nil
+		},
+	}
+	reconcile := func(tracker *retries.Tracker) {
+		t.Helper()
+		r := &BetterStackMonitorGroupReconciler{Client: client, Scheme: scheme, Clients: &fakeBetterStackMonitorGroupClientFactory{group: service}, Retries: tracker}
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: ctrlclient.ObjectKeyFromObject(group)})
+		assert.NoError(t, err, "reconcile")
+	}
+	list := func(tracker *retries.Tracker, query string) []retries.Entry {
+		t.Helper()
+		recorder := httptest.NewRecorder()
+		tracker.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/backoff"+query, nil))
+		assert.Int(t, "status code", recorder.Code, http.StatusOK)
+		var entries []retries.Entry
+		assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &entries), "decode entries")
+		return entries
+	}
+
+	tracker := retries.NewTracker()
+	reconcile(tracker)
+	entries := list(tracker, "?kind=monitorgroup")
+	assert.Int(t, "entries", len(entries), 1)
+	assert.String(t, "name", entries[0].Name, "example")
+	assert.Int(t, "failures", entries[0].Failures, 1)
+	assert.String(t, "reason", entries[0].Reason, monitoringv1alpha1.ReasonSyncFailed)
+	assert.Bool(t, "last error", strings.Contains(entries[0].LastError, "boom"), true)
+	assert.Int(t, "other namespace", len(list(tracker, "?namespace=other")), 0)
+
+	// A restarted operator lists the resource again from its status.
+	restarted := retries.NewTracker()
+	reconcile(restarted)
+	entries = list(restarted, "")
+	assert.Int(t, "entries after restart", len(entries), 1)
+	assert.Bool(t, "last error after restart", strings.Contains(entries[0].LastError, "boom"), true)
+
+	expire := func() {
+		t.Helper()
+		expired := &monitoringv1alpha1.BetterStackMonitorGroup{}
+		assert.NoError(t, client.Get(context.Background(), ctrlclient.ObjectKeyFromObject(group), expired), "fetch group")
+		expired.Status.Backoff.NextRetryTime = metav1.NewTime(time.Now().Add(-time.Second))
+		assert.NoError(t, client.Status().Update(context.Background(), expired), "expire backoff")
+	}
+
+	// Giving up early because the token is missing drops the entry too.
+	expire()
+	assert.NoError(t, client.Delete(context.Background(), secret.DeepCopy()), "delete secret")
+	reconcile(restarted)
+	assert.Int(t, "entries without token", len(list(restarted, "")), 0)
+	assert.NoError(t, client.Create(context.Background(), secret.DeepCopy()), "restore secret")
+	reconcile(restarted)
+	assert.Int(t, "entries after token restored", len(list(restarted, "")), 1)
+
+	failing = false
+	expire()
+	reconcile(restarted)
+	assert.Int(t, "entries after success", len(list(restarted, "")), 0)
+}
+
+func TestMonitorGroupClaimsFollowPersistedState(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+	older := metav1.NewTime(time.Now().Add(-time.Hour))
+	newer := metav1.NewTime(time.Now())
+
+	newGroup := func(namespace, name string, created metav1.Time, statusID string) *monitoringv1alpha1.BetterStackMonitorGroup {
+		return &monitoringv1alpha1.BetterStackMonitorGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         namespace,
+				Generation:        1,
+				CreationTimestamp: created,
+				Finalizers:        []string{monitoringv1alpha1.BetterStackMonitorGroupFinalizer},
+			},
+			Spec: monitoringv1alpha1.BetterStackMonitorGroupSpec{
+				Name:                   "Backend",
+				ExistingMonitorGroupID: "group-1",
+				APITokenSecretRef: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+					Key:                  "token",
+				},
+			},
+			Status: monitoringv1alpha1.BetterStackMonitorGroupStatus{MonitorGroupID: statusID},
+		}
+	}
+	newSecret := func(namespace string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: namespace},
+			Data:       map[string][]byte{"token": []byte("abcd")},
+		}
+	}
+	// team-b synced the group first; the older team-a resource only names it in its spec,
+	// as a freshly restarted operator would see them.
+	owner := newGroup("team-b", "backend", newer, "group-1")
+	sharer := newGroup("team-b", "backend-copy", newer, "group-1")
+	hijacker := newGroup("team-a", "backend", older, "")
+
+	client := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(owner, sharer, hijacker).
+		WithObjects(owner.DeepCopy(), sharer.DeepCopy(), hijacker.DeepCopy(), newSecret("team-a"), newSecret("team-b")).
+		Build()
+
+	service := &betterstackfakes.MonitorGroupClient{
+		UpdateFn: func(ctx context.Context, id string, req betterstack.MonitorGroupUpdateRequest) (betterstack.MonitorGroup, error) {
+			return betterstack.MonitorGroup{ID: id}, nil
+		},
+	}
+	r := &BetterStackMonitorGroupReconciler{Client: client, Scheme: scheme, Clients: &fakeBetterStackMonitorGroupClientFactory{group: service}}
+	ctx := context.Background()
+
+	assertRefused := func(step string) {
+		t.Helper()
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: ctrlclient.ObjectKeyFromObject(hijacker)})
+		assert.NoError(t, err, "%s", step)
+		assert.Int(t, step+": update calls", service.UpdateCalls, 0)
+		updated := &monitoringv1alpha1.BetterStackMonitorGroup{}
+		assert.NoError(t, client.Get(ctx, ctrlclient.ObjectKeyFromObject(hijacker), updated), "fetch hijacker")
+		ready := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionReady)
+		assert.NotNil(t, step+": ready condition", ready)
+		assert.String(t, step+": ready reason", ready.Reason, monitoringv1alpha1.ReasonRemoteIDClaimed)
+	}
+	assertRefused("reconcile hijacker first")
+
+	// Deleting one of the team-b sharers leaves the remote group to the other, and the
+	// hijacker is still refused.
+	deleting := &monitoringv1alpha1.BetterStackMonitorGroup{}
+	assert.NoError(t, client.Get(ctx, ctrlclient.ObjectKeyFromObject(owner), deleting), "fetch owner")
+	assert.NoError(t, client.Delete(ctx, deleting), "delete owner")
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: ctrlclient.ObjectKeyFromObject(owner)})
+	assert.NoError(t, err, "reconcile deleted owner")
+	assert.Int(t, "delete calls after shared delete", service.DeleteCalls, 0)
+	assertRefused("reconcile hijacker after owner deleted")
+
+	// The remaining sharer adopted the group through existingMonitorGroupID, so deleting it
+	// leaves the remote group in place too.
+	assert.NoError(t, client.Get(ctx, ctrlclient.ObjectKeyFromObject(sharer), deleting), "fetch sharer")
+	assert.NoError(t, client.Delete(ctx, deleting), "delete sharer")
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: ctrlclient.ObjectKeyFromObject(sharer)})
+	assert.NoError(t, err, "reconcile deleted sharer")
+	assert.Int(t, "delete calls after adopted delete", service.DeleteCalls, 0)
 }
@@ -0,0 +1,297 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/internal/controller/accounts"
+	"loks0n/betterstack-operator/internal/controller/conditions"
+	"loks0n/betterstack-operator/internal/controller/credentials"
+	"loks0n/betterstack-operator/internal/controller/metrics"
+	"loks0n/betterstack-operator/internal/controller/refs"
+	"loks0n/betterstack-operator/internal/controller/secretwatch"
+	"loks0n/betterstack-operator/internal/controller/shutdown"
+	"loks0n/betterstack-operator/pkg/betterstack"
+)
+
+const defaultSyncReportInterval = time.Hour
+
+// maxSyncReportEntries caps each list in a sync report's status, so an account with
+// thousands of unmanaged resources cannot push the object past the etcd size limit.
+const maxSyncReportEntries = 100
+
+// BetterStackSyncReportReconciler periodically compares a Better Stack account with the
+// monitors and heartbeats declared in the cluster.
+type BetterStackSyncReportReconciler struct {
+	client.Client
+	Scheme           *runtime.Scheme
+	HTTPClient       *http.Client
+	MonitorClients   BetterStackMonitorClientFactory
+	HeartbeatClients BetterStackHeartbeatClientFactory
+	Accounts         *accounts.Registry
+	Drainer          *shutdown.Drainer
+
+	// References decides whether accountRef may select a credential in another namespace.
+	References refs.Policy
+
+	// DefaultBaseURL is the Better Stack API endpoint for resources whose spec and
+	// credential set no baseURL. Empty means the public API.
+	DefaultBaseURL string
+}
+
+//+kubebuilder:rbac:groups=monitoring.betterstack.io,resources=betterstacksyncreports,verbs=get;list;watch
+//+kubebuilder:rbac:groups=monitoring.betterstack.io,resources=betterstacksyncreports/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=monitoring.betterstack.io,resources=betterstackmonitors;betterstackheartbeats,verbs=get;list;watch
+//+kubebuilder:rbac:groups=monitoring.betterstack.io,resources=betterstackcredentials,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+func (r *BetterStackSyncReportReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, done, ok := r.Drainer.Begin(ctx)
+	if !ok {
+		return ctrl.Result{}, nil
+	}
+	defer done()
+
+	logger := log.FromContext(ctx)
+
+	report := &monitoringv1alpha1.BetterStackSyncReport{}
+	if err := r.Get(ctx, req.NamespacedName, report); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	account, err := resolveAccount(ctx, r.Client, r.References, r.DefaultBaseURL, report.Namespace, report.Spec.AccountRef, report.Spec.APITokenSecretRef, report.Spec.BaseURL)
+	if err != nil {
+		logger.Error(err, "unable to fetch Better Stack API token")
+		_ = r.patchStatus(ctx, report, func(status *monitoringv1alpha1.BetterStackSyncReportStatus) {
+			now := metav1.Now()
+			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionCredentials, metav1.ConditionFalse, monitoringv1alpha1.ReasonTokenUnavailable, err.Error(), &now))
+			status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionFalse, monitoringv1alpha1.ReasonTokenUnavailable, "API credentials not available", &now))
+		})
+		return ctrl.Result{RequeueAfter: requeueIntervalOnError}, nil
+	}
+
+	httpClient := r.Accounts.HTTPClient(account, r.HTTPClient)
+	monitorFactory := r.MonitorClients
+	if monitorFactory == nil {
+		monitorFactory = defaultBetterStackMonitorClientFactory{}
+	}
+	heartbeatFactory := r.HeartbeatClients
+	if heartbeatFactory == nil {
+		heartbeatFactory = defaultBetterStackHeartbeatClientFactory{}
+	}
+
+	remoteMonitors, err := monitorFactory.Monitor(account.BaseURL, account.Token, httpClient).List(ctx)
+	if err != nil {
+		return r.listFailed(ctx, report, err)
+	}
+	remoteHeartbeats, err := heartbeatFactory.Heartbeat(account.BaseURL, account.Token, httpClient).List(ctx)
+	if err != nil {
+		return r.listFailed(ctx, report, err)
+	}
+
+	// Better Stack exposes no quota information, so the report's complete listing is the
+	// only source of usage; the limits come from the credential.
+	metrics.SetQuota(account.Name, "monitors", len(remoteMonitors), account.Quota.Monitors)
+	metrics.SetQuota(account.Name, "heartbeats", len(remoteHeartbeats), account.Quota.Heartbeats)
+
+	result, err := r.buildReport(ctx, account, remoteMonitors, remoteHeartbeats)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	return r.publishReport(ctx, report, account, result)
+}
+
+func (r *BetterStackSyncReportReconciler) listFailed(ctx context.Context, report *monitoringv1alpha1.BetterStackSyncReport, err error) (ctrl.Result, error) {
+	log.FromContext(ctx).Error(err, "unable to list Better Stack resources")
+	_ = r.patchStatus(ctx, report, func(status *monitoringv1alpha1.BetterStackSyncReportStatus) {
+		now := metav1.Now()
+		status.SetCondition(conditions.New(monitoringv1alpha1.ConditionSync, metav1.ConditionFalse, monitoringv1alpha1.ReasonListFailed, err.Error(), &now))
+		status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionFalse, monitoringv1alpha1.ReasonListFailed, "Unable to list Better Stack resources", &now))
+	})
+	return ctrl.Result{RequeueAfter: requeueIntervalOnError}, nil
+}
+
+// truncateReport returns the first maxSyncReportEntries entries and whether any were left
+// out.
+func truncateReport[T any](entries []T) ([]T, bool) {
+	if len(entries) <= maxSyncReportEntries {
+		return entries, false
+	}
+	return entries[:maxSyncReportEntries], true
+}
+
+func (r *BetterStackSyncReportReconciler) publishReport(ctx context.Context, report *monitoringv1alpha1.BetterStackSyncReport, account credentials.Account, result syncReportResult) (ctrl.Result, error) {
+	now := metav1.Now()
+	err := r.patchStatus(ctx, report, func(status *monitoringv1alpha1.BetterStackSyncReportStatus) {
+		status.ObservedGeneration = report.Generation
+		status.BaseURL = account.BaseURL
+		status.LastReportTime = &now
+		var unmanagedCut, duplicatesCut, missingCut bool
+		status.Unmanaged, unmanagedCut = truncateReport(result.unmanaged)
+		status.Duplicates, duplicatesCut = truncateReport(result.duplicates)
+		status.MissingRemotes, missingCut = truncateReport(result.missing)
+		status.Truncated = unmanagedCut || duplicatesCut || missingCut
+		status.UnmanagedCount = len(result.unmanaged)
+		status.DuplicateCount = len(result.duplicates)
+		status.MissingRemoteCount = len(result.missing)
+		message := fmt.Sprintf("%d unmanaged, %d duplicated, %d missing remote", len(result.unmanaged), len(result.duplicates), len(result.missing))
+		status.SetCondition(conditions.New(monitoringv1alpha1.ConditionCredentials, metav1.ConditionTrue, monitoringv1alpha1.ReasonTokenResolved, fmt.Sprintf("Using %s", account.Source), &now))
+		status.SetCondition(conditions.New(monitoringv1alpha1.ConditionSync, metav1.ConditionTrue, monitoringv1alpha1.ReasonReportGenerated, message, &now))
+		status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionTrue, monitoringv1alpha1.ReasonReportGenerated, message, &now))
+	})
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: syncReportInterval(report.Spec)}, nil
+}
+
+type syncReportResult struct {
+	unmanaged  []monitoringv1alpha1.RemoteResourceRef
+	duplicates []monitoringv1alpha1.DuplicateRemoteResources
+	missing    []monitoringv1alpha1.MissingRemoteResource
+}
+
+// buildReport compares the remote resources of account with the custom resources across
+// all namespaces that reconcile against the same account.
+func (r *BetterStackSyncReportReconciler) buildReport(ctx context.Context, account credentials.Account, remoteMonitors []betterstack.Monitor, remoteHeartbeats []betterstack.Heartbeat) (syncReportResult, error) {
+	managed := map[string]bool{}
+	var result syncReportResult
+
+	monitorIDs := map[string]bool{}
+	for _, remote := range remoteMonitors {
+		monitorIDs[remote.ID] = true
+	}
+	monitors := &monitoringv1alpha1.BetterStackMonitorList{}
+	if err := r.List(ctx, monitors); err != nil {
+		return syncReportResult{}, err
+	}
+	for _, monitor := range monitors.Items {
+		if monitor.Status.MonitorID == "" || !r.usesAccount(ctx, account, monitor.Namespace, monitor.Spec.AccountRef, monitor.Spec.APITokenSecretRef, monitor.Spec.BaseURL) {
+			continue
+		}
+		managed["Monitor/"+monitor.Status.MonitorID] = true
+		if !monitorIDs[monitor.Status.MonitorID] {
+			result.missing = append(result.missing, monitoringv1alpha1.MissingRemoteResource{Kind: "BetterStackMonitor", Namespace: monitor.Namespace, Name: monitor.Name, RemoteID: monitor.Status.MonitorID})
+		}
+	}
+
+	heartbeatIDs := map[string]bool{}
+	for _, remote := range remoteHeartbeats {
+		heartbeatIDs[remote.ID] = true
+	}
+	heartbeats := &monitoringv1alpha1.BetterStackHeartbeatList{}
+	if err := r.List(ctx, heartbeats); err != nil {
+		return syncReportResult{}, err
+	}
+	for _, heartbeat := range heartbeats.Items {
+		if heartbeat.Status.HeartbeatID == "" || !r.usesAccount(ctx, account, heartbeat.Namespace, heartbeat.Spec.AccountRef, heartbeat.Spec.APITokenSecretRef, heartbeat.Spec.BaseURL) {
+			continue
+		}
+		managed["Heartbeat/"+heartbeat.Status.HeartbeatID] = true
+		if !heartbeatIDs[heartbeat.Status.HeartbeatID] {
+			result.missing = append(result.missing, monitoringv1alpha1.MissingRemoteResource{Kind: "BetterStackHeartbeat", Namespace: heartbeat.Namespace, Name: heartbeat.Name, RemoteID: heartbeat.Status.HeartbeatID})
+		}
+	}
+
+	remotes := make([]monitoringv1alpha1.RemoteResourceRef, 0, len(remoteMonitors)+len(remoteHeartbeats))
+	for _, remote := range remoteMonitors {
+		remotes = append(remotes, monitoringv1alpha1.RemoteResourceRef{Kind: "Monitor", ID: remote.ID, Name: remote.Attributes.PronounceableName})
+	}
+	for _, remote := range remoteHeartbeats {
+		remotes = append(remotes, monitoringv1alpha1.RemoteResourceRef{Kind: "Heartbeat", ID: remote.ID, Name: remote.Attributes.Name})
+	}
+
+	byName := map[string][]string{}
+	for _, remote := range remotes {
+		if !managed[remote.Kind+"/"+remote.ID] {
+			result.unmanaged = append(result.unmanaged, remote)
+		}
+		if remote.Name != "" {
+			key := remote.Kind + "/" + remote.Name
+			byName[key] = append(byName[key], remote.ID)
+		}
+	}
+	for key, ids := range byName {
+		if len(ids) < 2 {
+			continue
+		}
+		kind, name, _ := strings.Cut(key, "/")
+		sort.Strings(ids)
+		result.duplicates = append(result.duplicates, monitoringv1alpha1.DuplicateRemoteResources{Kind: kind, Name: name, IDs: ids})
+	}
+
+	sort.Slice(result.unmanaged, func(i, j int) bool {
+		if result.unmanaged[i].Kind != result.unmanaged[j].Kind {
+			return result.unmanaged[i].Kind > result.unmanaged[j].Kind
+		}
+		return result.unmanaged[i].ID < result.unmanaged[j].ID
+	})
+	sort.Slice(result.duplicates, func(i, j int) bool {
+		if result.duplicates[i].Kind != result.duplicates[j].Kind {
+			return result.duplicates[i].Kind > result.duplicates[j].Kind
+		}
+		return result.duplicates[i].Name < result.duplicates[j].Name
+	})
+	sort.Slice(result.missing, func(i, j int) bool {
+		a, b := result.missing[i], result.missing[j]
+		if a.Kind != b.Kind {
+			return a.Kind > b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return result, nil
+}
+
+// usesAccount reports whether a resource resolves to the same token and API endpoint as
+// account. Resources whose credentials cannot be resolved are skipped.
+func (r *BetterStackSyncReportReconciler) usesAccount(ctx context.Context, account credentials.Account, namespace string, accountRef *monitoringv1alpha1.ResourceRef, selector corev1.SecretKeySelector, baseURL string) bool {
+	other, err := resolveAccount(ctx, r.Client, r.References, r.DefaultBaseURL, namespace, accountRef, selector, baseURL)
+	if err != nil {
+		return false
+	}
+	return other.Token == account.Token && other.BaseURL == account.BaseURL
+}
+
+func syncReportInterval(spec monitoringv1alpha1.BetterStackSyncReportSpec) time.Duration {
+	if spec.IntervalMinutes <= 0 {
+		return defaultSyncReportInterval
+	}
+	return time.Duration(spec.IntervalMinutes) * time.Minute
+}
+
+func (r *BetterStackSyncReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	builder, err := secretwatch.Watch(mgr, ctrl.NewControllerManagedBy(mgr).Named("betterstacksyncreport").Watches(&monitoringv1alpha1.BetterStackSyncReport{}, enqueueSpecChangesFirst()), "syncreport", &monitoringv1alpha1.BetterStackSyncReport{}, func() client.ObjectList { return &monitoringv1alpha1.BetterStackSyncReportList{} }, reportCredentialRefs)
+	if err != nil {
+		return err
+	}
+	return builder.Complete(r)
+}
+
+func reportCredentialRefs(report *monitoringv1alpha1.BetterStackSyncReport) ([]string, *monitoringv1alpha1.ResourceRef) {
+	return []string{report.Spec.APITokenSecretRef.Name}, report.Spec.AccountRef
+}
+
+func (r *BetterStackSyncReportReconciler) patchStatus(ctx context.Context, report *monitoringv1alpha1.BetterStackSyncReport, mutate func(*monitoringv1alpha1.BetterStackSyncReportStatus)) error {
+	return patchObjectStatus(ctx, r.Client, report, func(report *monitoringv1alpha1.BetterStackSyncReport) *monitoringv1alpha1.BetterStackSyncReportStatus {
+		return &report.Status
+	}, mutate)
+}
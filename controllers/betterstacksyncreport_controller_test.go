@@ -0,0 +1,217 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/internal/controller/metrics"
+	"loks0n/betterstack-operator/internal/testutil/assert"
+	"loks0n/betterstack-operator/internal/testutil/controllertest"
+	"loks0n/betterstack-operator/pkg/betterstack"
+	"loks0n/betterstack-operator/pkg/betterstack/betterstackfakes"
+)
+
+func TestSyncReportReconcileBuildsReport(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	tokenRef := corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+		Key:                  "token",
+	}
+	report := &monitoringv1alpha1.BetterStackSyncReport{
+		ObjectMeta: metav1.ObjectMeta{Name: "hygiene", Namespace: "default", Generation: 3},
+		Spec:       monitoringv1alpha1.BetterStackSyncReportSpec{APITokenSecretRef: tokenRef, IntervalMinutes: 30},
+	}
+	managed := &monitoringv1alpha1.BetterStackMonitor{
+		ObjectMeta: metav1.ObjectMeta{Name: "managed", Namespace: "default"},
+		Spec:       monitoringv1alpha1.BetterStackMonitorSpec{APITokenSecretRef: tokenRef},
+		Status:     monitoringv1alpha1.BetterStackMonitorStatus{MonitorID: "m-1"},
+	}
+	missing := &monitoringv1alpha1.BetterStackMonitor{
+		ObjectMeta: metav1.ObjectMeta{Name: "missing", Namespace: "default"},
+		Spec:       monitoringv1alpha1.BetterStackMonitorSpec{APITokenSecretRef: tokenRef},
+		Status:     monitoringv1alpha1.BetterStackMonitorStatus{MonitorID: "m-9"},
+	}
+	otherAccount := &monitoringv1alpha1.BetterStackMonitor{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "team-b"},
+		Spec:       monitoringv1alpha1.BetterStackMonitorSpec{APITokenSecretRef: tokenRef},
+		Status:     monitoringv1alpha1.BetterStackMonitorStatus{MonitorID: "m-2"},
+	}
+	heartbeat := &monitoringv1alpha1.BetterStackHeartbeat{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "default"},
+		Spec:       monitoringv1alpha1.BetterStackHeartbeatSpec{APITokenSecretRef: tokenRef},
+		Status:     monitoringv1alpha1.BetterStackHeartbeatStatus{HeartbeatID: "h-1"},
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+	otherSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "team-b"},
+		Data:       map[string][]byte{"token": []byte("efgh")},
+	}
+
+	client := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(report).
+		WithObjects(report.DeepCopy(), managed, missing, otherAccount, heartbeat, secret, otherSecret).
+		Build()
+
+	monitors := &betterstackfakes.MonitorClient{
+		ListFn: func(ctx context.Context) ([]betterstack.Monitor, error) {
+			return []betterstack.Monitor{
+				{ID: "m-1", Attributes: betterstack.MonitorAttributes{PronounceableName: "API"}},
+				{ID: "m-2", Attributes: betterstack.MonitorAttributes{PronounceableName: "API"}},
+				{ID: "m-3", Attributes: betterstack.MonitorAttributes{PronounceableName: "Docs"}},
+			}, nil
+		},
+	}
+	heartbeats := &betterstackfakes.HeartbeatClient{
+		ListFn: func(ctx context.Context) ([]betterstack.Heartbeat, error) {
+			return []betterstack.Heartbeat{{ID: "h-1", Attributes: betterstack.HeartbeatAttributes{Name: "Nightly"}}}, nil
+		},
+	}
+
+	monitorFactory := &fakeBetterStackMonitorClientFactory{monitor: monitors}
+	r := &BetterStackSyncReportReconciler{
+		Client:           client,
+		Scheme:           scheme,
+		MonitorClients:   monitorFactory,
+		HeartbeatClients: &fakeBetterStackHeartbeatClientFactory{heartbeat: heartbeats},
+	}
+
+	ctx := context.Background()
+	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: report.Name, Namespace: report.Namespace}})
+	assert.NoError(t, err, "reconcile")
+	assert.Equal(t, "requeueAfter", res.RequeueAfter, 30*time.Minute)
+	assert.String(t, "token", monitorFactory.lastMonitorToken, "abcd")
+
+	updated := &monitoringv1alpha1.BetterStackSyncReport{}
+	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: report.Name, Namespace: report.Namespace}, updated), "fetch report")
+	assert.Equal(t, "observed generation", updated.Status.ObservedGeneration, int64(3))
+	assert.NotNil(t, "last report time", updated.Status.LastReportTime)
+
+	assert.Int(t, "unmanaged count", updated.Status.UnmanagedCount, 2)
+	assert.Equal(t, "unmanaged[0]", updated.Status.Unmanaged[0], monitoringv1alpha1.RemoteResourceRef{Kind: "Monitor", ID: "m-2", Name: "API"})
+	assert.Equal(t, "unmanaged[1]", updated.Status.Unmanaged[1], monitoringv1alpha1.RemoteResourceRef{Kind: "Monitor", ID: "m-3", Name: "Docs"})
+
+	assert.Int(t, "duplicate count", updated.Status.DuplicateCount, 1)
+	assert.String(t, "duplicate name", updated.Status.Duplicates[0].Name, "API")
+	assert.StringSlice(t, "duplicate ids", updated.Status.Duplicates[0].IDs, []string{"m-1", "m-2"})
+
+	assert.Int(t, "missing count", updated.Status.MissingRemoteCount, 1)
+	assert.Bool(t, "truncated", updated.Status.Truncated, false)
+	assert.Equal(t, "missing remote", updated.Status.MissingRemotes[0], monitoringv1alpha1.MissingRemoteResource{Kind: "BetterStackMonitor", Namespace: "default", Name: "missing", RemoteID: "m-9"})
+
+	readyCond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionReady)
+	assert.NotNil(t, "ready condition", readyCond)
+	assert.Equal(t, "ready status", readyCond.Status, metav1.ConditionTrue)
+	assert.String(t, "ready reason", readyCond.Reason, monitoringv1alpha1.ReasonReportGenerated)
+
+	assert.Equal(t, "monitors used", testutil.ToFloat64(metrics.QuotaUsed.WithLabelValues(metrics.DefaultAccountLabel, "monitors")), 3.0)
+	assert.Equal(t, "heartbeats used", testutil.ToFloat64(metrics.QuotaUsed.WithLabelValues(metrics.DefaultAccountLabel, "heartbeats")), 1.0)
+}
+
+func TestSyncReportReconcileTruncatesLists(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	tokenRef := corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+		Key:                  "token",
+	}
+	report := &monitoringv1alpha1.BetterStackSyncReport{
+		ObjectMeta: metav1.ObjectMeta{Name: "hygiene", Namespace: "default"},
+		Spec:       monitoringv1alpha1.BetterStackSyncReportSpec{APITokenSecretRef: tokenRef},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+	client := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(report).
+		WithObjects(report.DeepCopy(), secret).
+		Build()
+
+	remote := make([]betterstack.Monitor, maxSyncReportEntries+5)
+	for i := range remote {
+		remote[i] = betterstack.Monitor{ID: fmt.Sprintf("m-%03d", i), Attributes: betterstack.MonitorAttributes{PronounceableName: fmt.Sprintf("Monitor %03d", i)}}
+	}
+	r := &BetterStackSyncReportReconciler{
+		Client:           client,
+		Scheme:           scheme,
+		MonitorClients:   &fakeBetterStackMonitorClientFactory{monitor: &betterstackfakes.MonitorClient{ListFn: func(ctx context.Context) ([]betterstack.Monitor, error) { return remote, nil }}},
+		HeartbeatClients: &fakeBetterStackHeartbeatClientFactory{heartbeat: &betterstackfakes.HeartbeatClient{}},
+	}
+
+	ctx := context.Background()
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: report.Name, Namespace: report.Namespace}})
+	assert.NoError(t, err, "reconcile")
+
+	updated := &monitoringv1alpha1.BetterStackSyncReport{}
+	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: report.Name, Namespace: report.Namespace}, updated), "fetch report")
+	assert.Int(t, "unmanaged count", updated.Status.UnmanagedCount, maxSyncReportEntries+5)
+	assert.Int(t, "unmanaged entries", len(updated.Status.Unmanaged), maxSyncReportEntries)
+	assert.Bool(t, "truncated", updated.Status.Truncated, true)
+}
+
+func TestSyncReportReconcileHandlesListError(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	report := &monitoringv1alpha1.BetterStackSyncReport{
+		ObjectMeta: metav1.ObjectMeta{Name: "hygiene", Namespace: "default"},
+		Spec: monitoringv1alpha1.BetterStackSyncReportSpec{APITokenSecretRef: corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+			Key:                  "token",
+		}},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+
+	client := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(report).
+		WithObjects(report.DeepCopy(), secret).
+		Build()
+
+	monitors := &betterstackfakes.MonitorClient{
+		ListFn: func(ctx context.Context) ([]betterstack.Monitor, error) {
+			return nil, &betterstack.APIError{StatusCode: http.StatusUnauthorized, Message: "invalid token"}
+		},
+	}
+	heartbeatFactory := &fakeBetterStackHeartbeatClientFactory{}
+	r := &BetterStackSyncReportReconciler{
+		Client:           client,
+		Scheme:           scheme,
+		MonitorClients:   &fakeBetterStackMonitorClientFactory{monitor: monitors},
+		HeartbeatClients: heartbeatFactory,
+	}
+
+	ctx := context.Background()
+	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: report.Name, Namespace: report.Namespace}})
+	assert.NoError(t, err, "reconcile")
+	assert.Equal(t, "requeueAfter", res.RequeueAfter, requeueIntervalOnError)
+	assert.Int(t, "heartbeat calls", heartbeatFactory.heartbeatCalls, 0)
+
+	updated := &monitoringv1alpha1.BetterStackSyncReport{}
+	assert.NoError(t, client.Get(ctx, types.NamespacedName{Name: report.Name, Namespace: report.Namespace}, updated), "fetch report")
+	syncCond := controllertest.FindCondition(updated.Status.Conditions, monitoringv1alpha1.ConditionSync)
+	assert.NotNil(t, "sync condition", syncCond)
+	assert.Equal(t, "sync status", syncCond.Status, metav1.ConditionFalse)
+	assert.String(t, "sync reason", syncCond.Reason, monitoringv1alpha1.ReasonListFailed)
+	assert.Bool(t, "no report time", updated.Status.LastReportTime == nil, true)
+}
@@ -0,0 +1,170 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/internal/controller/conditions"
+)
+
+// Field indexes listing the resources that name a remote ID, in status or, before the
+// first sync, through spec.existingMonitorID or spec.existingMonitorGroupID.
+const (
+	monitorIDIndexKey      = "monitoring.betterstack.io/monitor-id"
+	monitorGroupIDIndexKey = "monitoring.betterstack.io/monitorgroup-id"
+)
+
+// indexMonitorID returns the remote monitor ID a monitor manages or asks to adopt.
+func indexMonitorID(obj client.Object) []string {
+	monitor, ok := obj.(*monitoringv1alpha1.BetterStackMonitor)
+	if !ok {
+		return nil
+	}
+	id := monitor.Status.MonitorID
+	if id == "" {
+		id = monitor.Spec.ExistingMonitorID
+	}
+	if id == "" {
+		return nil
+	}
+	return []string{id}
+}
+
+// indexMonitorGroupID returns the remote monitor group ID a group manages or asks to adopt.
+func indexMonitorGroupID(obj client.Object) []string {
+	group, ok := obj.(*monitoringv1alpha1.BetterStackMonitorGroup)
+	if !ok {
+		return nil
+	}
+	id := group.Status.MonitorGroupID
+	if id == "" {
+		id = group.Spec.ExistingMonitorGroupID
+	}
+	if id == "" {
+		return nil
+	}
+	return []string{id}
+}
+
+// claimant is a resource that manages, or asks to manage, a remote object.
+type claimant struct {
+	key     types.NamespacedName
+	created metav1.Time
+	// synced is set when the resource's status already records the remote ID.
+	synced bool
+}
+
+// remoteHolder picks the resource that manages a remote object among claimants. It only
+// looks at state persisted in the cluster, so the holder is the same after a restart
+// whatever order resources reconcile in: a resource whose status already records the ID
+// wins over one that only names it in its spec, then the oldest resource wins, and the
+// namespace/name breaks ties.
+func remoteHolder(claimants []claimant) types.NamespacedName {
+	holder := claimants[0]
+	for _, c := range claimants[1:] {
+		switch {
+		case c.synced != holder.synced:
+			if c.synced {
+				holder = c
+			}
+		case !c.created.Equal(&holder.created):
+			if c.created.Before(&holder.created) {
+				holder = c
+			}
+		case c.key.String() < holder.key.String():
+			holder = c
+		}
+	}
+	return holder.key
+}
+
+// claimMonitorID reports whether monitor may manage the remote monitor id, and which
+// resource holds it otherwise. Resources in the holder's namespace may share an ID, which
+// is what spec.onConflict AdoptAnyway does; other namespaces are always refused.
+func (r *BetterStackMonitorReconciler) claimMonitorID(ctx context.Context, monitor *monitoringv1alpha1.BetterStackMonitor, id string) (types.NamespacedName, bool, error) {
+	key := client.ObjectKeyFromObject(monitor)
+	if id == "" {
+		return key, true, nil
+	}
+	var monitors monitoringv1alpha1.BetterStackMonitorList
+	if err := r.List(ctx, &monitors, client.MatchingFields{monitorIDIndexKey: id}); err != nil {
+		return key, false, fmt.Errorf("list monitors: %w", err)
+	}
+	claimants := []claimant{{key: key, created: monitor.CreationTimestamp, synced: monitor.Status.MonitorID == id}}
+	for i := range monitors.Items {
+		other := &monitors.Items[i]
+		if client.ObjectKeyFromObject(other) == key {
+			continue
+		}
+		if other.Status.MonitorID == id || (other.Status.MonitorID == "" && other.Spec.ExistingMonitorID == id) {
+			claimants = append(claimants, claimant{key: client.ObjectKeyFromObject(other), created: other.CreationTimestamp, synced: other.Status.MonitorID == id})
+		}
+	}
+	holder := remoteHolder(claimants)
+	return holder, holder.Namespace == key.Namespace, nil
+}
+
+// reportClaimed stops syncing monitor because another namespace manages id. The check is
+// repeated at the error interval so the monitor resumes once the other resource is gone.
+func (r *BetterStackMonitorReconciler) reportClaimed(ctx context.Context, monitor *monitoringv1alpha1.BetterStackMonitor, id string, holder types.NamespacedName) (ctrl.Result, error) {
+	message := claimedMessage("monitor", id, holder)
+	log.FromContext(ctx).Info("remote monitor claimed by another namespace", "id", id, "owner", holder.String())
+	err := r.patchStatus(ctx, monitor, func(status *monitoringv1alpha1.BetterStackMonitorStatus) {
+		now := metav1.Now()
+		status.SetCondition(conditions.New(monitoringv1alpha1.ConditionSync, metav1.ConditionFalse, monitoringv1alpha1.ReasonRemoteIDClaimed, message, &now))
+		status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionFalse, monitoringv1alpha1.ReasonRemoteIDClaimed, message, &now))
+	})
+	return ctrl.Result{RequeueAfter: requeueIntervalOnError}, err
+}
+
+// claimMonitorGroupID reports whether group may manage the remote monitor group id, which
+// resource holds it otherwise, and whether another resource in the group's namespace
+// records the same ID in its status.
+func (r *BetterStackMonitorGroupReconciler) claimMonitorGroupID(ctx context.Context, group *monitoringv1alpha1.BetterStackMonitorGroup, id string) (holder types.NamespacedName, ok, shared bool, err error) {
+	key := client.ObjectKeyFromObject(group)
+	if id == "" {
+		return key, true, false, nil
+	}
+	var groups monitoringv1alpha1.BetterStackMonitorGroupList
+	if err := r.List(ctx, &groups, client.MatchingFields{monitorGroupIDIndexKey: id}); err != nil {
+		return key, false, false, fmt.Errorf("list monitor groups: %w", err)
+	}
+	claimants := []claimant{{key: key, created: group.CreationTimestamp, synced: group.Status.MonitorGroupID == id}}
+	for i := range groups.Items {
+		other := &groups.Items[i]
+		if client.ObjectKeyFromObject(other) == key {
+			continue
+		}
+		if other.Status.MonitorGroupID == id || (other.Status.MonitorGroupID == "" && other.Spec.ExistingMonitorGroupID == id) {
+			claimants = append(claimants, claimant{key: client.ObjectKeyFromObject(other), created: other.CreationTimestamp, synced: other.Status.MonitorGroupID == id})
+			shared = shared || (other.Namespace == key.Namespace && other.Status.MonitorGroupID == id)
+		}
+	}
+	holder = remoteHolder(claimants)
+	return holder, holder.Namespace == key.Namespace, shared, nil
+}
+
+// claimedMessage explains why a resource stopped syncing a remote object another
+// namespace manages.
+func claimedMessage(kind, id string, holder types.NamespacedName) string {
+	return fmt.Sprintf("Remote %s %s is already managed by %s; remove the duplicate so the two resources do not overwrite each other", kind, id, holder)
+}
+
+// reportClaimed stops syncing group because another namespace manages id.
+func (r *BetterStackMonitorGroupReconciler) reportClaimed(ctx context.Context, group *monitoringv1alpha1.BetterStackMonitorGroup, id string, holder types.NamespacedName) (ctrl.Result, error) {
+	message := claimedMessage("monitor group", id, holder)
+	log.FromContext(ctx).Info("remote monitor group claimed by another namespace", "id", id, "owner", holder.String())
+	err := r.patchStatus(ctx, group, func(status *monitoringv1alpha1.BetterStackMonitorGroupStatus) {
+		now := metav1.Now()
+		status.SetCondition(conditions.New(monitoringv1alpha1.ConditionSync, metav1.ConditionFalse, monitoringv1alpha1.ReasonRemoteIDClaimed, message, &now))
+		status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionFalse, monitoringv1alpha1.ReasonRemoteIDClaimed, message, &now))
+	})
+	return ctrl.Result{RequeueAfter: requeueIntervalOnError}, err
+}
@@ -0,0 +1,17 @@
+package controllers
+
+import (
+	"strings"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+)
+
+// expandClusterName replaces $(CLUSTER_NAME) in name with cluster. Like variable
+// references in pod specs, the reference is left as written when no cluster name is
+// configured.
+func expandClusterName(name, cluster string) string {
+	if cluster == "" {
+		return name
+	}
+	return strings.ReplaceAll(name, monitoringv1alpha1.ClusterNameVariable, cluster)
+}
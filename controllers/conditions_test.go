@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/internal/controller/conditions"
+	"loks0n/betterstack-operator/internal/testutil/assert"
+	"loks0n/betterstack-operator/internal/testutil/controllertest"
+)
+
+type conditionSetter interface {
+	SetCondition(metav1.Condition)
+}
+
+func TestSetConditionTransitionTime(t *testing.T) {
+	first := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	later := metav1.NewTime(first.Add(time.Hour))
+
+	statuses := map[string]func() (conditionSetter, func() []metav1.Condition){
+		"monitor": func() (conditionSetter, func() []metav1.Condition) {
+			s := &monitoringv1alpha1.BetterStackMonitorStatus{}
+			return s, func() []metav1.Condition { return s.Conditions }
+		},
+		"heartbeat": func() (conditionSetter, func() []metav1.Condition) {
+			s := &monitoringv1alpha1.BetterStackHeartbeatStatus{}
+			return s, func() []metav1.Condition { return s.Conditions }
+		},
+		"monitor group": func() (conditionSetter, func() []metav1.Condition) {
+			s := &monitoringv1alpha1.BetterStackMonitorGroupStatus{}
+			return s, func() []metav1.Condition { return s.Conditions }
+		},
+	}
+
+	tests := []struct {
+		name     string
+		status   metav1.ConditionStatus
+		reason   string
+		message  string
+		wantTime metav1.Time
+	}{
+		{name: "unchanged", status: metav1.ConditionTrue, reason: "MonitorSynced", message: "Synced", wantTime: first},
+		{name: "message only", status: metav1.ConditionTrue, reason: "MonitorSynced", message: "Synced again", wantTime: first},
+		{name: "reason changed", status: metav1.ConditionTrue, reason: "Recovered", message: "Synced", wantTime: later},
+		{name: "status changed", status: metav1.ConditionFalse, reason: "MonitorSynced", message: "Failed", wantTime: later},
+	}
+
+	for kind, newStatus := range statuses {
+		for _, tt := range tests {
+			t.Run(kind+"/"+tt.name, func(t *testing.T) {
+				status, current := newStatus()
+				status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionTrue, monitoringv1alpha1.ReasonMonitorSynced, "Synced", &first))
+				status.SetCondition(conditions.New(monitoringv1alpha1.ConditionSync, metav1.ConditionTrue, monitoringv1alpha1.ReasonMonitorSynced, "Synced", &first))
+
+				status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, tt.status, tt.reason, tt.message, &later))
+
+				got := current()
+				assert.Int(t, "condition count", len(got), 2)
+				assert.String(t, "first condition type", got[0].Type, monitoringv1alpha1.ConditionReady)
+				ready := controllertest.FindCondition(got, monitoringv1alpha1.ConditionReady)
+				assert.NotNil(t, "ready condition", ready)
+				assert.Equal(t, "ready status", ready.Status, tt.status)
+				assert.String(t, "ready message", ready.Message, tt.message)
+				assert.Bool(t, "lastTransitionTime", ready.LastTransitionTime.Equal(&tt.wantTime), true)
+			})
+		}
+	}
+}
+
+func TestSetConditionPreservesForeignConditions(t *testing.T) {
+	now := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	foreign := metav1.Condition{Type: "example.com/Approved", Status: metav1.ConditionTrue, Reason: "ChangeApproved", Message: "Approved by change management", LastTransitionTime: now, ObservedGeneration: 3}
+
+	status := &monitoringv1alpha1.BetterStackMonitorStatus{Conditions: []metav1.Condition{foreign}}
+	status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionFalse, monitoringv1alpha1.ReasonSyncFailed, "Failed", &now))
+	status.SetCondition(conditions.New(monitoringv1alpha1.ConditionReady, metav1.ConditionTrue, monitoringv1alpha1.ReasonMonitorSynced, "Synced", &now))
+
+	assert.Int(t, "condition count", len(status.Conditions), 2)
+	assert.Equal(t, "foreign condition", status.Conditions[0], foreign)
+	assert.String(t, "operator condition", status.Conditions[1].Type, monitoringv1alpha1.ConditionReady)
+}
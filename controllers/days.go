@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// weekdays lists the maintenance days Better Stack accepts, in normalized form.
+var weekdays = []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}
+
+// weekdayNames maps full day names onto the abbreviations in weekdays.
+var weekdayNames = map[string]string{
+	"monday":    "mon",
+	"tuesday":   "tue",
+	"wednesday": "wed",
+	"thursday":  "thu",
+	"friday":    "fri",
+	"saturday":  "sat",
+	"sunday":    "sun",
+}
+
+// normalizeWeekday returns day as the lowercase three-letter abbreviation Better Stack
+// accepts, so Monday, MON and mon are the same day. Unknown values are only lowercased.
+func normalizeWeekday(day string) string {
+	day = strings.ToLower(strings.TrimSpace(day))
+	if abbreviation, ok := weekdayNames[day]; ok {
+		return abbreviation
+	}
+	return day
+}
+
+// normalizeWeekdays normalizes days, dropping repeats such as mon and Monday.
+func normalizeWeekdays(days []string) []string {
+	normalized := make([]string, 0, len(days))
+	for _, day := range days {
+		if day = normalizeWeekday(day); !slices.Contains(normalized, day) {
+			normalized = append(normalized, day)
+		}
+	}
+	return normalized
+}
+
+// weekdayErrors rejects maintenance days that are neither an abbreviation nor a full day
+// name, in any case. It accepts exactly what the CRD pattern
+// ^(?i:mon(day)?|tue(sday)?|...)$ accepts, so surrounding spaces are rejected too.
+func weekdayErrors(path *field.Path, days []string) field.ErrorList {
+	var errs field.ErrorList
+	for i, day := range days {
+		if day != strings.TrimSpace(day) || !slices.Contains(weekdays, normalizeWeekday(day)) {
+			errs = append(errs, field.NotSupported(path.Index(i), day, weekdays))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/internal/controller/loglevel"
+	"loks0n/betterstack-operator/pkg/betterstack"
+)
+
+type reconcileTraceKey struct{}
+
+// reconcileTrace collects the steps of one reconcile of a resource annotated with
+// betterstack.io/debug until the next status patch writes them to status.debugTrace.
+type reconcileTrace struct {
+	mu      sync.Mutex
+	pending []monitoringv1alpha1.ReconcileStep
+}
+
+// withReconcileTrace enables debugging for obj when it carries the debug annotation: the
+// returned context logs at every verbosity and records Better Stack requests into a
+// trace. Otherwise ctx is returned unchanged.
+func withReconcileTrace(ctx context.Context, obj client.Object) context.Context {
+	if obj.GetAnnotations()[monitoringv1alpha1.DebugAnnotation] != "true" {
+		return ctx
+	}
+	logger := log.FromContext(ctx).WithValues(loglevel.DebugKey, true)
+	ctx = log.IntoContext(ctx, logger)
+	return context.WithValue(ctx, reconcileTraceKey{}, &reconcileTrace{})
+}
+
+func traceFromContext(ctx context.Context) *reconcileTrace {
+	trace, _ := ctx.Value(reconcileTraceKey{}).(*reconcileTrace)
+	return trace
+}
+
+// recordAPIRequest is the betterstack.WithRequestObserver callback used by the default
+// client factories.
+func recordAPIRequest(ctx context.Context, result betterstack.RequestResult) {
+	trace := traceFromContext(ctx)
+	if trace == nil {
+		return
+	}
+	outcome := fmt.Sprintf("%d", result.StatusCode)
+	if result.Err != nil {
+		outcome = result.Err.Error()
+	}
+	trace.add(monitoringv1alpha1.DebugStepAPI, fmt.Sprintf("%s %s: %s in %s", result.Method, result.Path, outcome, result.Duration.Round(time.Millisecond)))
+}
+
+func (t *reconcileTrace) add(kind, message string) {
+	if runes := []rune(message); len(runes) > monitoringv1alpha1.MaxDebugTraceMessageLength {
+		message = string(runes[:monitoringv1alpha1.MaxDebugTraceMessageLength-3]) + "..."
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pending = append(t.pending, monitoringv1alpha1.ReconcileStep{Time: metav1.NowMicro(), Kind: kind, Message: message})
+}
+
+// debugTrace returns the status.debugTrace to write alongside conditions: the existing
+// steps followed by those recorded since the last patch and the Ready condition being
+// written, trimmed to the newest MaxDebugTraceSteps. Without a trace in ctx the field is
+// cleared, so removing the annotation also removes the trace.
+func debugTrace(ctx context.Context, existing []monitoringv1alpha1.ReconcileStep, conditions []metav1.Condition) []monitoringv1alpha1.ReconcileStep {
+	trace := traceFromContext(ctx)
+	if trace == nil {
+		return nil
+	}
+	if ready := meta.FindStatusCondition(conditions, monitoringv1alpha1.ConditionReady); ready != nil {
+		trace.add(monitoringv1alpha1.DebugStepStatus, fmt.Sprintf("Ready=%s %s: %s", ready.Status, ready.Reason, ready.Message))
+	}
+
+	trace.mu.Lock()
+	defer trace.mu.Unlock()
+	steps := append(append([]monitoringv1alpha1.ReconcileStep(nil), existing...), trace.pending...)
+	trace.pending = nil
+	if len(steps) > monitoringv1alpha1.MaxDebugTraceSteps {
+		steps = steps[len(steps)-monitoringv1alpha1.MaxDebugTraceSteps:]
+	}
+	return steps
+}
@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/internal/controller/conditions"
+	"loks0n/betterstack-operator/internal/controller/metrics"
+)
+
+// deprecatedField is a spec field scheduled for removal and the field replacing it.
+type deprecatedField struct {
+	field       string
+	replacement string
+}
+
+// deprecatedMonitorFields lists the deprecated fields the monitor spec sets.
+func deprecatedMonitorFields(spec monitoringv1alpha1.BetterStackMonitorSpec) []deprecatedField {
+	var fields []deprecatedField
+	if spec.ExpectedStatusCode != 0 {
+		fields = append(fields, deprecatedField{field: "expectedStatusCode", replacement: "expectedStatusCodes"})
+	}
+	return fields
+}
+
+// recordDeprecatedFields counts each deprecated field in use.
+func recordDeprecatedFields(fields []deprecatedField) {
+	for _, f := range fields {
+		metrics.DeprecatedFieldUsage.WithLabelValues(f.field).Inc()
+	}
+}
+
+// deprecatedFieldsCondition returns the DeprecatedFieldsUsed condition for fields and
+// whether to set it. Resources that never used a deprecated field are left without the
+// condition rather than gaining a False one.
+func deprecatedFieldsCondition(existing []metav1.Condition, fields []deprecatedField, now *metav1.Time) (metav1.Condition, bool) {
+	if len(fields) == 0 {
+		if meta.FindStatusCondition(existing, monitoringv1alpha1.ConditionDeprecatedFields) == nil {
+			return metav1.Condition{}, false
+		}
+		return conditions.New(monitoringv1alpha1.ConditionDeprecatedFields, metav1.ConditionFalse, monitoringv1alpha1.ReasonNoDeprecatedFields, "Spec uses no deprecated fields", now), true
+	}
+	parts := make([]string, 0, len(fields))
+	for _, f := range fields {
+		parts = append(parts, fmt.Sprintf("spec.%s is deprecated, use spec.%s", f.field, f.replacement))
+	}
+	return conditions.New(monitoringv1alpha1.ConditionDeprecatedFields, metav1.ConditionTrue, monitoringv1alpha1.ReasonDeprecatedField, strings.Join(parts, "; "), now), true
+}
@@ -0,0 +1,40 @@
+package controllers
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+)
+
+// periodTransition returns the transition to apply while syncing heartbeat, or nil when
+// spec.graceSeconds applies as-is. A shorter period under GraceThenApply is sent right
+// away with a grace widened by the difference, so Better Stack's deadline does not move
+// earlier. Clients still pinging at the old cadence have one old period plus grace to catch
+// up before the widened grace is dropped.
+func periodTransition(heartbeat *monitoringv1alpha1.BetterStackHeartbeat, now time.Time) *monitoringv1alpha1.HeartbeatPeriodTransition {
+	spec := heartbeat.Spec
+	if spec.PeriodChangePolicy != monitoringv1alpha1.PeriodChangeGraceThenApply {
+		return nil
+	}
+
+	from := heartbeat.Status.AppliedPeriodSeconds
+	if current := heartbeat.Status.PeriodTransition; current != nil && now.Before(current.Until.Time) {
+		if current.ToPeriodSeconds == spec.PeriodSeconds && current.GraceSeconds == spec.GraceSeconds+current.FromPeriodSeconds-spec.PeriodSeconds {
+			return current.DeepCopy()
+		}
+		// Shortened again mid-transition: clients may still be on the oldest cadence.
+		from = max(from, current.FromPeriodSeconds)
+	}
+	if from <= spec.PeriodSeconds {
+		return nil
+	}
+
+	return &monitoringv1alpha1.HeartbeatPeriodTransition{
+		FromPeriodSeconds: from,
+		ToPeriodSeconds:   spec.PeriodSeconds,
+		GraceSeconds:      spec.GraceSeconds + from - spec.PeriodSeconds,
+		Until:             metav1.NewTime(now.Add(time.Duration(from+spec.GraceSeconds) * time.Second)),
+	}
+}
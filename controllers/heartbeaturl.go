@@ -0,0 +1,113 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+)
+
+// urlAnnotationTarget describes a kind accepted by spec.urlAnnotation.
+type urlAnnotationTarget struct {
+	apiVersion string
+	// podTemplate is the field path of the kind's pod template.
+	podTemplate []string
+}
+
+// urlAnnotationTargets lists the kinds spec.urlAnnotation may select. The operator's RBAC
+// grants patch on exactly these.
+var urlAnnotationTargets = map[string]urlAnnotationTarget{
+	"CronJob":     {apiVersion: "batch/v1", podTemplate: []string{"spec", "jobTemplate", "spec", "template"}},
+	"Deployment":  {apiVersion: "apps/v1", podTemplate: []string{"spec", "template"}},
+	"StatefulSet": {apiVersion: "apps/v1", podTemplate: []string{"spec", "template"}},
+	"DaemonSet":   {apiVersion: "apps/v1", podTemplate: []string{"spec", "template"}},
+}
+
+// urlAnnotationKey matches the annotation keys spec.urlAnnotation.key may use. It mirrors
+// the CRD pattern so keys outside the prefix are refused even where the CRD is older.
+var urlAnnotationKey = regexp.MustCompile(`^betterstack\.io/[A-Za-z0-9]([-A-Za-z0-9_.]{0,61}[A-Za-z0-9])?$`)
+
+// resolvedURLAnnotation returns target with its key defaulted, or nil when target is nil.
+func resolvedURLAnnotation(target *monitoringv1alpha1.HeartbeatURLAnnotation) *monitoringv1alpha1.HeartbeatURLAnnotation {
+	if target == nil {
+		return nil
+	}
+	resolved := *target
+	if resolved.Key == "" {
+		resolved.Key = monitoringv1alpha1.DefaultHeartbeatURLAnnotation
+	}
+	return &resolved
+}
+
+// syncHeartbeatURLAnnotation writes url to desired after removing the annotation from
+// previous, the target recorded in status, when the two differ. It returns the target the
+// annotation is now on, to be recorded in status.
+func syncHeartbeatURLAnnotation(ctx context.Context, c client.Client, namespace string, previous, desired *monitoringv1alpha1.HeartbeatURLAnnotation, url string) (*monitoringv1alpha1.HeartbeatURLAnnotation, error) {
+	desired = resolvedURLAnnotation(desired)
+	applied := previous
+	if previous != nil && (desired == nil || *previous != *desired) {
+		if err := annotateHeartbeatURL(ctx, c, namespace, previous, ""); err != nil && !apierrors.IsNotFound(err) {
+			return applied, fmt.Errorf("remove previous annotation: %w", err)
+		}
+		applied = nil
+	}
+	if desired == nil {
+		return nil, nil
+	}
+	if err := annotateHeartbeatURL(ctx, c, namespace, desired, url); err != nil {
+		return applied, err
+	}
+	return desired, nil
+}
+
+// annotateHeartbeatURL writes url into the annotation selected by target, or removes the
+// annotation when url is empty. It uses a merge patch so it neither needs the target in
+// the cache nor conflicts with the workload's own controller.
+func annotateHeartbeatURL(ctx context.Context, c client.Client, namespace string, target *monitoringv1alpha1.HeartbeatURLAnnotation, url string) error {
+	kind, ok := urlAnnotationTargets[target.Kind]
+	if !ok {
+		return fmt.Errorf("urlAnnotation kind %q is not supported", target.Kind)
+	}
+	key := resolvedURLAnnotation(target).Key
+	if !urlAnnotationKey.MatchString(key) {
+		return fmt.Errorf("urlAnnotation key %q must be a betterstack.io/ annotation", key)
+	}
+
+	// A null value removes the key.
+	var value any
+	if url != "" {
+		value = url
+	}
+	metadata := map[string]any{"annotations": map[string]any{key: value}}
+	patch := map[string]any{"metadata": metadata}
+	if target.PodTemplate {
+		node := patch
+		for _, field := range kind.podTemplate {
+			child := map[string]any{}
+			node[field] = child
+			node = child
+		}
+		node["metadata"] = metadata
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(kind.apiVersion)
+	obj.SetKind(target.Kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(target.Name)
+	if err := c.Patch(ctx, obj, client.RawPatch(types.MergePatchType, data)); err != nil {
+		return fmt.Errorf("annotate %s %s: %w", target.Kind, target.Name, err)
+	}
+	return nil
+}
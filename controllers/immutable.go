@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/internal/controller/conditions"
+	"loks0n/betterstack-operator/pkg/betterstack"
+)
+
+// immutableFieldChange is a spec field whose value Better Stack kept on update.
+type immutableFieldChange struct {
+	field  string
+	want   string
+	remote string
+}
+
+// immutableMonitorGroupChanges compares the spec with the group Better Stack returned
+// from an update and lists the fields the update did not apply. Better Stack accepts a
+// new team_name on existing groups but keeps the group in its original team.
+func immutableMonitorGroupChanges(spec monitoringv1alpha1.BetterStackMonitorGroupSpec, remote betterstack.MonitorGroupAttributes) []immutableFieldChange {
+	var changes []immutableFieldChange
+	if spec.TeamName != "" && remote.TeamName != "" && !strings.EqualFold(spec.TeamName, remote.TeamName) {
+		changes = append(changes, immutableFieldChange{field: "teamName", want: spec.TeamName, remote: remote.TeamName})
+	}
+	return changes
+}
+
+func immutableFieldsMessage(changes []immutableFieldChange) string {
+	parts := make([]string, 0, len(changes))
+	for _, c := range changes {
+		parts = append(parts, fmt.Sprintf("spec.%s is %q but Better Stack keeps %q", c.field, c.want, c.remote))
+	}
+	return strings.Join(parts, "; ") + "; set spec.allowRecreate to recreate the remote resource"
+}
+
+// immutableFieldCondition returns the ImmutableFieldChanged condition and whether to set
+// it. Resources that never changed an immutable field are left without the condition.
+func immutableFieldCondition(existing []metav1.Condition, changes []immutableFieldChange, recreated []immutableFieldChange, now *metav1.Time) (metav1.Condition, bool) {
+	switch {
+	case len(changes) > 0:
+		return conditions.New(monitoringv1alpha1.ConditionImmutableFieldChanged, metav1.ConditionTrue, monitoringv1alpha1.ReasonImmutableFieldChanged, immutableFieldsMessage(changes), now), true
+	case len(recreated) > 0:
+		fields := make([]string, 0, len(recreated))
+		for _, c := range recreated {
+			fields = append(fields, "spec."+c.field)
+		}
+		return conditions.New(monitoringv1alpha1.ConditionImmutableFieldChanged, metav1.ConditionFalse, monitoringv1alpha1.ReasonRemoteRecreated, fmt.Sprintf("Remote resource recreated to apply %s", strings.Join(fields, ", ")), now), true
+	case meta.FindStatusCondition(existing, monitoringv1alpha1.ConditionImmutableFieldChanged) != nil:
+		return conditions.New(monitoringv1alpha1.ConditionImmutableFieldChanged, metav1.ConditionFalse, monitoringv1alpha1.ReasonImmutableFieldsInSync, "Remote resource matches the spec", now), true
+	default:
+		return metav1.Condition{}, false
+	}
+}
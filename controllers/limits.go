@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/pkg/betterstack"
+)
+
+// monitorRequestAttributes holds the API attribute names the operator sets from structured
+// spec fields.
+var monitorRequestAttributes = requestAttributes(reflect.TypeFor[betterstack.MonitorRequest]())
+
+// validateMonitorSpec repeats checks of the CRD schema with messages that say why they
+// exist. The API server reports schema violations first, so these messages matter for
+// clusters running the CRDs of an older release.
+func validateMonitorSpec(monitor *monitoringv1alpha1.BetterStackMonitor) error {
+	spec := field.NewPath("spec")
+	var errs field.ErrorList
+	errs = append(errs, requestMethodErrors(spec.Child("requestMethod"), monitor.Spec.RequestMethod)...)
+	errs = append(errs, weekdayErrors(spec.Child("maintenanceDays"), monitor.Spec.MaintenanceDays)...)
+	errs = append(errs, mapLimitErrors(spec.Child("additionalAttributes"), monitor.Spec.AdditionalAttributes, monitoringv1alpha1.MaxAdditionalAttributes)...)
+	errs = append(errs, attributeCollisionErrors(spec.Child("additionalAttributes"), monitor.Spec.AdditionalAttributes, monitorRequestAttributes)...)
+	errs = append(errs, mapLimitErrors(spec.Child("environmentVariables"), monitor.Spec.EnvironmentVariables, monitoringv1alpha1.MaxEnvironmentVariables)...)
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(monitoringv1alpha1.GroupVersion.WithKind("BetterStackMonitor").GroupKind(), monitor.Name, errs)
+}
+
+// validateHeartbeatSpec is validateMonitorSpec for heartbeats.
+func validateHeartbeatSpec(heartbeat *monitoringv1alpha1.BetterStackHeartbeat) error {
+	errs := weekdayErrors(field.NewPath("spec", "maintenanceDays"), heartbeat.Spec.MaintenanceDays)
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(monitoringv1alpha1.GroupVersion.WithKind("BetterStackHeartbeat").GroupKind(), heartbeat.Name, errs)
+}
+
+func mapLimitErrors(path *field.Path, values map[string]string, maxEntries int) field.ErrorList {
+	var errs field.ErrorList
+	if len(values) > maxEntries {
+		errs = append(errs, &field.Error{
+			Type:     field.ErrorTypeTooMany,
+			Field:    path.String(),
+			BadValue: len(values),
+			Detail:   fmt.Sprintf("has %d entries; at most %d are allowed so the monitor stays well below etcd's object size limit and Better Stack's request size limit", len(values), maxEntries),
+		})
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		if n := len(values[key]); n > monitoringv1alpha1.MaxMapValueLength {
+			errs = append(errs, &field.Error{
+				Type:     field.ErrorTypeTooLong,
+				Field:    path.Key(key).String(),
+				BadValue: field.OmitValueType{},
+				Detail:   fmt.Sprintf("is %d characters; values are limited to %d so a single entry cannot produce an oversized Better Stack request", n, monitoringv1alpha1.MaxMapValueLength),
+			})
+		}
+	}
+	return errs
+}
+
+// attributeCollisionErrors rejects additionalAttributes keys that name an attribute the
+// operator already sets, since the merged value would silently replace the structured one.
+func attributeCollisionErrors(path *field.Path, values map[string]string, managed []string) field.ErrorList {
+	var errs field.ErrorList
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		if slices.Contains(managed, key) {
+			errs = append(errs, field.Forbidden(path.Key(key), fmt.Sprintf("%s is set from the structured spec fields; setting it here would silently override them", key)))
+		}
+	}
+	return errs
+}
+
+// requestAttributes lists the JSON attribute names of a request struct.
+func requestAttributes(t reflect.Type) []string {
+	var names []string
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"loks0n/betterstack-operator/internal/controller/maintenance"
+)
+
+// MaintenanceReconciler keeps the maintenance switch in step with the maintenance
+// ConfigMap. It runs on every replica, not only the leader, so a replica that takes over
+// leadership already knows whether the cluster is under maintenance.
+type MaintenanceReconciler struct {
+	client.Client
+	Switch *maintenance.Switch
+
+	// ConfigMap names the maintenance ConfigMap. Deleting it turns maintenance mode off.
+	ConfigMap types.NamespacedName
+}
+
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+
+func (r *MaintenanceReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
+	return ctrl.Result{}, r.Load(ctx, r.Client)
+}
+
+// Load reads the maintenance ConfigMap through reader and applies it to the switch. The
+// manager calls it once before starting, with an uncached reader, so monitors are not
+// unpaused by a reconcile that runs before the ConfigMap is first seen.
+func (r *MaintenanceReconciler) Load(ctx context.Context, reader client.Reader) error {
+	var configMap corev1.ConfigMap
+	if err := reader.Get(ctx, r.ConfigMap, &configMap); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err := r.Switch.Apply(configMap.Data); err != nil {
+		// Retrying cannot fix the selector; wait for the ConfigMap to be edited.
+		log.FromContext(ctx).Error(err, "ignoring maintenance ConfigMap", "configMap", r.ConfigMap.String())
+	}
+	return nil
+}
+
+// SetupWithManager watches only the maintenance ConfigMap.
+func (r *MaintenanceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("maintenance").
+		For(&corev1.ConfigMap{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return client.ObjectKeyFromObject(obj) == r.ConfigMap
+		}))).
+		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
+		Complete(r)
+}
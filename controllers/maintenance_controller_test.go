@@ -0,0 +1,45 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"loks0n/betterstack-operator/internal/controller/maintenance"
+	"loks0n/betterstack-operator/internal/testutil/assert"
+	"loks0n/betterstack-operator/internal/testutil/controllertest"
+)
+
+func TestMaintenanceReconcilerFollowsConfigMap(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Namespace: "betterstack-system", Name: "maintenance"}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+		Data: map[string]string{
+			maintenance.ActiveKey:        "true",
+			maintenance.LabelSelectorKey: "tier=edge",
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(controllertest.NewScheme(t)).WithObjects(configMap).Build()
+	r := &MaintenanceReconciler{Client: c, Switch: maintenance.NewSwitch(), ConfigMap: key}
+
+	assert.NoError(t, r.Load(ctx, c), "load")
+	assert.Bool(t, "edge covered", r.Switch.Applies(map[string]string{"tier": "edge"}), true)
+	assert.Bool(t, "core covered", r.Switch.Applies(map[string]string{"tier": "core"}), false)
+
+	configMap.Data[maintenance.LabelSelectorKey] = "tier in ("
+	assert.NoError(t, c.Update(ctx, configMap), "update configmap")
+	_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile")
+	assert.Bool(t, "invalid selector keeps previous state", r.Switch.Applies(map[string]string{"tier": "edge"}), true)
+
+	assert.NoError(t, c.Delete(ctx, configMap), "delete configmap")
+	_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile")
+	assert.Bool(t, "active after delete", r.Switch.Active(), false)
+}
@@ -0,0 +1,40 @@
+package controllers
+
+import (
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// httpMethods lists the request methods Better Stack accepts, in normalized form.
+var httpMethods = []string{"get", "post", "put", "patch", "delete", "head", "options", "trace"}
+
+// caseInsensitiveFields lists the request attributes compared without regard to case.
+var caseInsensitiveFields = map[string]bool{
+	"http_method": true,
+}
+
+// normalizeHTTPMethod returns method in the lowercase form Better Stack accepts and
+// reports back, so GET and get are the same method everywhere in the operator.
+func normalizeHTTPMethod(method string) string {
+	return strings.ToLower(strings.TrimSpace(method))
+}
+
+// requestMethodErrors rejects a request method Better Stack does not support, in any case.
+func requestMethodErrors(path *field.Path, method string) field.ErrorList {
+	if method == "" || slices.Contains(httpMethods, normalizeHTTPMethod(method)) {
+		return nil
+	}
+	return field.ErrorList{field.NotSupported(path, method, httpMethods)}
+}
+
+// sameMethod reports whether two http_method values name the same method.
+func sameMethod(have, want any) bool {
+	haveMethod, ok := have.(string)
+	if !ok {
+		return false
+	}
+	wantMethod, ok := want.(string)
+	return ok && normalizeHTTPMethod(haveMethod) == normalizeHTTPMethod(wantMethod)
+}
@@ -0,0 +1,36 @@
+package controllers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+
+	"loks0n/betterstack-operator/pkg/betterstack"
+)
+
+// suffixedHeartbeatName is the name tried when name, the expanded spec.name, is taken and
+// spec.nameConflictStrategy is Suffix. The suffix hashes the namespace, so the same
+// resource always derives the same name and heartbeats from different namespaces differ.
+func suffixedHeartbeatName(namespace, name string) string {
+	sum := sha256.Sum256([]byte(namespace))
+	return name + "-" + hex.EncodeToString(sum[:])[:6]
+}
+
+// isHeartbeatNameConflict reports whether Better Stack refused a heartbeat because its
+// name is already in use.
+func isHeartbeatNameConflict(err error) bool {
+	var apiErr *betterstack.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusConflict:
+		return true
+	case http.StatusUnprocessableEntity:
+		message := strings.ToLower(apiErr.Message)
+		return strings.Contains(message, "name") && (strings.Contains(message, "taken") || strings.Contains(message, "exist"))
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+)
+
+// maintenanceWindowLayouts are the accepted spec.oneTimeMaintenance time formats.
+var maintenanceWindowLayouts = []string{"2006-01-02T15:04:05", "2006-01-02T15:04"}
+
+// timedPause reports whether spec.pausedUntil still covers now and, if so, how long
+// remains until the pause expires.
+func timedPause(until *metav1.Time, now time.Time) (time.Duration, bool) {
+	if until == nil || !now.Before(until.Time) {
+		return 0, false
+	}
+	return until.Sub(now), true
+}
+
+// requeueBefore shortens result so the object is reconciled again no later than after.
+func requeueBefore(result ctrl.Result, after time.Duration) ctrl.Result {
+	if result.RequeueAfter == 0 || after < result.RequeueAfter {
+		result.RequeueAfter = after
+	}
+	return result
+}
+
+// maintenanceWindow reports whether the one-time window covers now and how long until its
+// next boundary, the start or the end. The wait is zero once the window has passed.
+func maintenanceWindow(window *monitoringv1alpha1.BetterStackMaintenanceWindow, now time.Time) (bool, time.Duration, error) {
+	if window == nil {
+		return false, 0, nil
+	}
+	location := time.UTC
+	if window.Timezone != "" {
+		var err error
+		if location, err = time.LoadLocation(window.Timezone); err != nil {
+			return false, 0, fmt.Errorf("oneTimeMaintenance.timezone: %w", err)
+		}
+	}
+	from, err := parseWindowTime(window.From, location)
+	if err != nil {
+		return false, 0, fmt.Errorf("oneTimeMaintenance.from: %w", err)
+	}
+	to, err := parseWindowTime(window.To, location)
+	if err != nil {
+		return false, 0, fmt.Errorf("oneTimeMaintenance.to: %w", err)
+	}
+	if !to.After(from) {
+		return false, 0, fmt.Errorf("oneTimeMaintenance.to %s must be after from %s", window.To, window.From)
+	}
+
+	switch {
+	case now.Before(from):
+		return false, from.Sub(now), nil
+	case now.Before(to):
+		return true, to.Sub(now), nil
+	default:
+		return false, 0, nil
+	}
+}
+
+func parseWindowTime(value string, location *time.Location) (time.Time, error) {
+	var err error
+	for _, layout := range maintenanceWindowLayouts {
+		var t time.Time
+		if t, err = time.ParseInLocation(layout, value, location); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
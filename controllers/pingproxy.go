@@ -0,0 +1,249 @@
+package controllers
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/pkg/betterstack"
+)
+
+// maxPingBody caps the request body forwarded with a ping; Better Stack stores only a
+// short excerpt of it.
+const maxPingBody = 64 << 10
+
+// pingMissingTTL is how long a heartbeat Better Stack reported missing is refused from
+// the cache before the API is asked again, so unauthenticated pings cannot drive API calls.
+const pingMissingTTL = time.Minute
+
+// errPingDenied is returned for every ping the proxy refuses after the token is read, so
+// callers cannot tell a wrong token from a heartbeat that does not exist.
+var errPingDenied = errors.New("heartbeat token does not match")
+
+// defaultPingClient forwards pings when PingProxy.HTTPClient is unset. It is shared so
+// connections to Better Stack are reused across pings.
+var defaultPingClient = &http.Client{Timeout: 10 * time.Second}
+
+// PingProxy forwards pings sent to /ping/{namespace}/{name} to the Better Stack URL of the
+// matching BetterStackHeartbeat. Workloads can then ping a stable in-cluster address and
+// only the operator needs egress to Better Stack. A trailing /fail or /{exitCode} is passed
+// through as Better Stack defines it.
+//
+// Callers authenticate with the token of the heartbeat's Better Stack URL, its last path
+// segment, sent as "Authorization: Bearer <token>". Only workloads that were handed the
+// heartbeat URL, e.g. through spec.urlAnnotation, can therefore ping it. Unknown
+// heartbeats, heartbeats not created yet and wrong tokens all get the same 403.
+type PingProxy struct {
+	// Heartbeats supplies the Kubernetes client and Better Stack API clients used to look
+	// up each heartbeat's URL.
+	Heartbeats *BetterStackHeartbeatReconciler
+	// Addr is the address the proxy listens on, e.g. ":8082".
+	Addr string
+	// HTTPClient forwards pings. Defaults to a shared client with a 10 second timeout.
+	HTTPClient *http.Client
+
+	mu   sync.Mutex
+	urls map[types.NamespacedName]pingTarget
+}
+
+// pingTarget caches the ping URL of the remote heartbeat a resource last pointed at. An
+// empty url records that Better Stack reported the heartbeat missing until expires.
+type pingTarget struct {
+	heartbeatID string
+	url         string
+	expires     time.Time
+}
+
+// Handler returns the proxy's HTTP handler.
+func (p *PingProxy) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ping/{namespace}/{name}", p.servePing)
+	mux.HandleFunc("/ping/{namespace}/{name}/{suffix}", p.servePing)
+	return mux
+}
+
+// Start implements manager.Runnable.
+func (p *PingProxy) Start(ctx context.Context) error {
+	server := &http.Server{
+		Addr:              p.Addr,
+		Handler:           p.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("ping proxy: %w", err)
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable; every replica serves pings.
+func (p *PingProxy) NeedLeaderElection() bool {
+	return false
+}
+
+func (p *PingProxy) servePing(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead && req.Method != http.MethodPost {
+		w.Header().Set("Allow", "GET, HEAD, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	suffix := req.PathValue("suffix")
+	if suffix != "" && suffix != "fail" {
+		if _, err := strconv.Atoi(suffix); err != nil {
+			http.Error(w, "ping suffix must be fail or an exit code", http.StatusNotFound)
+			return
+		}
+	}
+
+	token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "missing heartbeat token", http.StatusUnauthorized)
+		return
+	}
+
+	ctx := req.Context()
+	key := types.NamespacedName{Namespace: req.PathValue("namespace"), Name: req.PathValue("name")}
+	logger := log.FromContext(ctx).WithValues("heartbeat", key.String())
+
+	target, err := p.pingURL(ctx, key)
+	if err == nil && !pingTokenMatches(target, token) {
+		err = errPingDenied
+	}
+	if errors.Is(err, errPingDenied) {
+		http.Error(w, errPingDenied.Error(), http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		logger.Error(err, "unable to resolve heartbeat ping URL")
+		http.Error(w, "unable to resolve heartbeat", http.StatusBadGateway)
+		return
+	}
+	if suffix != "" {
+		target = strings.TrimSuffix(target, "/") + "/" + suffix
+	}
+
+	forward, err := http.NewRequestWithContext(ctx, req.Method, target, io.LimitReader(req.Body, maxPingBody))
+	if err != nil {
+		logger.Error(err, "unable to build ping request")
+		http.Error(w, "unable to forward ping", http.StatusBadGateway)
+		return
+	}
+	if contentType := req.Header.Get("Content-Type"); contentType != "" {
+		forward.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := p.httpClient().Do(forward)
+	if err != nil {
+		logger.Error(err, "unable to forward ping")
+		http.Error(w, "unable to forward ping", http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		// The remote heartbeat was recreated or deleted; look its URL up again next time.
+		p.forget(key)
+	}
+	w.WriteHeader(resp.StatusCode)
+	_, _ = io.Copy(w, io.LimitReader(resp.Body, maxPingBody))
+}
+
+// pingURL returns the Better Stack URL for the heartbeat, asking the API only when the
+// resource points at a heartbeat that has not been looked up yet. Heartbeats that do not
+// exist, locally or in Better Stack, return errPingDenied.
+func (p *PingProxy) pingURL(ctx context.Context, key types.NamespacedName) (string, error) {
+	heartbeat := &monitoringv1alpha1.BetterStackHeartbeat{}
+	if err := p.Heartbeats.Get(ctx, key, heartbeat); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", errPingDenied
+		}
+		return "", err
+	}
+	id := heartbeat.Status.HeartbeatID
+	if id == "" {
+		return "", errPingDenied
+	}
+
+	p.mu.Lock()
+	cached, ok := p.urls[key]
+	p.mu.Unlock()
+	if ok && cached.heartbeatID == id {
+		if cached.url != "" {
+			return cached.url, nil
+		}
+		if time.Now().Before(cached.expires) {
+			return "", errPingDenied
+		}
+	}
+
+	account, err := resolveAccount(ctx, p.Heartbeats.Client, p.Heartbeats.References, p.Heartbeats.DefaultBaseURL, heartbeat.Namespace, heartbeat.Spec.AccountRef, heartbeat.Spec.APITokenSecretRef, heartbeat.Spec.BaseURL)
+	if err != nil {
+		return "", err
+	}
+	remote, err := p.Heartbeats.heartbeatService(account).Get(ctx, id)
+	if err != nil {
+		if betterstack.IsNotFound(err) {
+			p.remember(key, pingTarget{heartbeatID: id, expires: time.Now().Add(pingMissingTTL)})
+			return "", errPingDenied
+		}
+		return "", err
+	}
+	if remote.Attributes.URL == "" {
+		return "", fmt.Errorf("heartbeat %s has no ping URL", id)
+	}
+
+	p.remember(key, pingTarget{heartbeatID: id, url: remote.Attributes.URL})
+	return remote.Attributes.URL, nil
+}
+
+// pingTokenMatches reports whether token is the last path segment of the heartbeat's
+// Better Stack ping URL.
+func pingTokenMatches(pingURL, token string) bool {
+	want := strings.TrimSuffix(pingURL, "/")
+	want = want[strings.LastIndex(want, "/")+1:]
+	return want != "" && subtle.ConstantTimeCompare([]byte(want), []byte(token)) == 1
+}
+
+func (p *PingProxy) remember(key types.NamespacedName, target pingTarget) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.urls == nil {
+		p.urls = map[types.NamespacedName]pingTarget{}
+	}
+	p.urls[key] = target
+}
+
+func (p *PingProxy) forget(key types.NamespacedName) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.urls, key)
+}
+
+func (p *PingProxy) httpClient() *http.Client {
+	if p.HTTPClient != nil {
+		return p.HTTPClient
+	}
+	return defaultPingClient
+}
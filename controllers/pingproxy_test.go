@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/internal/testutil/assert"
+	"loks0n/betterstack-operator/internal/testutil/controllertest"
+	"loks0n/betterstack-operator/pkg/betterstack"
+	"loks0n/betterstack-operator/pkg/betterstack/betterstackfakes"
+)
+
+func TestPingProxyForwardsToHeartbeatURL(t *testing.T) {
+	var pinged []string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		pinged = append(pinged, req.Method+" "+req.URL.Path+" "+string(body))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer upstream.Close()
+
+	service := &betterstackfakes.HeartbeatClient{
+		GetFn: func(ctx context.Context, id string) (betterstack.Heartbeat, error) {
+			assert.String(t, "heartbeat id", id, "hb-1")
+			return betterstack.Heartbeat{ID: id, Attributes: betterstack.HeartbeatAttributes{URL: upstream.URL + "/api/v1/heartbeat/secret"}}, nil
+		},
+	}
+	proxy := newPingProxy(t, service, newPingHeartbeat("nightly", "hb-1"))
+
+	recorder := httptest.NewRecorder()
+	proxy.Handler().ServeHTTP(recorder, newPingRequest(http.MethodGet, "/ping/default/nightly", nil, "secret"))
+	assert.Int(t, "status", recorder.Code, http.StatusOK)
+
+	recorder = httptest.NewRecorder()
+	proxy.Handler().ServeHTTP(recorder, newPingRequest(http.MethodPost, "/ping/default/nightly/fail", strings.NewReader("disk full"), "secret"))
+	assert.Int(t, "status", recorder.Code, http.StatusOK)
+
+	assert.StringSlice(t, "pings", pinged, []string{
+		"GET /api/v1/heartbeat/secret ",
+		"POST /api/v1/heartbeat/secret/fail disk full",
+	})
+	assert.Int(t, "get calls", service.GetCalls, 1)
+}
+
+func TestPingProxyRejectsUnknownHeartbeats(t *testing.T) {
+	service := &betterstackfakes.HeartbeatClient{
+		GetFn: func(ctx context.Context, id string) (betterstack.Heartbeat, error) {
+			return betterstack.Heartbeat{}, &betterstack.APIError{StatusCode: http.StatusNotFound}
+		},
+	}
+	proxy := newPingProxy(t, service, newPingHeartbeat("pending", ""), newPingHeartbeat("deleted", "hb-gone"))
+
+	cases := []struct {
+		path string
+		want int
+	}{
+		{path: "/ping/default/missing", want: http.StatusForbidden},
+		{path: "/ping/default/pending", want: http.StatusForbidden},
+		{path: "/ping/default/deleted", want: http.StatusForbidden},
+		{path: "/ping/default/deleted", want: http.StatusForbidden},
+		{path: "/ping/default/deleted/later", want: http.StatusNotFound},
+	}
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			proxy.Handler().ServeHTTP(recorder, newPingRequest(http.MethodGet, tc.path, nil, "secret"))
+			assert.Int(t, "status", recorder.Code, tc.want)
+			if tc.want == http.StatusForbidden {
+				assert.String(t, "body", recorder.Body.String(), "heartbeat token does not match\n")
+			}
+		})
+	}
+	assert.Int(t, "get calls", service.GetCalls, 1)
+}
+
+func TestPingProxyRequiresHeartbeatToken(t *testing.T) {
+	pinged := 0
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		pinged++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer upstream.Close()
+
+	service := &betterstackfakes.HeartbeatClient{
+		GetFn: func(ctx context.Context, id string) (betterstack.Heartbeat, error) {
+			return betterstack.Heartbeat{ID: id, Attributes: betterstack.HeartbeatAttributes{URL: upstream.URL + "/api/v1/heartbeat/secret"}}, nil
+		},
+	}
+	proxy := newPingProxy(t, service, newPingHeartbeat("nightly", "hb-1"))
+
+	cases := []struct {
+		name  string
+		token string
+		want  int
+	}{
+		{name: "missing", want: http.StatusUnauthorized},
+		{name: "wrong", token: "guess", want: http.StatusForbidden},
+		{name: "prefix", token: "secre", want: http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			proxy.Handler().ServeHTTP(recorder, newPingRequest(http.MethodGet, "/ping/default/nightly", nil, tc.token))
+			assert.Int(t, "status", recorder.Code, tc.want)
+		})
+	}
+	assert.Int(t, "pings forwarded", pinged, 0)
+	assert.Int(t, "get calls", service.GetCalls, 1)
+}
+
+func newPingRequest(method, path string, body io.Reader, token string) *http.Request {
+	req := httptest.NewRequest(method, path, body)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
+
+func newPingHeartbeat(name, id string) *monitoringv1alpha1.BetterStackHeartbeat {
+	return &monitoringv1alpha1.BetterStackHeartbeat{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: monitoringv1alpha1.BetterStackHeartbeatSpec{
+			Name:          name,
+			PeriodSeconds: 60,
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+		},
+		Status: monitoringv1alpha1.BetterStackHeartbeatStatus{HeartbeatID: id},
+	}
+}
+
+func newPingProxy(t *testing.T, service *betterstackfakes.HeartbeatClient, heartbeats ...*monitoringv1alpha1.BetterStackHeartbeat) *PingProxy {
+	t.Helper()
+	scheme := controllertest.NewScheme(t)
+	builder := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+			Data:       map[string][]byte{"token": []byte("abcd")},
+		})
+	for _, heartbeat := range heartbeats {
+		builder = builder.WithStatusSubresource(heartbeat).WithObjects(heartbeat.DeepCopy())
+	}
+	return &PingProxy{
+		Heartbeats: &BetterStackHeartbeatReconciler{
+			Client:  builder.Build(),
+			Scheme:  scheme,
+			Clients: &fakeBetterStackHeartbeatClientFactory{heartbeat: service},
+		},
+	}
+}
@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+)
+
+const preflightTimeout = 10 * time.Second
+
+// preflightMonitorTypes lists the HTTP monitor types a preflight request can validate.
+var preflightMonitorTypes = []string{"", "status", "expected_status_code", "keyword", "keyword_absence"}
+
+// preflightCheck issues a single request to the monitor URL from inside the cluster so that
+// unreachable or mistyped endpoints are reported before a remote monitor starts alerting.
+// Non-HTTP monitor types are skipped.
+func preflightCheck(ctx context.Context, httpClient *http.Client, spec monitoringv1alpha1.BetterStackMonitorSpec) error {
+	if !slices.Contains(preflightMonitorTypes, strings.ToLower(spec.MonitorType)) {
+		return nil
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: preflightTimeout}
+	}
+	httpClient, closeIdle := preflightClientFor(httpClient, spec)
+	defer closeIdle()
+
+	method := http.MethodGet
+	if spec.RequestMethod != "" {
+		method = strings.ToUpper(normalizeHTTPMethod(spec.RequestMethod))
+	}
+	var body io.Reader
+	if spec.RequestBody != "" {
+		body = strings.NewReader(spec.RequestBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, spec.URL, body)
+	if err != nil {
+		return fmt.Errorf("preflight request to %s: %w", spec.URL, err)
+	}
+	for _, header := range spec.RequestHeaders {
+		req.Header.Add(header.Name, header.Value)
+	}
+	if spec.AuthUsername != "" || spec.AuthPassword != "" {
+		req.SetBasicAuth(spec.AuthUsername, spec.AuthPassword)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("preflight request to %s failed: %w", spec.URL, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	expected := spec.ExpectedStatusCodes
+	if len(expected) == 0 && spec.ExpectedStatusCode > 0 {
+		expected = []int{spec.ExpectedStatusCode}
+	}
+	if len(expected) > 0 {
+		if !slices.Contains(expected, resp.StatusCode) {
+			return fmt.Errorf("preflight request to %s returned %d, expected one of %v", spec.URL, resp.StatusCode, expected)
+		}
+		return nil
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("preflight request to %s returned %d", spec.URL, resp.StatusCode)
+	}
+	return nil
+}
+
+// preflightClientFor returns a copy of httpClient that checks the URL the way Better Stack
+// will: without certificate verification when spec.verifySSL is false and without following
+// redirects when spec.followRedirects is false. Both default to enabled, as they do remotely.
+// The returned func releases connections of a transport cloned for this request.
+func preflightClientFor(httpClient *http.Client, spec monitoringv1alpha1.BetterStackMonitorSpec) (*http.Client, func()) {
+	client := *httpClient
+	closeIdle := func() {}
+	if spec.FollowRedirects != nil && !*spec.FollowRedirects {
+		client.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
+	if spec.VerifySSL != nil && !*spec.VerifySSL {
+		base, ok := client.Transport.(*http.Transport)
+		if client.Transport == nil {
+			base, ok = http.DefaultTransport.(*http.Transport)
+		}
+		if ok {
+			transport := base.Clone()
+			if transport.TLSClientConfig == nil {
+				transport.TLSClientConfig = &tls.Config{}
+			}
+			transport.TLSClientConfig.InsecureSkipVerify = true
+			client.Transport = transport
+			closeIdle = transport.CloseIdleConnections
+		}
+	}
+	return &client, closeIdle
+}
@@ -0,0 +1,185 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/pkg/betterstack"
+)
+
+// RequestPreview is the payload the operator would send for a resource together with the
+// remote attributes that payload would change.
+type RequestPreview struct {
+	// RemoteID is the Better Stack identifier the preview was compared against. Empty when
+	// the resource would be created.
+	RemoteID string                 `json:"remoteID,omitempty"`
+	Request  map[string]any         `json:"request"`
+	Diff     map[string]FieldChange `json:"diff,omitempty"`
+	// Remote summarizes the state Better Stack reports for the remote object. Nil when the
+	// resource would be created.
+	Remote *RemoteState `json:"remote,omitempty"`
+}
+
+// RemoteState is the derived state of a remote monitor or heartbeat.
+type RemoteState struct {
+	Paused  bool   `json:"paused"`
+	Healthy bool   `json:"healthy"`
+	Age     string `json:"age,omitempty"`
+}
+
+func newRemoteState(paused, healthy bool, age time.Duration) *RemoteState {
+	state := &RemoteState{Paused: paused, Healthy: healthy}
+	if age > 0 {
+		state.Age = age.Truncate(time.Second).String()
+	}
+	return state
+}
+
+// FieldChange describes a single attribute that differs from the remote object.
+type FieldChange struct {
+	Current any `json:"current"`
+	Desired any `json:"desired"`
+}
+
+// PreviewMonitor builds the monitor request without sending it. When id is set the remote
+// monitor is fetched and compared.
+func PreviewMonitor(ctx context.Context, api betterstack.MonitorClient, monitor *monitoringv1alpha1.BetterStackMonitor, id string) (RequestPreview, error) {
+	var existing *betterstack.Monitor
+	if id != "" {
+		remote, err := api.Get(ctx, id)
+		if err != nil && !betterstack.IsNotFound(err) {
+			return RequestPreview{}, fmt.Errorf("fetch monitor %s: %w", id, err)
+		}
+		if err == nil {
+			existing = &remote
+		}
+	}
+
+	request := buildMonitorRequest(monitor.Spec, existing)
+	if existing == nil {
+		return newRequestPreview("", request, nil)
+	}
+	preview, err := newRequestPreview(id, request, existing.Attributes)
+	if err != nil {
+		return RequestPreview{}, err
+	}
+	attrs := existing.Attributes
+	preview.Remote = newRemoteState(attrs.IsPaused(), attrs.Healthy(), attrs.Age(time.Now()))
+	return preview, nil
+}
+
+// PreviewHeartbeat builds the heartbeat request without sending it. When id is set the
+// remote heartbeat is fetched and compared.
+func PreviewHeartbeat(ctx context.Context, api betterstack.HeartbeatClient, heartbeat *monitoringv1alpha1.BetterStackHeartbeat, id string) (RequestPreview, error) {
+	request := buildHeartbeatRequest(heartbeat.Spec)
+	if id == "" {
+		return newRequestPreview("", request, nil)
+	}
+
+	remote, err := api.Get(ctx, id)
+	if betterstack.IsNotFound(err) {
+		return newRequestPreview("", request, nil)
+	}
+	if err != nil {
+		return RequestPreview{}, fmt.Errorf("fetch heartbeat %s: %w", id, err)
+	}
+	preview, err := newRequestPreview(id, request, remote.Attributes)
+	if err != nil {
+		return RequestPreview{}, err
+	}
+	attrs := remote.Attributes
+	preview.Remote = newRemoteState(attrs.IsPaused(), attrs.Healthy(), attrs.Age(time.Now()))
+	return preview, nil
+}
+
+// PreviewMonitorGroup builds the monitor group request without sending it. When id is set
+// the remote group is fetched and compared.
+func PreviewMonitorGroup(ctx context.Context, api betterstack.MonitorGroupClient, group *monitoringv1alpha1.BetterStackMonitorGroup, id string) (RequestPreview, error) {
+	request := buildMonitorGroupRequest(group.Spec)
+	if id == "" {
+		return newRequestPreview("", request, nil)
+	}
+
+	remote, err := api.Get(ctx, id)
+	if betterstack.IsNotFound(err) {
+		return newRequestPreview("", request, nil)
+	}
+	if err != nil {
+		return RequestPreview{}, fmt.Errorf("fetch monitor group %s: %w", id, err)
+	}
+	return newRequestPreview(id, request, remote.Attributes)
+}
+
+func newRequestPreview(id string, request any, remote any) (RequestPreview, error) {
+	desired, err := toJSONMap(request)
+	if err != nil {
+		return RequestPreview{}, fmt.Errorf("encode request: %w", err)
+	}
+	preview := RequestPreview{RemoteID: id, Request: desired}
+	if remote == nil {
+		return preview, nil
+	}
+
+	current, err := toJSONMap(remote)
+	if err != nil {
+		return RequestPreview{}, fmt.Errorf("encode remote attributes: %w", err)
+	}
+	for key, want := range desired {
+		have, ok := current[key]
+		if ok && sameAttribute(key, have, want) {
+			continue
+		}
+		if preview.Diff == nil {
+			preview.Diff = map[string]FieldChange{}
+		}
+		preview.Diff[key] = FieldChange{Current: have, Desired: want}
+	}
+	return preview, nil
+}
+
+// sameAttribute compares a remote attribute with the desired value, ignoring URL
+// normalization performed by Better Stack, the case of HTTP methods and IDs the API
+// returns as numbers but accepts as strings.
+func sameAttribute(key string, have, want any) bool {
+	if reflect.DeepEqual(have, want) {
+		return true
+	}
+	if number, ok := have.(json.Number); ok {
+		if text, ok := want.(string); ok {
+			return number.String() == text
+		}
+	}
+	if caseInsensitiveFields[key] {
+		return sameMethod(have, want)
+	}
+	if !urlFields[key] {
+		return false
+	}
+	haveURL, ok := have.(string)
+	if !ok {
+		return false
+	}
+	wantURL, ok := want.(string)
+	return ok && equivalentURLs(haveURL, wantURL)
+}
+
+// toJSONMap converts v to its JSON object form. Numbers are kept as json.Number so 64-bit
+// IDs are compared and shown with their exact digits.
+func toJSONMap(v any) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]any{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/internal/testutil/assert"
+	"loks0n/betterstack-operator/pkg/betterstack"
+	"loks0n/betterstack-operator/pkg/betterstack/betterstackfakes"
+)
+
+func TestPreviewMonitorDiffsAgainstRemote(t *testing.T) {
+	created := time.Now().Add(-90 * time.Minute)
+	service := &betterstackfakes.MonitorClient{
+		GetFn: func(ctx context.Context, id string) (betterstack.Monitor, error) {
+			assert.String(t, "get id", id, "remote-1")
+			return betterstack.Monitor{ID: id, Attributes: betterstack.MonitorAttributes{
+				URL:            "https://old.example.com",
+				MonitorType:    "status",
+				CheckFrequency: 180,
+				Status:         betterstack.MonitorStatusPaused,
+				CreatedAt:      &created,
+			}}, nil
+		},
+	}
+	monitor := &monitoringv1alpha1.BetterStackMonitor{
+		Spec: monitoringv1alpha1.BetterStackMonitorSpec{
+			URL:                   "https://example.com",
+			MonitorType:           "status",
+			CheckFrequencyMinutes: 3,
+		},
+	}
+
+	preview, err := PreviewMonitor(context.Background(), service, monitor, "remote-1")
+	assert.NoError(t, err, "preview monitor")
+	assert.String(t, "remote id", preview.RemoteID, "remote-1")
+	assert.Equal(t, "request url", preview.Request["url"], any("https://example.com"))
+
+	change, ok := preview.Diff["url"]
+	assert.Bool(t, "url diff present", ok, true)
+	assert.Equal(t, "current url", change.Current, any("https://old.example.com"))
+	assert.Equal(t, "desired url", change.Desired, any("https://example.com"))
+	_, ok = preview.Diff["monitor_type"]
+	assert.Bool(t, "monitor_type unchanged", ok, false)
+	_, ok = preview.Diff["check_frequency"]
+	assert.Bool(t, "check_frequency unchanged", ok, false)
+	assert.NotNil(t, "remote state", preview.Remote)
+	assert.Bool(t, "remote paused", preview.Remote.Paused, true)
+	assert.Bool(t, "remote healthy", preview.Remote.Healthy, false)
+	assert.Bool(t, "remote age", strings.HasPrefix(preview.Remote.Age, "1h30m"), true)
+	assert.Int(t, "update calls", service.UpdateCalls, 0)
+	assert.Int(t, "create calls", service.CreateCalls, 0)
+}
+
+func TestPreviewMonitorKeeps64BitIDs(t *testing.T) {
+	// 2^53+1 and 2^53+3 round to the same float64.
+	policyID := 9007199254740993
+	service := &betterstackfakes.MonitorClient{
+		GetFn: func(ctx context.Context, id string) (betterstack.Monitor, error) {
+			return betterstack.Monitor{ID: id, Attributes: betterstack.MonitorAttributes{
+				URL:                "https://example.com",
+				PolicyID:           &policyID,
+				ExpirationPolicyID: &policyID,
+			}}, nil
+		},
+	}
+	monitor := &monitoringv1alpha1.BetterStackMonitor{
+		Spec: monitoringv1alpha1.BetterStackMonitorSpec{
+			URL:                "https://example.com",
+			PolicyID:           "9007199254740993",
+			ExpirationPolicyID: "9007199254740995",
+		},
+	}
+
+	preview, err := PreviewMonitor(context.Background(), service, monitor, "remote-1")
+	assert.NoError(t, err, "preview monitor")
+	_, ok := preview.Diff["policy_id"]
+	assert.Bool(t, "policy_id diff present", ok, false)
+	change, ok := preview.Diff["expiration_policy_id"]
+	assert.Bool(t, "expiration_policy_id diff present", ok, true)
+	assert.Equal(t, "current expiration policy", change.Current, any(json.Number("9007199254740993")))
+	assert.Equal(t, "desired expiration policy", change.Desired, any("9007199254740995"))
+}
+
+func TestPreviewMonitorIgnoresURLNormalization(t *testing.T) {
+	service := &betterstackfakes.MonitorClient{
+		GetFn: func(ctx context.Context, id string) (betterstack.Monitor, error) {
+			return betterstack.Monitor{ID: id, Attributes: betterstack.MonitorAttributes{URL: "https://example.com/health"}}, nil
+		},
+	}
+	monitor := &monitoringv1alpha1.BetterStackMonitor{
+		Spec: monitoringv1alpha1.BetterStackMonitorSpec{URL: "HTTPS://Example.COM:443/health/"},
+	}
+
+	preview, err := PreviewMonitor(context.Background(), service, monitor, "remote-1")
+	assert.NoError(t, err, "preview monitor")
+	_, ok := preview.Diff["url"]
+	assert.Bool(t, "url diff present", ok, false)
+}
+
+func TestPreviewMonitorIgnoresMethodCase(t *testing.T) {
+	service := &betterstackfakes.MonitorClient{
+		GetFn: func(ctx context.Context, id string) (betterstack.Monitor, error) {
+			return betterstack.Monitor{ID: id, Attributes: betterstack.MonitorAttributes{URL: "https://example.com", HTTPMethod: "POST"}}, nil
+		},
+	}
+	monitor := &monitoringv1alpha1.BetterStackMonitor{
+		Spec: monitoringv1alpha1.BetterStackMonitorSpec{URL: "https://example.com", RequestMethod: "Post"},
+	}
+
+	preview, err := PreviewMonitor(context.Background(), service, monitor, "remote-1")
+	assert.NoError(t, err, "preview monitor")
+	assert.Equal(t, "request method", preview.Request["http_method"], any("post"))
+	_, ok := preview.Diff["http_method"]
+	assert.Bool(t, "http_method diff present", ok, false)
+}
+
+func TestEquivalentURLs(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"https://example.com", "https://example.com/", true},
+		{"https://Example.com/Path", "https://example.com/Path", true},
+		{"http://example.com:80/a", "http://example.com/a", true},
+		{"https://example.com:8443", "https://example.com", false},
+		{"https://[::1]:443/", "https://[::1]", true},
+		{"https://example.com/Path", "https://example.com/path", false},
+		{"https://example.com/?a=1", "https://example.com/?a=2", false},
+		{"example.com", "example.com/", false},
+	}
+	for _, tt := range tests {
+		assert.Bool(t, tt.a+" vs "+tt.b, equivalentURLs(tt.a, tt.b), tt.want)
+	}
+}
+
+func TestPreviewHeartbeatWithoutRemote(t *testing.T) {
+	service := &betterstackfakes.HeartbeatClient{
+		GetFn: func(ctx context.Context, id string) (betterstack.Heartbeat, error) {
+			return betterstack.Heartbeat{}, &betterstack.APIError{StatusCode: http.StatusNotFound}
+		},
+	}
+	heartbeat := &monitoringv1alpha1.BetterStackHeartbeat{
+		Spec: monitoringv1alpha1.BetterStackHeartbeatSpec{Name: "Nightly", PeriodSeconds: 3600},
+	}
+
+	preview, err := PreviewHeartbeat(context.Background(), service, heartbeat, "gone")
+	assert.NoError(t, err, "preview heartbeat")
+	assert.String(t, "remote id", preview.RemoteID, "")
+	assert.Equal(t, "request name", preview.Request["name"], any("Nightly"))
+	assert.Int(t, "diff entries", len(preview.Diff), 0)
+	assert.Nil(t, "remote state", preview.Remote)
+}
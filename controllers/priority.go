@@ -0,0 +1,36 @@
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// enqueueSpecChangesFirst enqueues a reconciler's own resources like the handler For
+// installs, except that updates leaving metadata.generation unchanged get low priority
+// as well. Those are the operator's own status patches and annotation or label edits,
+// so with the priority queue a spec edit is reconciled before the status-only churn of a
+// large resync. Without the priority queue every event is added normally.
+func enqueueSpecChangesFirst() handler.EventHandler {
+	forObject := handler.WithLowPriorityWhenUnchanged(&handler.EnqueueRequestForObject{})
+	return handler.Funcs{
+		CreateFunc:  forObject.Create,
+		DeleteFunc:  forObject.Delete,
+		GenericFunc: forObject.Generic,
+		UpdateFunc: func(ctx context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			queue, ok := q.(priorityqueue.PriorityQueue[reconcile.Request])
+			if !ok || e.ObjectNew.GetGeneration() != e.ObjectOld.GetGeneration() {
+				forObject.Update(ctx, e, q)
+				return
+			}
+			request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(e.ObjectNew)}
+			queue.AddWithOpts(priorityqueue.AddOpts{Priority: ptr.To(handler.LowPriority)}, request)
+		},
+	}
+}
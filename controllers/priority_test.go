@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/internal/testutil/assert"
+)
+
+func priorityMonitor(name, resourceVersion string, generation int64) *monitoringv1alpha1.BetterStackMonitor {
+	return &monitoringv1alpha1.BetterStackMonitor{ObjectMeta: metav1.ObjectMeta{
+		Name:            name,
+		Namespace:       "default",
+		ResourceVersion: resourceVersion,
+		Generation:      generation,
+	}}
+}
+
+func TestEnqueueSpecChangesFirst(t *testing.T) {
+	queue := priorityqueue.New[reconcile.Request]("test")
+	defer queue.ShutDown()
+
+	ctx := context.Background()
+	h := enqueueSpecChangesFirst()
+	h.Create(ctx, event.CreateEvent{Object: priorityMonitor("listed", "1", 1), IsInInitialList: true}, queue)
+	h.Update(ctx, event.UpdateEvent{ObjectOld: priorityMonitor("status", "1", 1), ObjectNew: priorityMonitor("status", "2", 1)}, queue)
+	h.Update(ctx, event.UpdateEvent{ObjectOld: priorityMonitor("edited", "1", 1), ObjectNew: priorityMonitor("edited", "2", 2)}, queue)
+
+	first, priority, _ := queue.GetWithPriority()
+	assert.String(t, "first", first.Name, "edited")
+	assert.Int(t, "first priority", priority, 0)
+	for range 2 {
+		item, priority, _ := queue.GetWithPriority()
+		if item.Name == "edited" {
+			t.Fatalf("spec change dequeued twice")
+		}
+		assert.Int(t, item.Name+" priority", priority, handler.LowPriority)
+	}
+}
@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"loks0n/betterstack-operator/internal/controller/credentials"
+	"loks0n/betterstack-operator/pkg/betterstack"
+)
+
+// regionRejectionTTL bounds how long a rejected region set is remembered, so a plan
+// upgrade is picked up without restarting the operator.
+const regionRejectionTTL = 30 * time.Minute
+
+// regionCache remembers, per Better Stack credential, the region sets the API rejected.
+// Better Stack offers no endpoint listing the regions a plan includes, so availability is
+// learned from rejections; later reconciles of the same set fail fast with
+// RegionUnavailable instead of repeating a request that cannot succeed.
+type regionCache struct {
+	mu       sync.Mutex
+	rejected map[string]time.Time
+}
+
+// rejectedAt reports whether regions was rejected for account within regionRejectionTTL.
+func (c *regionCache) rejectedAt(account credentials.Account, regions []string, now time.Time) bool {
+	if len(regions) == 0 {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := regionCacheKey(account, regions)
+	at, ok := c.rejected[key]
+	if ok && now.Sub(at) >= regionRejectionTTL {
+		delete(c.rejected, key)
+		return false
+	}
+	return ok
+}
+
+// reject records that Better Stack refused regions for account.
+func (c *regionCache) reject(account credentials.Account, regions []string, now time.Time) {
+	if len(regions) == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.rejected == nil {
+		c.rejected = map[string]time.Time{}
+	}
+	c.rejected[regionCacheKey(account, regions)] = now
+}
+
+// regionCacheKey identifies the credential by a digest of its token and endpoint, so
+// resources sharing a token share rejections without the token being kept as a key.
+func regionCacheKey(account credentials.Account, regions []string) string {
+	sorted := slices.Clone(regions)
+	slices.Sort(sorted)
+	return accountDigest(account) + "/" + strings.Join(sorted, ",")
+}
+
+// accountDigest identifies a credential by a digest of its token and endpoint.
+func accountDigest(account credentials.Account) string {
+	digest := sha256.Sum256([]byte(account.BaseURL + "\x00" + account.Token))
+	return hex.EncodeToString(digest[:8])
+}
+
+// isRegionUnavailable reports whether Better Stack rejected the monitor's regions, which
+// it does with a validation error naming the regions attribute.
+func isRegionUnavailable(err error) bool {
+	var apiErr *betterstack.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	if apiErr.StatusCode != http.StatusUnprocessableEntity && apiErr.StatusCode != http.StatusForbidden {
+		return false
+	}
+	return strings.Contains(strings.ToLower(apiErr.Message), "region")
+}
@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"sync"
+	"time"
+
+	"loks0n/betterstack-operator/internal/controller/credentials"
+	"loks0n/betterstack-operator/pkg/betterstack"
+)
+
+// remoteMonitorCache remembers the monitor Better Stack returned from the last update or
+// create, per credential and remote ID. A reconcile within the TTL uses it in place of
+// the Get that precedes every update, so no-op reconciles triggered by resyncs or secret
+// changes cost one API call instead of two. Entries are dropped whenever a write fails,
+// since the remote state is then unknown.
+type remoteMonitorCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedMonitor
+}
+
+type cachedMonitor struct {
+	monitor  betterstack.Monitor
+	storedAt time.Time
+}
+
+// get returns the cached monitor for id if it was stored less than ttl before now. A
+// zero ttl disables the cache.
+func (c *remoteMonitorCache) get(account credentials.Account, id string, ttl time.Duration, now time.Time) (betterstack.Monitor, bool) {
+	if ttl <= 0 || id == "" {
+		return betterstack.Monitor{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := remoteCacheKey(account, id)
+	entry, ok := c.entries[key]
+	if !ok {
+		return betterstack.Monitor{}, false
+	}
+	if now.Sub(entry.storedAt) >= ttl {
+		delete(c.entries, key)
+		return betterstack.Monitor{}, false
+	}
+	return entry.monitor, true
+}
+
+// put stores monitor as Better Stack last returned it.
+func (c *remoteMonitorCache) put(account credentials.Account, monitor betterstack.Monitor, ttl time.Duration, now time.Time) {
+	if ttl <= 0 || monitor.ID == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = map[string]cachedMonitor{}
+	}
+	c.entries[remoteCacheKey(account, monitor.ID)] = cachedMonitor{monitor: monitor, storedAt: now}
+}
+
+// forget drops the cached monitor for id.
+func (c *remoteMonitorCache) forget(account credentials.Account, id string) {
+	if id == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, remoteCacheKey(account, id))
+}
+
+// remoteCacheKey scopes id to the credential, since IDs are only unique within an account.
+func remoteCacheKey(account credentials.Account, id string) string {
+	return accountDigest(account) + "/" + id
+}
@@ -0,0 +1,78 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/internal/controller/refs"
+	"loks0n/betterstack-operator/internal/controller/secretwatch"
+	"loks0n/betterstack-operator/internal/testutil/assert"
+	"loks0n/betterstack-operator/internal/testutil/controllertest"
+)
+
+func TestSecretWatchMapsSecretsAndCredentials(t *testing.T) {
+	newHeartbeat := func(namespace, name string, secret string, account *monitoringv1alpha1.ResourceRef) *monitoringv1alpha1.BetterStackHeartbeat {
+		return &monitoringv1alpha1.BetterStackHeartbeat{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: monitoringv1alpha1.BetterStackHeartbeatSpec{
+				APITokenSecretRef: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: secret}, Key: "token"},
+				AccountRef:        account,
+			},
+		}
+	}
+
+	direct := newHeartbeat("default", "direct", "api", nil)
+	viaAccount := newHeartbeat("default", "via-account", "unused", &monitoringv1alpha1.ResourceRef{Name: "team-b"})
+	crossNamespace := newHeartbeat("team-a", "cross-namespace", "unused", &monitoringv1alpha1.ResourceRef{Name: "team-b", Namespace: "default"})
+	unrelated := newHeartbeat("default", "unrelated", "other", nil)
+	credential := &monitoringv1alpha1.BetterStackCredential{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-b", Namespace: "default"},
+		Spec: monitoringv1alpha1.BetterStackCredentialSpec{
+			APITokenSecretRef: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "api"}, Key: "api-key"},
+		},
+	}
+
+	newList := func() client.ObjectList { return &monitoringv1alpha1.BetterStackHeartbeatList{} }
+	mapper := secretwatch.NewMapper(nil, "heartbeat", newList)
+	indexValue := func(index func(*monitoringv1alpha1.BetterStackHeartbeat) []string) client.IndexerFunc {
+		return func(obj client.Object) []string {
+			return index(obj.(*monitoringv1alpha1.BetterStackHeartbeat))
+		}
+	}
+
+	mapper.Reader = fake.NewClientBuilder().
+		WithScheme(controllertest.NewScheme(t)).
+		WithObjects(direct, viaAccount, crossNamespace, unrelated, credential).
+		WithIndex(&monitoringv1alpha1.BetterStackHeartbeat{}, mapper.SecretIndexKey, indexValue(func(h *monitoringv1alpha1.BetterStackHeartbeat) []string {
+			return []string{secretwatch.IndexValue(h.Namespace, h.Spec.APITokenSecretRef.Name)}
+		})).
+		WithIndex(&monitoringv1alpha1.BetterStackHeartbeat{}, mapper.AccountIndexKey, indexValue(func(h *monitoringv1alpha1.BetterStackHeartbeat) []string {
+			if h.Spec.AccountRef == nil {
+				return nil
+			}
+			target := refs.Target(h.Namespace, *h.Spec.AccountRef)
+			return []string{secretwatch.IndexValue(target.Namespace, target.Name)}
+		})).
+		Build()
+
+	ctx := context.Background()
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"}}
+	assert.StringSlice(t, "secret requests", requestNames(mapper.RequestsForSecret(ctx, secret)), []string{"direct", "via-account", "cross-namespace"})
+	assert.StringSlice(t, "credential requests", requestNames(mapper.RequestsForCredential(ctx, credential)), []string{"via-account", "cross-namespace"})
+	assert.Int(t, "unrelated object", len(mapper.RequestsForSecret(ctx, &corev1.ConfigMap{})), 0)
+}
+
+func requestNames(requests []reconcile.Request) []string {
+	names := make([]string, 0, len(requests))
+	for _, req := range requests {
+		names = append(names, req.Name)
+	}
+	return names
+}
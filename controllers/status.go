@@ -0,0 +1,40 @@
+package controllers
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// patchObjectStatus applies mutate to the status of obj, as returned by status, and patches
+// it. A merge patch replaces status.conditions as a whole, so writing a list read from a
+// stale cache would drop conditions other controllers added in the meantime. The patch is
+// therefore guarded by the resourceVersion; on a conflict a fresh copy is read and mutate
+// reapplied to it, which keeps conditions the operator does not manage. Only the patched
+// status is copied back: obj keeps the spec, metadata and generation the reconcile synced,
+// so mutate may read them from obj.
+func patchObjectStatus[T client.Object, S any](ctx context.Context, c client.Client, obj T, status func(T) *S, mutate func(*S)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	target := obj
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		base := target.DeepCopyObject().(T)
+		mutate(status(target))
+		err := c.Status().Patch(ctx, target, client.MergeFromWithOptions(base, client.MergeFromWithOptimisticLock{}))
+		if apierrors.IsConflict(err) {
+			fresh := obj.DeepCopyObject().(T)
+			if getErr := c.Get(ctx, client.ObjectKeyFromObject(obj), fresh); getErr != nil {
+				return getErr
+			}
+			target = fresh
+		}
+		return err
+	})
+	if any(target) != any(obj) {
+		*status(obj) = *status(target)
+	}
+	return err
+}
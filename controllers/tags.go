@@ -0,0 +1,39 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"loks0n/betterstack-operator/pkg/betterstack"
+)
+
+// tagsIgnoredMessage is appended to the Synced condition when the account rejected tags.
+const tagsIgnoredMessage = "spec.tags ignored because this Better Stack account does not support monitor tags"
+
+// sendWithoutUnsupportedTags sends req and, when Better Stack rejects the tags attribute,
+// sends it again without tags. The second result reports whether tags were dropped.
+func sendWithoutUnsupportedTags(req betterstack.MonitorRequest, send func(betterstack.MonitorRequest) (betterstack.Monitor, error)) (betterstack.Monitor, bool, error) {
+	monitor, err := send(req)
+	if err == nil || len(req.Tags) == 0 || !isTagsUnsupported(err) {
+		return monitor, false, err
+	}
+	req.Tags = nil
+	monitor, err = send(req)
+	return monitor, err == nil, err
+}
+
+// isTagsUnsupported reports whether err is Better Stack rejecting the tags attribute,
+// either as unknown or as not enabled for the account.
+func isTagsUnsupported(err error) bool {
+	var apiErr *betterstack.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusBadRequest, http.StatusForbidden, http.StatusUnprocessableEntity:
+	default:
+		return false
+	}
+	return strings.Contains(strings.ToLower(apiErr.Message), "tag")
+}
@@ -0,0 +1,444 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+)
+
+// Terraform betteruptime provider resource types.
+const (
+	TerraformMonitorType      = "betteruptime_monitor"
+	TerraformHeartbeatType    = "betteruptime_heartbeat"
+	TerraformMonitorGroupType = "betteruptime_monitor_group"
+)
+
+// terraformNumericIDs lists attributes the provider types as numbers while the API
+// request carries them as strings.
+var terraformNumericIDs = []string{"policy_id", "expiration_policy_id", "monitor_group_id"}
+
+var terraformNameInvalid = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+
+// TerraformResource is the betteruptime provider resource equivalent to a custom resource.
+type TerraformResource struct {
+	Type       string
+	Name       string
+	Attributes map[string]any
+}
+
+// TerraformResourceFor converts a BetterStackMonitor, BetterStackHeartbeat or
+// BetterStackMonitorGroup into the matching provider resource. The attributes are the
+// request the operator would send, so both tools manage the same remote configuration.
+func TerraformResourceFor(obj client.Object) (TerraformResource, error) {
+	var resourceType string
+	var request any
+	switch typed := obj.(type) {
+	case *monitoringv1alpha1.BetterStackMonitor:
+		resourceType = TerraformMonitorType
+		request = buildMonitorRequest(typed.Spec, nil)
+	case *monitoringv1alpha1.BetterStackHeartbeat:
+		resourceType = TerraformHeartbeatType
+		request = buildHeartbeatRequest(typed.Spec)
+	case *monitoringv1alpha1.BetterStackMonitorGroup:
+		resourceType = TerraformMonitorGroupType
+		request = buildMonitorGroupRequest(typed.Spec)
+	default:
+		return TerraformResource{}, fmt.Errorf("unsupported object %T", obj)
+	}
+
+	attributes, err := toJSONMap(request)
+	if err != nil {
+		return TerraformResource{}, fmt.Errorf("encode request: %w", err)
+	}
+	for _, key := range terraformNumericIDs {
+		if value, ok := attributes[key].(string); ok {
+			if id, err := strconv.Atoi(value); err == nil {
+				attributes[key] = id
+			}
+		}
+	}
+	return TerraformResource{Type: resourceType, Name: terraformName(obj.GetName()), Attributes: attributes}, nil
+}
+
+func terraformName(name string) string {
+	name = terraformNameInvalid.ReplaceAllString(name, "_")
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "_" + name
+	}
+	return name
+}
+
+// WriteTerraformHCL renders resources as Terraform configuration.
+func WriteTerraformHCL(w io.Writer, resources []TerraformResource) error {
+	for i, resource := range resources {
+		if i > 0 {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+		body := strings.Builder{}
+		writeHCLBody(&body, resource.Attributes, "  ")
+		if _, err := fmt.Fprintf(w, "resource %s %s {\n%s}\n", hclString(resource.Type), hclString(resource.Name), body.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeHCLBody writes the attributes of an object one per line, aligning the equals signs
+// of consecutive single-line attributes the way terraform fmt does.
+func writeHCLBody(b *strings.Builder, attributes map[string]any, indent string) {
+	keys := make([]string, 0, len(attributes))
+	for key, value := range attributes {
+		if value != nil {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	rendered := make([]string, len(keys))
+	for i, key := range keys {
+		rendered[i] = hclValue(attributes[key], indent)
+	}
+
+	for start := 0; start < len(keys); {
+		end := start + 1
+		if !strings.Contains(rendered[start], "\n") {
+			for end < len(keys) && !strings.Contains(rendered[end], "\n") {
+				end++
+			}
+		}
+		width := 0
+		for _, key := range keys[start:end] {
+			width = max(width, len(hclKey(key)))
+		}
+		for i := start; i < end; i++ {
+			fmt.Fprintf(b, "%s%-*s = %s\n", indent, width, hclKey(keys[i]), rendered[i])
+		}
+		start = end
+	}
+}
+
+func hclValue(value any, indent string) string {
+	switch v := value.(type) {
+	case string:
+		return hclString(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case json.Number:
+		return v.String()
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []any:
+		if len(v) == 0 {
+			return "[]"
+		}
+		items := make([]string, len(v))
+		multiline := false
+		for i, item := range v {
+			items[i] = hclValue(item, indent+"  ")
+			if _, ok := item.(map[string]any); ok {
+				multiline = true
+			}
+		}
+		if !multiline {
+			return "[" + strings.Join(items, ", ") + "]"
+		}
+		b := strings.Builder{}
+		b.WriteString("[\n")
+		for _, item := range items {
+			fmt.Fprintf(&b, "%s  %s,\n", indent, item)
+		}
+		b.WriteString(indent + "]")
+		return b.String()
+	case map[string]any:
+		b := strings.Builder{}
+		b.WriteString("{\n")
+		writeHCLBody(&b, v, indent+"  ")
+		b.WriteString(indent + "}")
+		return b.String()
+	default:
+		return hclString(fmt.Sprint(v))
+	}
+}
+
+func hclKey(key string) string {
+	for i, r := range key {
+		if !(r == '_' || r == '-' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r))) {
+			return hclString(key)
+		}
+	}
+	if key == "" {
+		return `""`
+	}
+	return key
+}
+
+// hclString quotes s as an HCL string literal, escaping template sequences.
+func hclString(s string) string {
+	b := strings.Builder{}
+	b.WriteByte('"')
+	for i, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case (r == '$' || r == '%') && strings.HasPrefix(s[i+1:], "{"):
+			b.WriteRune(r)
+			b.WriteRune(r)
+		case unicode.IsControl(r):
+			fmt.Fprintf(&b, `\u%04x`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// ObjectsFromTerraformJSON converts betteruptime resources written in Terraform's JSON
+// configuration syntax (.tf.json) into custom resources in namespace that read their
+// token from tokenRef. Resources of other types are ignored.
+func ObjectsFromTerraformJSON(data []byte, namespace string, tokenRef corev1.SecretKeySelector) ([]client.Object, error) {
+	var config struct {
+		Resource map[string]map[string]json.RawMessage `json:"resource"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("decode terraform json: %w", err)
+	}
+
+	var objects []client.Object
+	for _, resourceType := range []string{TerraformMonitorGroupType, TerraformMonitorType, TerraformHeartbeatType} {
+		resources := config.Resource[resourceType]
+		names := make([]string, 0, len(resources))
+		for name := range resources {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			attributes := terraformAttributes{}
+			if err := json.Unmarshal(resources[name], &attributes); err != nil {
+				return nil, fmt.Errorf("decode %s.%s: %w", resourceType, name, err)
+			}
+			meta := metav1.ObjectMeta{Name: strings.ReplaceAll(strings.ToLower(name), "_", "-"), Namespace: namespace}
+
+			var obj client.Object
+			switch resourceType {
+			case TerraformMonitorType:
+				spec := monitorSpecFromTerraform(attributes)
+				spec.APITokenSecretRef = tokenRef
+				obj = &monitoringv1alpha1.BetterStackMonitor{ObjectMeta: meta, Spec: spec}
+			case TerraformHeartbeatType:
+				spec := heartbeatSpecFromTerraform(attributes)
+				spec.APITokenSecretRef = tokenRef
+				obj = &monitoringv1alpha1.BetterStackHeartbeat{ObjectMeta: meta, Spec: spec}
+			case TerraformMonitorGroupType:
+				spec := monitorGroupSpecFromTerraform(attributes)
+				spec.APITokenSecretRef = tokenRef
+				obj = &monitoringv1alpha1.BetterStackMonitorGroup{ObjectMeta: meta, Spec: spec}
+			}
+			obj.GetObjectKind().SetGroupVersionKind(monitoringv1alpha1.GroupVersion.WithKind(terraformKinds[resourceType]))
+			objects = append(objects, obj)
+		}
+	}
+	return objects, nil
+}
+
+var terraformKinds = map[string]string{
+	TerraformMonitorType:      "BetterStackMonitor",
+	TerraformHeartbeatType:    "BetterStackHeartbeat",
+	TerraformMonitorGroupType: "BetterStackMonitorGroup",
+}
+
+// terraformAttributes reads provider attributes whose values may be written as strings or
+// numbers, as Terraform allows.
+type terraformAttributes map[string]any
+
+func (a terraformAttributes) string(key string) string {
+	switch v := a[key].(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return ""
+	}
+}
+
+func (a terraformAttributes) int(key string) int {
+	switch v := a[key].(type) {
+	case float64:
+		return int(v)
+	case string:
+		n, _ := strconv.Atoi(v)
+		return n
+	default:
+		return 0
+	}
+}
+
+func (a terraformAttributes) intPtr(key string) *int {
+	if _, ok := a[key]; !ok {
+		return nil
+	}
+	return ptr.To(a.int(key))
+}
+
+func (a terraformAttributes) boolPtr(key string) *bool {
+	switch v := a[key].(type) {
+	case bool:
+		return ptr.To(v)
+	case string:
+		if parsed, err := strconv.ParseBool(v); err == nil {
+			return ptr.To(parsed)
+		}
+	}
+	return nil
+}
+
+func (a terraformAttributes) strings(key string) []string {
+	items, _ := a[key].([]any)
+	var out []string
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func monitorSpecFromTerraform(a terraformAttributes) monitoringv1alpha1.BetterStackMonitorSpec {
+	spec := monitoringv1alpha1.BetterStackMonitorSpec{
+		URL:                       a.string("url"),
+		Name:                      a.string("pronounceable_name"),
+		MonitorType:               a.string("monitor_type"),
+		TeamName:                  a.string("team_name"),
+		Regions:                   a.strings("regions"),
+		RequestMethod:             normalizeHTTPMethod(a.string("http_method")),
+		RequiredKeyword:           a.string("required_keyword"),
+		Paused:                    ptr.Deref(a.boolPtr("paused"), false),
+		Email:                     a.boolPtr("email"),
+		SMS:                       a.boolPtr("sms"),
+		Call:                      a.boolPtr("call"),
+		Push:                      a.boolPtr("push"),
+		CriticalAlert:             a.boolPtr("critical_alert"),
+		FollowRedirects:           a.boolPtr("follow_redirects"),
+		VerifySSL:                 a.boolPtr("verify_ssl"),
+		RememberCookies:           a.boolPtr("remember_cookies"),
+		PolicyID:                  a.string("policy_id"),
+		ExpirationPolicyID:        a.string("expiration_policy_id"),
+		MonitorGroupID:            a.string("monitor_group_id"),
+		TeamWaitSeconds:           a.int("team_wait"),
+		DomainExpirationDays:      a.int("domain_expiration"),
+		SSLExpirationDays:         a.int("ssl_expiration"),
+		RecoveryPeriodSeconds:     a.int("recovery_period"),
+		ConfirmationPeriodSeconds: a.int("confirmation_period"),
+		IPVersion:                 a.string("ip_version"),
+		MaintenanceDays:           a.strings("maintenance_days"),
+		MaintenanceFrom:           a.string("maintenance_from"),
+		MaintenanceTo:             a.string("maintenance_to"),
+		MaintenanceTimezone:       a.string("maintenance_timezone"),
+		RequestBody:               a.string("request_body"),
+		AuthUsername:              a.string("auth_username"),
+		AuthPassword:              a.string("auth_password"),
+		PlaywrightScript:          a.string("playwright_script"),
+		ScenarioName:              a.string("scenario_name"),
+	}
+
+	if frequency := a.int("check_frequency"); frequency > 0 {
+		spec.CheckFrequencyMinutes = (frequency + 59) / 60
+	}
+	if codes, ok := a["expected_status_codes"].([]any); ok {
+		for _, code := range codes {
+			if n, ok := code.(float64); ok {
+				spec.ExpectedStatusCodes = append(spec.ExpectedStatusCodes, int(n))
+			}
+		}
+	}
+	if port, err := strconv.Atoi(a.string("port")); err == nil {
+		spec.Port = port
+	}
+	if timeout := a.int("request_timeout"); timeout > 0 {
+		switch strings.ToLower(spec.MonitorType) {
+		case "ping", "tcp", "udp", "smtp", "pop", "imap", "dns":
+			timeout = timeout / 1000
+		}
+		spec.RequestTimeoutSeconds = timeout
+	}
+	if headers, ok := a["request_headers"].([]any); ok {
+		for _, header := range headers {
+			fields, ok := header.(map[string]any)
+			if !ok {
+				continue
+			}
+			name, _ := fields["name"].(string)
+			value, _ := fields["value"].(string)
+			if name != "" {
+				spec.RequestHeaders = append(spec.RequestHeaders, monitoringv1alpha1.BetterStackHeader{Name: name, Value: value})
+			}
+		}
+	}
+	if variables, ok := a["environment_variables"].(map[string]any); ok {
+		spec.EnvironmentVariables = map[string]string{}
+		for key, value := range variables {
+			spec.EnvironmentVariables[key] = fmt.Sprint(value)
+		}
+	}
+	return spec
+}
+
+func heartbeatSpecFromTerraform(a terraformAttributes) monitoringv1alpha1.BetterStackHeartbeatSpec {
+	spec := monitoringv1alpha1.BetterStackHeartbeatSpec{
+		Name:                a.string("name"),
+		PeriodSeconds:       a.int("period"),
+		GraceSeconds:        a.int("grace"),
+		TeamName:            a.string("team_name"),
+		Call:                a.boolPtr("call"),
+		SMS:                 a.boolPtr("sms"),
+		Email:               a.boolPtr("email"),
+		Push:                a.boolPtr("push"),
+		CriticalAlert:       a.boolPtr("critical_alert"),
+		TeamWaitSeconds:     a.int("team_wait"),
+		HeartbeatGroupID:    a.intPtr("heartbeat_group_id"),
+		SortIndex:           a.intPtr("sort_index"),
+		Paused:              a.boolPtr("paused"),
+		MaintenanceDays:     a.strings("maintenance_days"),
+		MaintenanceFrom:     a.string("maintenance_from"),
+		MaintenanceTo:       a.string("maintenance_to"),
+		MaintenanceTimezone: a.string("maintenance_timezone"),
+	}
+	if policyID := a.string("policy_id"); policyID != "" {
+		spec.PolicyID = ptr.To(policyID)
+	}
+	return spec
+}
+
+func monitorGroupSpecFromTerraform(a terraformAttributes) monitoringv1alpha1.BetterStackMonitorGroupSpec {
+	return monitoringv1alpha1.BetterStackMonitorGroupSpec{
+		Name:      a.string("name"),
+		TeamName:  a.string("team_name"),
+		SortIndex: a.intPtr("sort_index"),
+		Paused:    a.boolPtr("paused"),
+	}
+}
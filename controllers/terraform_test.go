@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/internal/testutil/assert"
+)
+
+func TestTerraformResourceForMonitorRendersHCL(t *testing.T) {
+	monitor := &monitoringv1alpha1.BetterStackMonitor{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-health"},
+		Spec: monitoringv1alpha1.BetterStackMonitorSpec{
+			URL:                   "https://example.com/health",
+			Name:                  "API \"health\" ${env}",
+			MonitorType:           "status",
+			CheckFrequencyMinutes: 3,
+			PolicyID:              "42",
+			Regions:               []string{"us", "eu"},
+			RequestHeaders:        []monitoringv1alpha1.BetterStackHeader{{Name: "X-Token", Value: "abc"}},
+		},
+	}
+
+	resource, err := TerraformResourceFor(monitor)
+	assert.NoError(t, err, "convert monitor")
+	assert.String(t, "type", resource.Type, TerraformMonitorType)
+	assert.String(t, "name", resource.Name, "api-health")
+	assert.Equal(t, "policy id", resource.Attributes["policy_id"], any(42))
+
+	var out strings.Builder
+	assert.NoError(t, WriteTerraformHCL(&out, []TerraformResource{resource}), "write hcl")
+	want := `resource "betteruptime_monitor" "api-health" {
+  check_frequency    = 180
+  monitor_type       = "status"
+  paused             = false
+  policy_id          = 42
+  pronounceable_name = "API \"health\" $${env}"
+  regions            = ["us", "eu"]
+  request_headers = [
+    {
+      name  = "X-Token"
+      value = "abc"
+    },
+  ]
+  url = "https://example.com/health"
+}
+`
+	assert.String(t, "hcl", out.String(), want)
+}
+
+func TestTerraformResourceForRejectsUnsupportedObjects(t *testing.T) {
+	_, err := TerraformResourceFor(&corev1.Secret{})
+	assert.Error(t, err, "convert secret")
+}
+
+func TestObjectsFromTerraformJSON(t *testing.T) {
+	config := []byte(`{
+  "resource": {
+    "betteruptime_monitor": {
+      "api_health": {
+        "url": "https://example.com/health",
+        "monitor_type": "tcp",
+        "check_frequency": 90,
+        "request_timeout": 5000,
+        "port": "443",
+        "monitor_group_id": 7,
+        "email": true,
+        "request_headers": [{"name": "X-Token", "value": "abc"}]
+      }
+    },
+    "betteruptime_heartbeat": {
+      "nightly": {"name": "Nightly", "period": 86400, "grace": "300", "policy_id": 9}
+    },
+    "aws_instance": {"web": {"ami": "ami-123"}}
+  }
+}`)
+	tokenRef := corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "api"}, Key: "token"}
+
+	objects, err := ObjectsFromTerraformJSON(config, "monitoring", tokenRef)
+	assert.NoError(t, err, "convert terraform")
+	assert.Int(t, "objects", len(objects), 2)
+
+	monitor, ok := objects[0].(*monitoringv1alpha1.BetterStackMonitor)
+	assert.Bool(t, "monitor type", ok, true)
+	assert.String(t, "kind", monitor.Kind, "BetterStackMonitor")
+	assert.String(t, "name", monitor.Name, "api-health")
+	assert.String(t, "namespace", monitor.Namespace, "monitoring")
+	assert.Int(t, "check frequency", monitor.Spec.CheckFrequencyMinutes, 2)
+	assert.Int(t, "request timeout", monitor.Spec.RequestTimeoutSeconds, 5)
+	assert.Int(t, "port", monitor.Spec.Port, 443)
+	assert.String(t, "monitor group", monitor.Spec.MonitorGroupID, "7")
+	assert.EqualPtr(t, "email", monitor.Spec.Email, true)
+	assert.Equal(t, "header", monitor.Spec.RequestHeaders[0], monitoringv1alpha1.BetterStackHeader{Name: "X-Token", Value: "abc"})
+	assert.Equal(t, "token ref", monitor.Spec.APITokenSecretRef, tokenRef)
+
+	heartbeat, ok := objects[1].(*monitoringv1alpha1.BetterStackHeartbeat)
+	assert.Bool(t, "heartbeat type", ok, true)
+	assert.Int(t, "period", heartbeat.Spec.PeriodSeconds, 86400)
+	assert.Int(t, "grace", heartbeat.Spec.GraceSeconds, 300)
+	assert.EqualPtr(t, "policy", heartbeat.Spec.PolicyID, "9")
+}
@@ -0,0 +1,169 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+)
+
+// Uniqueness scopes accepted by UniquenessValidator.
+const (
+	// UniquenessNamespace rejects duplicates within a namespace.
+	UniquenessNamespace = "namespace"
+	// UniquenessCluster rejects duplicates anywhere in the cluster.
+	UniquenessCluster = "cluster"
+)
+
+//+kubebuilder:webhook:path=/validate-monitoring-betterstack-io-v1alpha1-betterstackmonitor,mutating=false,failurePolicy=ignore,sideEffects=None,groups=monitoring.betterstack.io,resources=betterstackmonitors,verbs=create;update,versions=v1alpha1,name=vbetterstackmonitor.monitoring.betterstack.io,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-monitoring-betterstack-io-v1alpha1-betterstackheartbeat,mutating=false,failurePolicy=ignore,sideEffects=None,groups=monitoring.betterstack.io,resources=betterstackheartbeats,verbs=create;update,versions=v1alpha1,name=vbetterstackheartbeat.monitoring.betterstack.io,admissionReviewVersions=v1
+
+// UniquenessValidator rejects a BetterStackMonitor with the same URL and name as an
+// existing one, and a BetterStackHeartbeat with the same name. Such duplicates are almost
+// always copy-paste mistakes and show up as confusingly identical entries in Better Stack.
+// It also rejects monitors with an unsupported request method or free-form maps that
+// exceed the size limits, maintenance days that are not a day of the week, and, in
+// namespaces opted in through the Verifier, references Better Stack does not know.
+type UniquenessValidator struct {
+	Client client.Reader
+	// Scope is UniquenessNamespace (the default) or UniquenessCluster.
+	Scope string
+	// Verifier, when set, also checks resources in opted-in namespaces against Better Stack.
+	Verifier *AdmissionVerifier
+}
+
+// SetupWebhookWithManager registers the validator for monitors and heartbeats.
+func (v *UniquenessValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if v.Scope != "" && v.Scope != UniquenessNamespace && v.Scope != UniquenessCluster {
+		return fmt.Errorf("unknown uniqueness scope %q", v.Scope)
+	}
+	if err := ctrl.NewWebhookManagedBy(mgr).For(&monitoringv1alpha1.BetterStackMonitor{}).WithValidator(v).Complete(); err != nil {
+		return err
+	}
+	return ctrl.NewWebhookManagedBy(mgr).For(&monitoringv1alpha1.BetterStackHeartbeat{}).WithValidator(v).Complete()
+}
+
+// ValidateCreate implements admission.CustomValidator.
+func (v *UniquenessValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	if err := validateSpec(obj); err != nil {
+		return nil, err
+	}
+	if err := v.validate(ctx, obj); err != nil {
+		return nil, err
+	}
+	return v.Verifier.Verify(ctx, obj)
+}
+
+// ValidateUpdate implements admission.CustomValidator. Updates that leave the spec alone are
+// always admitted, so finalizer and label edits never wait on Better Stack and still work on
+// objects created before a validation rule was added. Uniqueness is only checked when an
+// update changes the name or URL, so existing duplicates can still be edited.
+func (v *UniquenessValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	if !specChanged(oldObj, newObj) {
+		return nil, nil
+	}
+	if err := validateSpec(newObj); err != nil {
+		return nil, err
+	}
+	if uniquenessKey(oldObj) != uniquenessKey(newObj) {
+		if err := v.validate(ctx, newObj); err != nil {
+			return nil, err
+		}
+	}
+	return v.Verifier.Verify(ctx, newObj)
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (v *UniquenessValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *UniquenessValidator) validate(ctx context.Context, obj runtime.Object) error {
+	var opts []client.ListOption
+	if v.Scope != UniquenessCluster {
+		opts = append(opts, client.InNamespace(obj.(client.Object).GetNamespace()))
+	}
+
+	switch obj := obj.(type) {
+	case *monitoringv1alpha1.BetterStackMonitor:
+		var monitors monitoringv1alpha1.BetterStackMonitorList
+		if err := v.Client.List(ctx, &monitors, opts...); err != nil {
+			return apierrors.NewInternalError(err)
+		}
+		for i := range monitors.Items {
+			other := &monitors.Items[i]
+			if obj.UID != "" && other.UID == obj.UID || other.Namespace == obj.Namespace && other.Name == obj.Name {
+				continue
+			}
+			if other.Spec.Name == obj.Spec.Name && equivalentURLs(other.Spec.URL, obj.Spec.URL) {
+				return duplicateError("betterstackmonitors", "BetterStackMonitor", obj, other, fmt.Sprintf("url %s and name %q", obj.Spec.URL, obj.Spec.Name))
+			}
+		}
+	case *monitoringv1alpha1.BetterStackHeartbeat:
+		var heartbeats monitoringv1alpha1.BetterStackHeartbeatList
+		if err := v.Client.List(ctx, &heartbeats, opts...); err != nil {
+			return apierrors.NewInternalError(err)
+		}
+		for i := range heartbeats.Items {
+			other := &heartbeats.Items[i]
+			if obj.UID != "" && other.UID == obj.UID || other.Namespace == obj.Namespace && other.Name == obj.Name {
+				continue
+			}
+			if obj.Spec.Name != "" && other.Spec.Name == obj.Spec.Name {
+				return duplicateError("betterstackheartbeats", "BetterStackHeartbeat", obj, other, fmt.Sprintf("name %q", obj.Spec.Name))
+			}
+		}
+	default:
+		return apierrors.NewBadRequest(fmt.Sprintf("unexpected object %T", obj))
+	}
+	return nil
+}
+
+func validateSpec(obj runtime.Object) error {
+	switch obj := obj.(type) {
+	case *monitoringv1alpha1.BetterStackMonitor:
+		return validateMonitorSpec(obj)
+	case *monitoringv1alpha1.BetterStackHeartbeat:
+		return validateHeartbeatSpec(obj)
+	}
+	return nil
+}
+
+func duplicateError(resource, kind string, obj, other client.Object, identity string) error {
+	return apierrors.NewForbidden(
+		monitoringv1alpha1.GroupVersion.WithResource(resource).GroupResource(),
+		obj.GetName(),
+		fmt.Errorf("%s %s/%s already uses %s", kind, other.GetNamespace(), other.GetName(), identity),
+	)
+}
+
+// specChanged reports whether an update edits the spec of a monitor or heartbeat.
+func specChanged(oldObj, newObj runtime.Object) bool {
+	switch newObj := newObj.(type) {
+	case *monitoringv1alpha1.BetterStackMonitor:
+		oldObj, ok := oldObj.(*monitoringv1alpha1.BetterStackMonitor)
+		return !ok || !equality.Semantic.DeepEqual(oldObj.Spec, newObj.Spec)
+	case *monitoringv1alpha1.BetterStackHeartbeat:
+		oldObj, ok := oldObj.(*monitoringv1alpha1.BetterStackHeartbeat)
+		return !ok || !equality.Semantic.DeepEqual(oldObj.Spec, newObj.Spec)
+	}
+	return false
+}
+
+// uniquenessKey is the identity compared by UniquenessValidator.
+func uniquenessKey(obj runtime.Object) string {
+	switch obj := obj.(type) {
+	case *monitoringv1alpha1.BetterStackMonitor:
+		return normalizeURL(obj.Spec.URL) + "\x00" + obj.Spec.Name
+	case *monitoringv1alpha1.BetterStackHeartbeat:
+		return obj.Spec.Name
+	}
+	return ""
+}
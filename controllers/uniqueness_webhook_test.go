@@ -0,0 +1,184 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/internal/testutil/assert"
+	"loks0n/betterstack-operator/internal/testutil/controllertest"
+)
+
+func uniquenessMonitor(namespace, name, url, displayName string) *monitoringv1alpha1.BetterStackMonitor {
+	return &monitoringv1alpha1.BetterStackMonitor{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       monitoringv1alpha1.BetterStackMonitorSpec{URL: url, Name: displayName},
+	}
+}
+
+func uniquenessHeartbeat(namespace, name, displayName string) *monitoringv1alpha1.BetterStackHeartbeat {
+	return &monitoringv1alpha1.BetterStackHeartbeat{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       monitoringv1alpha1.BetterStackHeartbeatSpec{Name: displayName},
+	}
+}
+
+func newUniquenessValidator(t *testing.T, scope string, objs ...client.Object) *UniquenessValidator {
+	t.Helper()
+	c := fake.NewClientBuilder().WithScheme(controllertest.NewScheme(t)).WithObjects(objs...).Build()
+	return &UniquenessValidator{Client: c, Scope: scope}
+}
+
+func TestUniquenessValidatorMonitors(t *testing.T) {
+	existing := uniquenessMonitor("team-a", "api", "https://example.com/health", "API")
+	cases := []struct {
+		name    string
+		scope   string
+		monitor *monitoringv1alpha1.BetterStackMonitor
+		reject  bool
+	}{
+		{name: "same url and name", monitor: uniquenessMonitor("team-a", "api-copy", "https://EXAMPLE.com/health/", "API"), reject: true},
+		{name: "different name", monitor: uniquenessMonitor("team-a", "api-eu", "https://example.com/health", "API (EU)")},
+		{name: "different url", monitor: uniquenessMonitor("team-a", "web", "https://example.com/", "API")},
+		{name: "other namespace", monitor: uniquenessMonitor("team-b", "api", "https://example.com/health", "API")},
+		{name: "other namespace in cluster scope", scope: UniquenessCluster, monitor: uniquenessMonitor("team-b", "api", "https://example.com/health", "API"), reject: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			v := newUniquenessValidator(t, tc.scope, existing.DeepCopy())
+			_, err := v.ValidateCreate(context.Background(), tc.monitor)
+			if tc.reject {
+				assert.Error(t, err, "validate create")
+				assert.Bool(t, "forbidden", apierrors.IsForbidden(err), true)
+				return
+			}
+			assert.NoError(t, err, "validate create")
+		})
+	}
+}
+
+func TestUniquenessValidatorHeartbeats(t *testing.T) {
+	v := newUniquenessValidator(t, "", uniquenessHeartbeat("jobs", "nightly", "Nightly backup"), uniquenessHeartbeat("jobs", "unnamed", ""))
+
+	_, err := v.ValidateCreate(context.Background(), uniquenessHeartbeat("jobs", "nightly-copy", "Nightly backup"))
+	assert.Error(t, err, "duplicate heartbeat name")
+	_, err = v.ValidateCreate(context.Background(), uniquenessHeartbeat("jobs", "weekly", "Weekly backup"))
+	assert.NoError(t, err, "distinct heartbeat name")
+	_, err = v.ValidateCreate(context.Background(), uniquenessHeartbeat("jobs", "also-unnamed", ""))
+	assert.NoError(t, err, "heartbeats without a name")
+}
+
+func TestUniquenessValidatorUpdate(t *testing.T) {
+	first := uniquenessMonitor("team-a", "api", "https://example.com/health", "API")
+	second := uniquenessMonitor("team-a", "api-copy", "https://example.com/health", "API")
+	v := newUniquenessValidator(t, "", first.DeepCopy(), second.DeepCopy())
+
+	edited := second.DeepCopy()
+	edited.Spec.Paused = true
+	_, err := v.ValidateUpdate(context.Background(), second, edited)
+	assert.NoError(t, err, "update leaving an existing duplicate's identity alone")
+
+	renamed := uniquenessMonitor("team-a", "web", "https://example.com/", "Web")
+	target := renamed.DeepCopy()
+	target.Spec.URL = "https://example.com/health"
+	target.Spec.Name = "API"
+	_, err = v.ValidateUpdate(context.Background(), renamed, target)
+	assert.Error(t, err, "update introducing a duplicate")
+}
+
+func TestUniquenessValidatorEnforcesMapLimits(t *testing.T) {
+	v := newUniquenessValidator(t, "")
+
+	tooMany := uniquenessMonitor("team-a", "api", "https://example.com/health", "API")
+	tooMany.Spec.AdditionalAttributes = map[string]string{}
+	for i := 0; i <= monitoringv1alpha1.MaxAdditionalAttributes; i++ {
+		tooMany.Spec.AdditionalAttributes[fmt.Sprintf("attr_%d", i)] = "x"
+	}
+	_, err := v.ValidateCreate(context.Background(), tooMany)
+	assert.Error(t, err, "too many additional attributes")
+	assert.Bool(t, "invalid", apierrors.IsInvalid(err), true)
+	assert.Bool(t, "message names the limit", strings.Contains(err.Error(), "at most 64 are allowed"), true)
+
+	tooLong := uniquenessMonitor("team-a", "browser", "https://example.com/", "Browser")
+	tooLong.Spec.EnvironmentVariables = map[string]string{"TOKEN": strings.Repeat("a", monitoringv1alpha1.MaxMapValueLength+1)}
+	_, err = v.ValidateUpdate(context.Background(), uniquenessMonitor("team-a", "browser", "https://example.com/", "Browser"), tooLong)
+	assert.Error(t, err, "oversized environment variable")
+	assert.Bool(t, "message names the field", strings.Contains(err.Error(), "spec.environmentVariables[TOKEN]"), true)
+	assert.Bool(t, "value omitted", strings.Contains(err.Error(), "aaaa"), false)
+
+	tooLong.Spec.EnvironmentVariables["TOKEN"] = strings.Repeat("a", monitoringv1alpha1.MaxMapValueLength)
+	_, err = v.ValidateCreate(context.Background(), tooLong)
+	assert.NoError(t, err, "environment variable at the limit")
+}
+
+func TestUniquenessValidatorAcceptsMethodsInAnyCase(t *testing.T) {
+	v := newUniquenessValidator(t, "")
+
+	monitor := uniquenessMonitor("team-a", "api", "https://example.com/health", "API")
+	monitor.Spec.RequestMethod = "GET"
+	_, err := v.ValidateCreate(context.Background(), monitor)
+	assert.NoError(t, err, "uppercase method")
+
+	monitor.Spec.RequestMethod = "fetch"
+	_, err = v.ValidateCreate(context.Background(), monitor)
+	assert.Error(t, err, "unsupported method")
+	assert.Bool(t, "invalid", apierrors.IsInvalid(err), true)
+	assert.Bool(t, "message lists methods", strings.Contains(err.Error(), `"get"`), true)
+}
+
+func TestUniquenessValidatorRejectsManagedAdditionalAttributes(t *testing.T) {
+	v := newUniquenessValidator(t, "")
+
+	monitor := uniquenessMonitor("team-a", "api", "https://example.com/health", "API")
+	monitor.Spec.AdditionalAttributes = map[string]string{"paused": "true", "url": "https://other.example.com", "custom_flag": "on"}
+	_, err := v.ValidateCreate(context.Background(), monitor)
+	assert.Error(t, err, "managed additional attributes")
+	assert.Bool(t, "invalid", apierrors.IsInvalid(err), true)
+	assert.Bool(t, "names paused", strings.Contains(err.Error(), "spec.additionalAttributes[paused]"), true)
+	assert.Bool(t, "names url", strings.Contains(err.Error(), "spec.additionalAttributes[url]"), true)
+	assert.Bool(t, "allows unknown keys", strings.Contains(err.Error(), "custom_flag"), false)
+
+	monitor.Spec.AdditionalAttributes = map[string]string{"custom_flag": "on"}
+	_, err = v.ValidateCreate(context.Background(), monitor)
+	assert.NoError(t, err, "unmanaged additional attribute")
+
+	legacy := uniquenessMonitor("team-a", "legacy", "https://example.com/", "Legacy")
+	legacy.Spec.AdditionalAttributes = map[string]string{"paused": "true"}
+	unfinalized := legacy.DeepCopy()
+	unfinalized.Finalizers = nil
+	legacy.Finalizers = []string{"monitoring.betterstack.io/finalizer"}
+	_, err = v.ValidateUpdate(context.Background(), legacy, unfinalized)
+	assert.NoError(t, err, "metadata-only update of a monitor created before the rule")
+
+	edited := legacy.DeepCopy()
+	edited.Spec.Name = "Legacy API"
+	_, err = v.ValidateUpdate(context.Background(), legacy, edited)
+	assert.Error(t, err, "spec update keeping a managed additional attribute")
+}
+
+func TestUniquenessValidatorChecksMaintenanceDays(t *testing.T) {
+	v := newUniquenessValidator(t, "")
+
+	monitor := uniquenessMonitor("team-a", "api", "https://example.com/health", "API")
+	monitor.Spec.MaintenanceDays = []string{"Monday", "TUE", "sat", "wEdNeSdAy", "Thu"}
+	_, err := v.ValidateCreate(context.Background(), monitor)
+	assert.NoError(t, err, "full and abbreviated day names in any case")
+
+	monitor.Spec.MaintenanceDays = []string{" fri"}
+	_, err = v.ValidateCreate(context.Background(), monitor)
+	assert.Error(t, err, "padded day")
+
+	heartbeat := uniquenessHeartbeat("team-a", "backup", "Backup")
+	heartbeat.Spec.MaintenanceDays = []string{"sunday", "funday"}
+	_, err = v.ValidateCreate(context.Background(), heartbeat)
+	assert.Error(t, err, "unknown day")
+	assert.Bool(t, "invalid", apierrors.IsInvalid(err), true)
+	assert.Bool(t, "names the entry", strings.Contains(err.Error(), "spec.maintenanceDays[1]"), true)
+}
@@ -0,0 +1,54 @@
+package controllers
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"loks0n/betterstack-operator/pkg/betterstack"
+)
+
+// withoutUnmanagedFields returns req with the attributes named in fields cleared, so an
+// update leaves their current Better Stack values alone. Names are API attribute names
+// such as paused or policy_id and also remove matching additionalAttributes.
+func withoutUnmanagedFields(req betterstack.MonitorRequest, fields []string) betterstack.MonitorRequest {
+	if len(fields) == 0 {
+		return req
+	}
+	value := reflect.ValueOf(&req).Elem()
+	for i := range value.NumField() {
+		name, _, _ := strings.Cut(value.Type().Field(i).Tag.Get("json"), ",")
+		if slices.Contains(fields, name) {
+			value.Field(i).SetZero()
+		}
+	}
+	if len(req.AdditionalAttributes) > 0 {
+		req.AdditionalAttributes = maps.Clone(req.AdditionalAttributes)
+		for _, field := range fields {
+			delete(req.AdditionalAttributes, field)
+		}
+	}
+	return req
+}
+
+// unmanagedDrift lists, sorted, the unmanaged attributes whose remote value differs from
+// what the spec would set.
+func unmanagedDrift(req betterstack.MonitorRequest, remote betterstack.MonitorAttributes, fields []string) ([]string, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	preview, err := newRequestPreview("", req, remote)
+	if err != nil {
+		return nil, fmt.Errorf("compare unmanaged fields: %w", err)
+	}
+	var drift []string
+	for key := range preview.Diff {
+		if slices.Contains(fields, key) {
+			drift = append(drift, key)
+		}
+	}
+	slices.Sort(drift)
+	return drift, nil
+}
@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/internal/testutil/assert"
+	"loks0n/betterstack-operator/internal/testutil/controllertest"
+	"loks0n/betterstack-operator/pkg/betterstack"
+	"loks0n/betterstack-operator/pkg/betterstack/betterstackfakes"
+)
+
+func TestWithoutUnmanagedFields(t *testing.T) {
+	req := betterstack.MonitorRequest{
+		URL:                  ptr.To("https://example.com"),
+		Paused:               ptr.To(false),
+		PolicyID:             ptr.To("42"),
+		Regions:              []string{"us"},
+		AdditionalAttributes: map[string]any{"policy_id": "7", "sort_index": 1},
+	}
+
+	got := withoutUnmanagedFields(req, []string{"paused", "policy_id", "regions"})
+
+	assert.EqualPtr(t, "url", got.URL, "https://example.com")
+	if got.Paused != nil || got.PolicyID != nil || got.Regions != nil {
+		t.Fatalf("expected unmanaged fields to be cleared, got paused=%v policy=%v regions=%v", got.Paused, got.PolicyID, got.Regions)
+	}
+	_, kept := got.AdditionalAttributes["policy_id"]
+	assert.Bool(t, "policy_id additional attribute kept", kept, false)
+	assert.Equal(t, "sort_index additional attribute", got.AdditionalAttributes["sort_index"], any(1))
+	assert.Int(t, "original additional attributes", len(req.AdditionalAttributes), 2)
+	assert.EqualPtr(t, "original paused", req.Paused, false)
+}
+
+func TestReconcileLeavesUnmanagedFieldsAlone(t *testing.T) {
+	scheme := controllertest.NewScheme(t)
+
+	monitor := &monitoringv1alpha1.BetterStackMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example",
+			Namespace:  "default",
+			Finalizers: []string{monitoringv1alpha1.BetterStackMonitorFinalizer},
+		},
+		Spec: monitoringv1alpha1.BetterStackMonitorSpec{
+			URL:             "https://example.com",
+			Name:            "API",
+			PolicyID:        "42",
+			UnmanagedFields: []string{"paused", "policy_id"},
+			APITokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			},
+		},
+		Status: monitoringv1alpha1.BetterStackMonitorStatus{
+			MonitorID: "remote-123",
+		},
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("abcd")},
+	}
+
+	client := newIndexedClientBuilder(scheme).
+		WithStatusSubresource(monitor).
+		WithObjects(monitor.DeepCopy(), secret.DeepCopy()).
+		Build()
+
+	service := &betterstackfakes.MonitorClient{
+		GetFn: func(ctx context.Context, id string) (betterstack.Monitor, error) {
+			return betterstack.Monitor{ID: id, Attributes: betterstack.MonitorAttributes{
+				URL:               "https://example.com",
+				PronounceableName: "Renamed in UI",
+				Paused:            true,
+				PolicyID:          ptr.To(42),
+			}}, nil
+		},
+		UpdateFn: func(ctx context.Context, id string, req betterstack.MonitorUpdateRequest) (betterstack.Monitor, error) {
+			return betterstack.Monitor{ID: id}, nil
+		},
+	}
+	r := &BetterStackMonitorReconciler{Client: client, Scheme: scheme, Clients: &fakeBetterStackMonitorClientFactory{monitor: service}}
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: monitor.Name, Namespace: monitor.Namespace}
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err, "reconcile")
+
+	if service.LastUpdateReq.Paused != nil || service.LastUpdateReq.PolicyID != nil {
+		t.Fatalf("expected unmanaged fields to be omitted, got paused=%v policy=%v", service.LastUpdateReq.Paused, service.LastUpdateReq.PolicyID)
+	}
+	assert.EqualPtr(t, "managed name", service.LastUpdateReq.PronounceableName, "API")
+
+	updated := &monitoringv1alpha1.BetterStackMonitor{}
+	assert.NoError(t, client.Get(ctx, key, updated), "fetch monitor")
+	// policy_id matches once the remote number is compared with the requested string.
+	assert.StringSlice(t, "unmanaged drift", updated.Status.UnmanagedDrift, []string{"paused"})
+}
@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	"net"
+	"net/url"
+	"strings"
+)
+
+// defaultPorts maps URL schemes to the port Better Stack drops when it normalizes a URL.
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+}
+
+// urlFields lists the request attributes that hold URLs and are compared with
+// equivalentURLs rather than byte for byte.
+var urlFields = map[string]bool{
+	"url": true,
+}
+
+// normalizeURL lowercases the scheme and host, drops the scheme's default port and trims
+// a trailing slash from the path so URLs that Better Stack rewrites compare equal. Values
+// that do not parse as absolute URLs are returned unchanged.
+func normalizeURL(raw string) string {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return raw
+	}
+
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	host := strings.ToLower(parsed.Hostname())
+	if port := parsed.Port(); port != "" && port != defaultPorts[parsed.Scheme] {
+		host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	parsed.Host = host
+	parsed.Path = strings.TrimSuffix(parsed.Path, "/")
+	parsed.RawPath = strings.TrimSuffix(parsed.RawPath, "/")
+	return parsed.String()
+}
+
+// equivalentURLs reports whether a and b address the same endpoint once normalized.
+func equivalentURLs(a, b string) bool {
+	return a == b || normalizeURL(a) == normalizeURL(b)
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/controllers"
+	"loks0n/betterstack-operator/pkg/betterstack"
+)
+
+const diffTokenEnv = "BETTERSTACK_TOKEN"
+
+// runDiff implements the "diff" subcommand: it translates a manifest into the Better Stack
+// request the operator would send and, when a remote ID is known, diffs it against the
+// current remote object. Nothing is written to Better Stack.
+func runDiff(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	file := fs.String("f", "-", "Path to the BetterStackMonitor, BetterStackHeartbeat or BetterStackMonitorGroup manifest (- for stdin).")
+	id := fs.String("id", "", "Remote Better Stack ID to compare against. Defaults to the ID recorded in the manifest status.")
+	baseURL := fs.String("base-url", "", "Better Stack API base URL. Defaults to spec.baseURL or the public API.")
+	timeout := fs.Duration("timeout", 30*time.Second, "Timeout for Better Stack API calls.")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	data, err := readManifest(*file)
+	if err != nil {
+		fmt.Fprintf(stderr, "read manifest: %v\n", err)
+		return 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	preview, err := previewManifest(ctx, data, os.Getenv(diffTokenEnv), *baseURL, *id)
+	if err != nil {
+		fmt.Fprintf(stderr, "diff: %v\n", err)
+		return 1
+	}
+
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(preview); err != nil {
+		fmt.Fprintf(stderr, "encode preview: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func readManifest(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
+
+func previewManifest(ctx context.Context, data []byte, token, baseURL, id string) (controllers.RequestPreview, error) {
+	var meta struct {
+		Kind string `json:"kind"`
+	}
+	if err := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096).Decode(&meta); err != nil {
+		return controllers.RequestPreview{}, err
+	}
+
+	client := func(specBaseURL string) *betterstack.Client {
+		if baseURL == "" {
+			baseURL = specBaseURL
+		}
+		return betterstack.NewClient(baseURL, token, nil)
+	}
+	requireToken := func(remoteID string) error {
+		if remoteID != "" && token == "" {
+			return fmt.Errorf("%s must be set to compare against remote ID %s", diffTokenEnv, remoteID)
+		}
+		return nil
+	}
+
+	switch meta.Kind {
+	case "BetterStackMonitor":
+		monitor := &monitoringv1alpha1.BetterStackMonitor{}
+		if err := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096).Decode(monitor); err != nil {
+			return controllers.RequestPreview{}, err
+		}
+		if id == "" {
+			id = monitor.Status.MonitorID
+		}
+		if err := requireToken(id); err != nil {
+			return controllers.RequestPreview{}, err
+		}
+		return controllers.PreviewMonitor(ctx, client(monitor.Spec.BaseURL).Monitors, monitor, id)
+	case "BetterStackHeartbeat":
+		heartbeat := &monitoringv1alpha1.BetterStackHeartbeat{}
+		if err := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096).Decode(heartbeat); err != nil {
+			return controllers.RequestPreview{}, err
+		}
+		if id == "" {
+			id = heartbeat.Status.HeartbeatID
+		}
+		if err := requireToken(id); err != nil {
+			return controllers.RequestPreview{}, err
+		}
+		return controllers.PreviewHeartbeat(ctx, client(heartbeat.Spec.BaseURL).Heartbeats, heartbeat, id)
+	case "BetterStackMonitorGroup":
+		group := &monitoringv1alpha1.BetterStackMonitorGroup{}
+		if err := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096).Decode(group); err != nil {
+			return controllers.RequestPreview{}, err
+		}
+		if id == "" {
+			id = group.Status.MonitorGroupID
+		}
+		if err := requireToken(id); err != nil {
+			return controllers.RequestPreview{}, err
+		}
+		return controllers.PreviewMonitorGroup(ctx, client(group.Spec.BaseURL).MonitorGroups, group, id)
+	default:
+		return controllers.RequestPreview{}, fmt.Errorf("unsupported kind %q", meta.Kind)
+	}
+}
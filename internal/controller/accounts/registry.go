@@ -0,0 +1,115 @@
+// Package accounts shares HTTP clients between resources that use the same Better Stack account.
+package accounts
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"loks0n/betterstack-operator/internal/controller/credentials"
+	"loks0n/betterstack-operator/internal/controller/metrics"
+)
+
+// Registry hands out per-account HTTP clients so every resource referencing the same
+// BetterStackCredential also shares its rate limiter. A nil Registry performs no limiting.
+type Registry struct {
+	mu      sync.Mutex
+	entries map[string]*entry
+}
+
+type entry struct {
+	requestsPerSecond int
+	burst             int
+	base              *http.Client
+	client            *http.Client
+}
+
+// NewRegistry constructs an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{entries: map[string]*entry{}}
+}
+
+// HTTPClient returns the client to use for requests on behalf of account. Clients are
+// cached per account and rebuilt when its rate limit configuration changes.
+func (r *Registry) HTTPClient(account credentials.Account, base *http.Client) *http.Client {
+	if r == nil {
+		return base
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := account.Name
+	if e, ok := r.entries[key]; ok && e.base == base && e.requestsPerSecond == account.RequestsPerSecond && e.burst == account.Burst {
+		return e.client
+	}
+
+	e := &entry{
+		requestsPerSecond: account.RequestsPerSecond,
+		burst:             account.Burst,
+		base:              base,
+		client:            newClient(account, base),
+	}
+	r.entries[key] = e
+	return e.client
+}
+
+func newClient(account credentials.Account, base *http.Client) *http.Client {
+	client := &http.Client{Timeout: 30 * time.Second}
+	if base != nil {
+		copied := *base
+		client = &copied
+	}
+
+	next := client.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	var limiter *rate.Limiter
+	if account.RequestsPerSecond > 0 {
+		burst := account.Burst
+		if burst <= 0 {
+			burst = account.RequestsPerSecond
+		}
+		limiter = rate.NewLimiter(rate.Limit(account.RequestsPerSecond), burst)
+	}
+
+	client.Transport = &transport{
+		account: metrics.AccountLabel(account.Name),
+		limiter: limiter,
+		next:    next,
+	}
+	return client
+}
+
+type transport struct {
+	account string
+	limiter *rate.Limiter
+	next    http.RoundTripper
+}
+
+// RoundTrip waits for the account rate limiter and records request and rate limit metrics.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.limiter != nil {
+		start := time.Now()
+		if err := t.limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+		metrics.APIRateLimitWait.WithLabelValues(t.account).Observe(time.Since(start).Seconds())
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+		if remaining, parseErr := strconv.Atoi(resp.Header.Get("RateLimit-Remaining")); parseErr == nil {
+			metrics.APIRateLimitRemaining.WithLabelValues(t.account).Set(float64(remaining))
+		}
+	}
+	metrics.APIRequests.WithLabelValues(t.account, req.Method, code).Inc()
+	return resp, err
+}
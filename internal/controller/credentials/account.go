@@ -0,0 +1,78 @@
+package credentials
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/internal/controller/refs"
+)
+
+// Account captures the Better Stack credentials a resource reconciles with.
+type Account struct {
+	// Name identifies the BetterStackCredential as namespace/name. It is empty when the
+	// resource supplies its token directly through apiTokenSecretRef.
+	Name    string
+	Token   string
+	BaseURL string
+
+	// RequestsPerSecond and Burst configure the account-wide API rate limit.
+	RequestsPerSecond int
+	Burst             int
+
+	// Quota holds the plan limits recorded on the credential, if any.
+	Quota monitoringv1alpha1.BetterStackQuota
+
+	// Source describes where the token was read from, for status messages.
+	Source string
+}
+
+// Resolve loads the account a resource should use. accountRef takes precedence over the
+// inline secret reference and is checked against policy; baseURL on the resource
+// overrides the account default.
+func Resolve(ctx context.Context, cl client.Client, policy refs.Policy, namespace string, accountRef *monitoringv1alpha1.ResourceRef, selector corev1.SecretKeySelector, baseURL string) (Account, error) {
+	if accountRef == nil || accountRef.Name == "" {
+		token, err := FetchAPIToken(ctx, cl, namespace, selector)
+		if err != nil {
+			return Account{}, err
+		}
+		return Account{
+			Token:   token,
+			BaseURL: baseURL,
+			Source:  fmt.Sprintf("secret %s/%s", namespace, selector.Name),
+		}, nil
+	}
+
+	key, err := policy.Resolve(namespace, *accountRef)
+	if err != nil {
+		return Account{}, err
+	}
+	credential := &monitoringv1alpha1.BetterStackCredential{}
+	if err := cl.Get(ctx, key, credential); err != nil {
+		return Account{}, fmt.Errorf("fetch BetterStackCredential %s: %w", key, err)
+	}
+
+	// The credential's secret lives next to the credential, which may be in another
+	// namespace than the resource.
+	token, err := FetchAPIToken(ctx, cl, key.Namespace, credential.Spec.APITokenSecretRef)
+	if err != nil {
+		return Account{}, err
+	}
+
+	if baseURL == "" {
+		baseURL = credential.Spec.BaseURL
+	}
+	name := key.String()
+	return Account{
+		Name:              name,
+		Token:             token,
+		BaseURL:           baseURL,
+		RequestsPerSecond: credential.Spec.RequestsPerSecond,
+		Burst:             credential.Spec.Burst,
+		Quota:             credential.Spec.Quota,
+		Source:            fmt.Sprintf("account %s", name),
+	}, nil
+}
@@ -0,0 +1,207 @@
+// Package loglevel sets log verbosity per controller and reloads it from a file, typically
+// a mounted ConfigMap, without restarting the manager.
+package loglevel
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap/zapcore"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// controllerKey is the structured log field controller-runtime attaches to every
+// reconciler's logger.
+const controllerKey = "controller"
+
+// DebugKey is a structured log field that, set to true, writes a logger's entries at
+// every level regardless of its controller's level. Reconcilers set it for a single
+// resource that asks to be debugged.
+const DebugKey = "debug"
+
+// Levels holds the minimum level for each controller and a default for everything else.
+// Controllers are named as in --log-levels, e.g. "monitor" for the betterstackmonitor
+// controller.
+type Levels struct {
+	mu           sync.RWMutex
+	defaultLevel zapcore.LevelEnabler
+	levels       map[string]zapcore.Level
+}
+
+// New returns Levels that apply defaultLevel to every controller until overridden.
+func New(defaultLevel zapcore.LevelEnabler) *Levels {
+	return &Levels{defaultLevel: defaultLevel, levels: map[string]zapcore.Level{}}
+}
+
+// Set replaces the per-controller levels.
+func (l *Levels) Set(levels map[string]zapcore.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.levels = levels
+}
+
+// Enabled reports whether a log entry at level from controller should be written.
+func (l *Levels) Enabled(controller string, level zapcore.Level) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if min, ok := l.levels[shortName(controller)]; ok && controller != "" {
+		return level >= min
+	}
+	return l.defaultLevel.Enabled(level)
+}
+
+// WrapCore filters a zap core through the per-controller levels. The wrapped core must
+// accept every level; Levels applies the default itself.
+func (l *Levels) WrapCore(core zapcore.Core) zapcore.Core {
+	return &filteredCore{Core: core, levels: l}
+}
+
+// Parse reads "name=level" pairs separated by commas or newlines. Levels are debug,
+// info, error or a logr verbosity such as 2. Blank lines and lines starting with # are
+// ignored.
+func Parse(spec string) (map[string]zapcore.Level, error) {
+	levels := map[string]zapcore.Level{}
+	scanner := bufio.NewScanner(strings.NewReader(strings.ReplaceAll(spec, ",", "\n")))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, value, ok := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid log level %q: expected name=level", line)
+		}
+		level, err := parseLevel(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid log level for %s: %w", name, err)
+		}
+		levels[shortName(name)] = level
+	}
+	return levels, scanner.Err()
+}
+
+func parseLevel(value string) (zapcore.Level, error) {
+	if verbosity, err := strconv.Atoi(value); err == nil {
+		if verbosity < 0 {
+			return 0, fmt.Errorf("verbosity %d must not be negative", verbosity)
+		}
+		return zapcore.Level(-verbosity), nil
+	}
+	return zapcore.ParseLevel(value)
+}
+
+// shortName lets "monitor" and "betterstackmonitor" name the same controller.
+func shortName(controller string) string {
+	return strings.TrimPrefix(strings.ToLower(controller), "betterstack")
+}
+
+type filteredCore struct {
+	zapcore.Core
+	levels     *Levels
+	controller string
+	debug      bool
+}
+
+func (c *filteredCore) Enabled(level zapcore.Level) bool {
+	return c.debug || c.levels.Enabled(c.controller, level)
+}
+
+func (c *filteredCore) With(fields []zapcore.Field) zapcore.Core {
+	controller, debug := c.controller, c.debug
+	for _, field := range fields {
+		switch {
+		case field.Key == controllerKey && field.Type == zapcore.StringType:
+			controller = field.String
+		case field.Key == DebugKey && field.Type == zapcore.BoolType:
+			debug = field.Integer == 1
+		}
+	}
+	return &filteredCore{Core: c.Core.With(fields), levels: c.levels, controller: controller, debug: debug}
+}
+
+func (c *filteredCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(entry.Level) {
+		return checked
+	}
+	return c.Core.Check(entry, checked)
+}
+
+// FileWatcher reloads Levels from a file whenever it changes. It watches the file's
+// directory so the symlink swap Kubernetes performs when updating a ConfigMap volume is
+// picked up.
+type FileWatcher struct {
+	levels *Levels
+	path   string
+}
+
+// NewFileWatcher loads path into levels once and returns a watcher that keeps them in sync.
+func NewFileWatcher(levels *Levels, path string) (*FileWatcher, error) {
+	w := &FileWatcher{levels: levels, path: path}
+	if err := w.load(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *FileWatcher) load() error {
+	data, err := os.ReadFile(w.path)
+	if err != nil {
+		return fmt.Errorf("read log levels: %w", err)
+	}
+	levels, err := Parse(string(data))
+	if err != nil {
+		return err
+	}
+	w.levels.Set(levels)
+	return nil
+}
+
+// Start implements manager.Runnable. A file that fails to parse keeps the previous levels.
+func (w *FileWatcher) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("loglevel")
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+	if err := watcher.Add(filepath.Dir(w.path)); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Has(fsnotify.Chmod) {
+				continue
+			}
+			if err := w.load(); err != nil {
+				logger.Error(err, "unable to reload log levels", "path", w.path)
+				continue
+			}
+			logger.Info("reloaded log levels", "path", w.path)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			logger.Error(err, "log level watcher failed", "path", w.path)
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable; every replica logs.
+func (w *FileWatcher) NeedLeaderElection() bool {
+	return false
+}
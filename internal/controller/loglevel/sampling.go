@@ -0,0 +1,37 @@
+package loglevel
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Sampler returns a zap core wrapper that samples entries below error level: each second
+// the first entries with a given level and message are written, then every thereafter-th.
+// Errors are always written, so sampling only thins out repetitive info and debug output.
+// A first of zero or less disables sampling.
+func Sampler(first, thereafter int, opts ...zapcore.SamplerOption) func(zapcore.Core) zapcore.Core {
+	return func(core zapcore.Core) zapcore.Core {
+		if first <= 0 {
+			return core
+		}
+		return &sampledCore{Core: core, sampled: zapcore.NewSamplerWithOptions(core, time.Second, first, max(thereafter, 0), opts...)}
+	}
+}
+
+// sampledCore writes errors through the embedded core and everything else through sampled.
+type sampledCore struct {
+	zapcore.Core
+	sampled zapcore.Core
+}
+
+func (c *sampledCore) With(fields []zapcore.Field) zapcore.Core {
+	return &sampledCore{Core: c.Core.With(fields), sampled: c.sampled.With(fields)}
+}
+
+func (c *sampledCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if entry.Level >= zapcore.ErrorLevel {
+		return c.Core.Check(entry, checked)
+	}
+	return c.sampled.Check(entry, checked)
+}
@@ -0,0 +1,151 @@
+// Package metrics defines the Prometheus collectors exported by the operator.
+package metrics
+
+import (
+	"net/http/httptrace"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const namespace = "betterstack_operator"
+
+// DefaultAccountLabel is used for resources that read their token directly from a secret.
+const DefaultAccountLabel = "default"
+
+var (
+	// BuildInfo is always 1 and labels the running operator build.
+	BuildInfo = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "build_info",
+		Help:      "Always 1; labelled with the version, commit and Go version of the running operator.",
+	}, []string{"version", "commit", "go_version"})
+
+	// APIRequests counts Better Stack API requests by account, HTTP method and response code.
+	APIRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "api_requests_total",
+		Help:      "Better Stack API requests issued by the operator.",
+	}, []string{"account", "method", "code"})
+
+	// APIRateLimitWait observes how long requests waited on the account rate limiter.
+	APIRateLimitWait = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "api_rate_limit_wait_seconds",
+		Help:      "Time Better Stack API requests spent waiting on the per-account rate limiter.",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 4, 8),
+	}, []string{"account"})
+
+	// APIRateLimitRemaining reports the RateLimit-Remaining header of the latest Better
+	// Stack API response per account.
+	APIRateLimitRemaining = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "api_rate_limit_remaining",
+		Help:      "Requests remaining in the Better Stack API rate limit window, as last reported by the API.",
+	}, []string{"account"})
+
+	// DeprecatedFieldUsage counts reconciles of resources that set a deprecated spec field.
+	DeprecatedFieldUsage = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "deprecated_field_usage_total",
+		Help:      "Reconciles of resources whose spec sets a deprecated field.",
+	}, []string{"field"})
+
+	// APIConnections counts connections obtained for Better Stack API requests by whether an
+	// idle keep-alive connection was reused.
+	APIConnections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "api_connections_total",
+		Help:      "Connections obtained for Better Stack API requests, by whether an idle connection was reused.",
+	}, []string{"reused"})
+
+	// APIConnectionIdle observes how long reused connections sat idle in the pool.
+	APIConnectionIdle = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "api_connection_idle_seconds",
+		Help:      "Time reused Better Stack API connections spent idle before being picked up.",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 4, 8),
+	})
+
+	// QuotaUsed reports how many monitors and heartbeats exist in each account, as counted
+	// by the latest BetterStackSyncReport for it.
+	QuotaUsed = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "quota_used",
+		Help:      "Remote Better Stack resources in the account, by resource kind, as of the latest sync report.",
+	}, []string{"account", "resource"})
+
+	// QuotaLimit reports the plan limits recorded in spec.quota of each BetterStackCredential.
+	QuotaLimit = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "quota_limit",
+		Help:      "Plan limit of the Better Stack account, by resource kind, as configured on its BetterStackCredential.",
+	}, []string{"account", "resource"})
+
+	// RemoteRecreated counts resources whose remote ID changed after a successful sync,
+	// because the remote was missing and created anew, adopted from another remote or
+	// recreated to apply immutable fields.
+	RemoteRecreated = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "remote_recreated_total",
+		Help:      "Resources synced to a different Better Stack ID than the one previously recorded, by kind.",
+	}, []string{"kind"})
+
+	// FinalizerSkippedRemoteDelete counts finalizers removed without deleting the remote
+	// resource, which is then left orphaned in Better Stack.
+	FinalizerSkippedRemoteDelete = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "finalizer_skipped_remote_delete_total",
+		Help:      "Deleted resources whose finalizer was removed without deleting the Better Stack resource, by kind and reason.",
+	}, []string{"kind", "reason"})
+
+	// LogEntriesDropped counts log entries dropped by --log-sampling-initial and
+	// --log-sampling-thereafter.
+	LogEntriesDropped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "log_entries_dropped_total",
+		Help:      "Log entries dropped by log sampling, by level.",
+	}, []string{"level"})
+)
+
+// Reasons of FinalizerSkippedRemoteDelete.
+const (
+	// SkipReasonCredentialsUnavailable means the API token could not be resolved.
+	SkipReasonCredentialsUnavailable = "credentials_unavailable"
+	// SkipReasonDeleteFailed means Better Stack returned an error other than 404.
+	SkipReasonDeleteFailed = "delete_failed"
+)
+
+func init() {
+	crmetrics.Registry.MustRegister(BuildInfo, APIRequests, APIRateLimitWait, APIRateLimitRemaining, DeprecatedFieldUsage, APIConnections, APIConnectionIdle, QuotaUsed, QuotaLimit, RemoteRecreated, FinalizerSkippedRemoteDelete, LogEntriesDropped)
+}
+
+// ObserveConnection records connection reuse for a Better Stack API request. It is passed
+// to betterstack.WithConnectionTrace.
+func ObserveConnection(info httptrace.GotConnInfo) {
+	APIConnections.WithLabelValues(strconv.FormatBool(info.Reused)).Inc()
+	if info.WasIdle {
+		APIConnectionIdle.Observe(info.IdleTime.Seconds())
+	}
+}
+
+// AccountLabel maps an account name to its metric label value.
+func AccountLabel(account string) string {
+	if account == "" {
+		return DefaultAccountLabel
+	}
+	return account
+}
+
+// SetQuota records the usage of one resource kind in account and its plan limit. A zero
+// limit is unknown and removes the limit series rather than reporting a limit of zero.
+func SetQuota(account, resource string, used, limit int) {
+	label := AccountLabel(account)
+	QuotaUsed.WithLabelValues(label, resource).Set(float64(used))
+	if limit > 0 {
+		QuotaLimit.WithLabelValues(label, resource).Set(float64(limit))
+	} else {
+		QuotaLimit.DeleteLabelValues(label, resource)
+	}
+}
@@ -0,0 +1,50 @@
+// Package refs resolves ResourceRefs between operator resources and enforces whether they
+// may point into other namespaces.
+package refs
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+)
+
+// Policy controls which namespaces references may point into. The zero value only allows
+// references within the referencing resource's namespace.
+type Policy struct {
+	// AllowCrossNamespace lets references name an object in any namespace.
+	AllowCrossNamespace bool
+}
+
+// CrossNamespaceError reports a reference into another namespace that the policy forbids.
+type CrossNamespaceError struct {
+	// Namespace is the namespace of the referencing resource.
+	Namespace string
+	// Target is the object the reference points at.
+	Target types.NamespacedName
+}
+
+func (e *CrossNamespaceError) Error() string {
+	return fmt.Sprintf("reference to %s from namespace %s is not allowed; cross-namespace references require --allow-cross-namespace-refs", e.Target, e.Namespace)
+}
+
+// Target returns the object ref points at from a resource in namespace, without applying
+// any policy. Field indexes use it so that events for the target reach the referencing
+// resource, which then reports a policy violation itself.
+func Target(namespace string, ref monitoringv1alpha1.ResourceRef) types.NamespacedName {
+	if ref.Namespace != "" {
+		namespace = ref.Namespace
+	}
+	return types.NamespacedName{Namespace: namespace, Name: ref.Name}
+}
+
+// Resolve returns the object ref points at from a resource in namespace, or a
+// *CrossNamespaceError when the policy does not allow the reference.
+func (p Policy) Resolve(namespace string, ref monitoringv1alpha1.ResourceRef) (types.NamespacedName, error) {
+	target := Target(namespace, ref)
+	if target.Namespace != namespace && !p.AllowCrossNamespace {
+		return types.NamespacedName{}, &CrossNamespaceError{Namespace: namespace, Target: target}
+	}
+	return target, nil
+}
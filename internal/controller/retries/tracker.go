@@ -0,0 +1,111 @@
+// Package retries keeps track of resources waiting out a backoff after failed syncs.
+package retries
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Entry describes a resource whose sync with Better Stack failed and is waiting to retry.
+type Entry struct {
+	Kind          string    `json:"kind"`
+	Namespace     string    `json:"namespace"`
+	Name          string    `json:"name"`
+	Failures      int       `json:"failures"`
+	NextRetryTime time.Time `json:"nextRetryTime"`
+	Reason        string    `json:"reason,omitempty"`
+	LastError     string    `json:"lastError,omitempty"`
+}
+
+type key struct {
+	kind string
+	name types.NamespacedName
+}
+
+// Tracker records the resources currently backing off so operators can see why a resource
+// has not synced without reading logs. A nil Tracker records nothing.
+type Tracker struct {
+	mu      sync.Mutex
+	entries map[key]Entry
+}
+
+// NewTracker constructs an empty Tracker.
+func NewTracker() *Tracker {
+	return &Tracker{entries: map[key]Entry{}}
+}
+
+// Record stores entry, replacing the previous one for the same resource.
+func (t *Tracker) Record(entry Entry) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.entries[key{entry.Kind, types.NamespacedName{Namespace: entry.Namespace, Name: entry.Name}}] = entry
+}
+
+// Forget drops the entry of a resource that synced or was deleted.
+func (t *Tracker) Forget(kind string, name types.NamespacedName) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.entries, key{kind, name})
+}
+
+// Entries returns the recorded entries, soonest retry first.
+func (t *Tracker) Entries() []Entry {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	entries := make([]Entry, 0, len(t.entries))
+	for _, entry := range t.entries {
+		entries = append(entries, entry)
+	}
+	t.mu.Unlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].NextRetryTime.Equal(entries[j].NextRetryTime) {
+			return entries[i].NextRetryTime.Before(entries[j].NextRetryTime)
+		}
+		if entries[i].Kind != entries[j].Kind {
+			return entries[i].Kind < entries[j].Kind
+		}
+		if entries[i].Namespace != entries[j].Namespace {
+			return entries[i].Namespace < entries[j].Namespace
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
+
+// Handler serves the entries as JSON on GET, optionally limited by the kind and namespace
+// query parameters.
+func (t *Tracker) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			w.Header().Set("Allow", "GET")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		kind := req.URL.Query().Get("kind")
+		namespace := req.URL.Query().Get("namespace")
+		entries := []Entry{}
+		for _, entry := range t.Entries() {
+			if (kind == "" || entry.Kind == kind) && (namespace == "" || entry.Namespace == namespace) {
+				entries = append(entries, entry)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(entries)
+	})
+}
@@ -0,0 +1,154 @@
+// Package secretwatch re-enqueues resources when the Secret or BetterStackCredential that
+// supplies their Better Stack API token changes.
+package secretwatch
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/internal/controller/refs"
+)
+
+// Refs returns the names of the secrets a resource reads from, including its API token
+// secret, and the optional account it selects.
+type Refs[T client.Object] func(obj T) (secretNames []string, accountRef *monitoringv1alpha1.ResourceRef)
+
+// Watch indexes resources of type T by the credentials they reference and adds Secret and
+// BetterStackCredential watches to b that enqueue the dependent resources. name scopes the
+// index keys, e.g. "monitor" yields monitoring.betterstack.io/monitor-secret.
+func Watch[T client.Object](mgr ctrl.Manager, b *builder.Builder, name string, obj T, newList func() client.ObjectList, credentialRefs Refs[T]) (*builder.Builder, error) {
+	mapper := NewMapper(mgr.GetClient(), name, newList)
+
+	ctx := context.Background()
+	indexer := mgr.GetFieldIndexer()
+	if err := indexer.IndexField(ctx, obj, mapper.SecretIndexKey, func(o client.Object) []string {
+		typed, ok := o.(T)
+		if !ok {
+			return nil
+		}
+		secretNames, _ := credentialRefs(typed)
+		var values []string
+		for _, name := range secretNames {
+			if name != "" {
+				values = append(values, IndexValue(typed.GetNamespace(), name))
+			}
+		}
+		return values
+	}); err != nil {
+		return nil, err
+	}
+	if err := indexer.IndexField(ctx, obj, mapper.AccountIndexKey, func(o client.Object) []string {
+		typed, ok := o.(T)
+		if !ok {
+			return nil
+		}
+		_, accountRef := credentialRefs(typed)
+		if accountRef == nil || accountRef.Name == "" {
+			return nil
+		}
+		target := refs.Target(typed.GetNamespace(), *accountRef)
+		return []string{IndexValue(target.Namespace, target.Name)}
+	}); err != nil {
+		return nil, err
+	}
+
+	return b.
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(mapper.RequestsForSecret)).
+		Watches(&monitoringv1alpha1.BetterStackCredential{}, handler.EnqueueRequestsFromMapFunc(mapper.RequestsForCredential)), nil
+}
+
+// IndexValue formats the namespace/name value stored in the credential field indexes.
+func IndexValue(namespace, name string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
+
+// Mapper maps Secret and BetterStackCredential events to reconcile requests using the
+// field indexes registered by Watch.
+type Mapper struct {
+	Reader          client.Reader
+	NewList         func() client.ObjectList
+	SecretIndexKey  string
+	AccountIndexKey string
+}
+
+// NewMapper constructs a Mapper whose index keys are scoped by name.
+func NewMapper(reader client.Reader, name string, newList func() client.ObjectList) Mapper {
+	return Mapper{
+		Reader:          reader,
+		NewList:         newList,
+		SecretIndexKey:  fmt.Sprintf("monitoring.betterstack.io/%s-secret", name),
+		AccountIndexKey: fmt.Sprintf("monitoring.betterstack.io/%s-account", name),
+	}
+}
+
+// RequestsForSecret enqueues resources referencing the secret directly or through a
+// BetterStackCredential.
+func (m Mapper) RequestsForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		return nil
+	}
+	if secret.Namespace == "" || secret.Name == "" {
+		return nil
+	}
+
+	requests := m.requestsForIndex(ctx, secret.Namespace, m.SecretIndexKey, IndexValue(secret.Namespace, secret.Name))
+
+	credentials := &monitoringv1alpha1.BetterStackCredentialList{}
+	if err := m.Reader.List(ctx, credentials, client.InNamespace(secret.Namespace)); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list credentials for secret", "secret", IndexValue(secret.Namespace, secret.Name))
+		return requests
+	}
+	for _, credential := range credentials.Items {
+		if credential.Spec.APITokenSecretRef.Name != secret.Name {
+			continue
+		}
+		requests = append(requests, m.requestsForIndex(ctx, "", m.AccountIndexKey, IndexValue(credential.Namespace, credential.Name))...)
+	}
+	return requests
+}
+
+// RequestsForCredential enqueues resources selecting the credential through accountRef,
+// in any namespace.
+func (m Mapper) RequestsForCredential(ctx context.Context, obj client.Object) []reconcile.Request {
+	credential, ok := obj.(*monitoringv1alpha1.BetterStackCredential)
+	if !ok {
+		return nil
+	}
+	return m.requestsForIndex(ctx, "", m.AccountIndexKey, IndexValue(credential.Namespace, credential.Name))
+}
+
+func (m Mapper) requestsForIndex(ctx context.Context, namespace, indexKey, value string) []reconcile.Request {
+	list := m.NewList()
+	if err := m.Reader.List(ctx, list, client.InNamespace(namespace), client.MatchingFields{indexKey: value}); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list resources for index", "index", indexKey, "value", value)
+		return nil
+	}
+
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "unable to extract list items", "index", indexKey)
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(items))
+	for _, item := range items {
+		object, ok := item.(client.Object)
+		if !ok {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: object.GetNamespace(), Name: object.GetName()}})
+	}
+	return requests
+}
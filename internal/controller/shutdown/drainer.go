@@ -0,0 +1,92 @@
+// Package shutdown lets reconcilers finish in-flight Better Stack calls and status patches
+// when the manager stops, instead of abandoning them mid-request.
+package shutdown
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Drainer tracks in-flight reconciles. Once draining starts it refuses new reconciles and
+// waits, up to a timeout, for the running ones before cancelling their contexts. A nil
+// Drainer admits every reconcile and leaves its context untouched.
+type Drainer struct {
+	timeout time.Duration
+
+	mu       sync.Mutex
+	draining bool
+	inflight sync.WaitGroup
+
+	stop       context.Context
+	cancelStop context.CancelFunc
+}
+
+// NewDrainer constructs a Drainer that waits at most timeout for in-flight reconciles.
+func NewDrainer(timeout time.Duration) *Drainer {
+	stop, cancel := context.WithCancel(context.Background())
+	return &Drainer{timeout: timeout, stop: stop, cancelStop: cancel}
+}
+
+// Begin registers a reconcile. It returns a context that keeps the values of ctx but
+// survives its cancellation until the drain deadline, and a function to call when the
+// reconcile finishes. ok is false once draining has started; the caller should return
+// without doing any work.
+func (d *Drainer) Begin(ctx context.Context) (_ context.Context, done func(), ok bool) {
+	if d == nil {
+		return ctx, func() {}, true
+	}
+
+	d.mu.Lock()
+	if d.draining {
+		d.mu.Unlock()
+		return ctx, func() {}, false
+	}
+	d.inflight.Add(1)
+	d.mu.Unlock()
+
+	detached, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stopCancel := context.AfterFunc(d.stop, cancel)
+	return detached, func() {
+		stopCancel()
+		cancel()
+		d.inflight.Done()
+	}, true
+}
+
+// Drain stops admitting reconciles and waits for in-flight ones up to the timeout, then
+// cancels the contexts of any still running. It reports whether all of them finished.
+func (d *Drainer) Drain() bool {
+	d.mu.Lock()
+	d.draining = true
+	d.mu.Unlock()
+
+	finished := make(chan struct{})
+	go func() {
+		d.inflight.Wait()
+		close(finished)
+	}()
+
+	timer := time.NewTimer(d.timeout)
+	defer timer.Stop()
+	defer d.cancelStop()
+	select {
+	case <-finished:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// Start implements manager.Runnable: it drains once the manager begins shutting down.
+func (d *Drainer) Start(ctx context.Context) error {
+	<-ctx.Done()
+	d.Drain()
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Draining applies whether
+// or not this replica leads.
+func (d *Drainer) NeedLeaderElection() bool {
+	return false
+}
@@ -0,0 +1,49 @@
+// Package version reports the operator build, stamped at link time with
+//
+//	-ldflags "-X loks0n/betterstack-operator/internal/version.Version=v1.2.3 -X loks0n/betterstack-operator/internal/version.Commit=abc123"
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+var (
+	// Version is the release the binary was built from. Defaults to "dev".
+	Version = "dev"
+	// Commit is the git revision the binary was built from. When not stamped it is read
+	// from the VCS information Go embeds in the binary, if any.
+	Commit = ""
+)
+
+// Info describes the running build.
+type Info struct {
+	Version   string
+	Commit    string
+	GoVersion string
+}
+
+// Get returns the build information of the running binary.
+func Get() Info {
+	info := Info{Version: Version, Commit: Commit, GoVersion: runtime.Version()}
+	if info.Commit == "" {
+		info.Commit = vcsRevision()
+	}
+	if info.Commit == "" {
+		info.Commit = "unknown"
+	}
+	return info
+}
+
+func vcsRevision() string {
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	for _, setting := range build.Settings {
+		if setting.Key == "vcs.revision" {
+			return setting.Value
+		}
+	}
+	return ""
+}
@@ -1,18 +1,42 @@
 package main
 
 import (
+	"cmp"
+	"context"
 	"flag"
+	"fmt"
+	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
 	"loks0n/betterstack-operator/controllers"
+	"loks0n/betterstack-operator/internal/controller/accounts"
+	"loks0n/betterstack-operator/internal/controller/loglevel"
+	"loks0n/betterstack-operator/internal/controller/maintenance"
+	"loks0n/betterstack-operator/internal/controller/metrics"
+	"loks0n/betterstack-operator/internal/controller/refs"
+	"loks0n/betterstack-operator/internal/controller/retries"
+	"loks0n/betterstack-operator/internal/controller/shutdown"
+	"loks0n/betterstack-operator/internal/version"
+	"loks0n/betterstack-operator/pkg/betterstack"
 
+	uberzap "go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/config"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 )
 
@@ -27,36 +51,236 @@ func init() {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		os.Exit(runDiff(os.Args[2:], os.Stdout, os.Stderr))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "terraform" {
+		os.Exit(runTerraform(os.Args[2:], os.Stdout, os.Stderr))
+	}
+
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var apiMaxIdleConnsPerHost int
+	var apiIdleConnTimeout time.Duration
+	var apiHTTPVersion string
+	var apiKeepAlive time.Duration
+	var shutdownDrainTimeout time.Duration
+	var logLevels string
+	var logLevelsFile string
+	var enableWebhooks bool
+	var uniquenessScope string
+	var pingProxyAddr string
+	var adminAddr string
+	var allowCrossNamespaceRefs bool
+	var remoteCacheTTL time.Duration
+	var priorityQueue bool
+	var clusterName string
+	var defaultBaseURL string
+	var alertRoutes bool
+	var maintenanceConfigMap string
+	var logSamplingInitial int
+	var logSamplingThereafter int
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false, "Enable leader election for controller manager.")
+	flag.IntVar(&apiMaxIdleConnsPerHost, "api-max-idle-conns-per-host", 16, "Idle keep-alive connections to the Better Stack API retained for reuse.")
+	flag.DurationVar(&apiIdleConnTimeout, "api-idle-conn-timeout", 90*time.Second, "How long idle Better Stack API connections are retained.")
+	flag.StringVar(&apiHTTPVersion, "api-http-version", "auto", "HTTP version used for the Better Stack API: auto, 1.1 or 2. Use 1.1 behind proxies that mishandle HTTP/2.")
+	flag.DurationVar(&apiKeepAlive, "api-keepalive", 30*time.Second, "TCP keep-alive and HTTP/2 ping interval for Better Stack API connections. A negative value disables keep-alives.")
+	flag.DurationVar(&shutdownDrainTimeout, "shutdown-drain-timeout", 20*time.Second, "How long in-flight reconciles may keep running after a shutdown signal before their Better Stack calls are cancelled.")
+	flag.StringVar(&logLevels, "log-levels", "", "Per-controller log levels as name=level pairs, e.g. monitor=debug,heartbeat=info. Levels are debug, info, error or a verbosity number.")
+	flag.StringVar(&logLevelsFile, "log-levels-file", "", "File with per-controller log levels, one name=level per line. It is reloaded on change and takes precedence over --log-levels.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false, "Serve the validating admission webhooks on port 9443. Requires a serving certificate in /tmp/k8s-webhook-server/serving-certs.")
+	flag.StringVar(&uniquenessScope, "uniqueness-scope", controllers.UniquenessNamespace, "Scope in which the webhook rejects monitors with the same URL and name and heartbeats with the same name: namespace or cluster.")
+	flag.BoolVar(&allowCrossNamespaceRefs, "allow-cross-namespace-refs", false, "Allow accountRef to select a BetterStackCredential in another namespace.")
 	opts := zap.Options{Development: true}
+	flag.BoolVar(&priorityQueue, "priority-queue", false, "Reconcile created and edited resources ahead of those queued by the startup list and cache resyncs. Opt-in while the controller-runtime priority queue is experimental.")
+	flag.DurationVar(&remoteCacheTTL, "remote-cache-ttl", 0, "How long a monitor returned by Better Stack replaces the remote Get on the next reconcile. Zero fetches the monitor on every reconcile.")
+	flag.StringVar(&clusterName, "cluster-name", "", "Name of this cluster. It replaces $(CLUSTER_NAME) in spec.name and is recorded in status.clusterName.")
+	flag.StringVar(&defaultBaseURL, "default-base-url", "", "Better Stack API base URL for resources whose spec and credential set none. Empty uses the public API.")
+	flag.BoolVar(&alertRoutes, "enable-alert-routes", false, "Apply BetterStackAlertRoute policies and contact settings to the monitors they select.")
+	flag.StringVar(&adminAddr, "admin-bind-address", "", "The address the authenticated HTTPS admin endpoint binds to, serving /backoff to callers allowed to get that non-resource URL. Empty disables it.")
+	flag.StringVar(&pingProxyAddr, "ping-proxy-bind-address", "", "The address the heartbeat ping proxy binds to, serving /ping/{namespace}/{name}. Empty disables the proxy.")
+	flag.StringVar(&maintenanceConfigMap, "maintenance-configmap", "", "ConfigMap, as namespace/name, whose active and labelSelector keys pause managed monitors for cluster maintenance. Empty disables the maintenance switch.")
+	flag.IntVar(&logSamplingInitial, "log-sampling-initial", 100, "Log entries with the same level and message written per second before sampling starts. Errors are never sampled. Applies with --zap-devel=false; zero disables sampling.")
+	flag.IntVar(&logSamplingThereafter, "log-sampling-thereafter", 100, "Once sampling starts, write every nth entry with the same level and message for the rest of the second. Zero drops them all.")
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
+	// Controllers without an entry in --log-levels keep the --zap-log-level default.
+	defaultLevel := opts.Level
+	if defaultLevel == nil {
+		defaultLevel = zapcore.InfoLevel
+		if opts.Development {
+			defaultLevel = zapcore.DebugLevel
+		}
+	}
+	levels := loglevel.New(defaultLevel)
+	// The levels below are enforced by the wrapped core, which also disables
+	// controller-runtime's own production sampling; sample here instead, sparing errors.
+	opts.Level = uberzap.LevelEnablerFunc(func(zapcore.Level) bool { return true })
+	if !opts.Development {
+		opts.ZapOpts = append(opts.ZapOpts, uberzap.WrapCore(loglevel.Sampler(logSamplingInitial, logSamplingThereafter, zapcore.SamplerHook(countDroppedLogEntry))))
+	}
+	opts.ZapOpts = append(opts.ZapOpts, uberzap.WrapCore(levels.WrapCore))
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	build := version.Get()
+	setupLog.Info("betterstack-operator build", "version", build.Version, "commit", build.Commit, "goVersion", build.GoVersion)
+	setupLog.Info("effective configuration",
+		"development", opts.Development,
+		"logLevel", defaultLevel,
+		"logSampling", logSampling(opts.Development, logSamplingInitial, logSamplingThereafter),
+		"leaderElection", enableLeaderElection,
+		"metricsBindAddress", metricsAddr,
+		"healthProbeBindAddress", probeAddr,
+		"apiHTTPVersion", apiHTTPVersion,
+		"apiMaxIdleConnsPerHost", apiMaxIdleConnsPerHost,
+		"apiIdleConnTimeout", apiIdleConnTimeout.String(),
+		"apiKeepAlive", apiKeepAlive.String(),
+		"shutdownDrainTimeout", shutdownDrainTimeout.String(),
+		"defaultBaseURL", cmp.Or(defaultBaseURL, betterstack.DefaultBaseURL),
+		"clusterName", clusterName,
+		"allowCrossNamespaceRefs", allowCrossNamespaceRefs,
+		"priorityQueue", priorityQueue,
+		"remoteCacheTTL", remoteCacheTTL.String(),
+	)
+	metrics.BuildInfo.WithLabelValues(build.Version, build.Commit, build.GoVersion).Set(1)
+
+	parsedLevels, err := loglevel.Parse(logLevels)
+	if err != nil {
+		setupLog.Error(err, "invalid --log-levels")
+		os.Exit(1)
+	}
+	levels.Set(parsedLevels)
+	var levelsWatcher *loglevel.FileWatcher
+	if logLevelsFile != "" {
+		if levelsWatcher, err = loglevel.NewFileWatcher(levels, logLevelsFile); err != nil {
+			setupLog.Error(err, "unable to load --log-levels-file")
+			os.Exit(1)
+		}
+	}
+
+	httpVersion, err := betterstack.ParseHTTPVersion(apiHTTPVersion)
+	if err != nil {
+		setupLog.Error(err, "invalid --api-http-version")
+		os.Exit(1)
+	}
+
+	maintenanceSwitch := maintenance.NewSwitch()
+	var maintenanceKey types.NamespacedName
+	cacheOptions := cache.Options{}
+	if maintenanceConfigMap != "" {
+		namespace, name, ok := strings.Cut(maintenanceConfigMap, "/")
+		if !ok || namespace == "" || name == "" {
+			setupLog.Error(nil, "invalid --maintenance-configmap: expected namespace/name", "value", maintenanceConfigMap)
+			os.Exit(1)
+		}
+		maintenanceKey = types.NamespacedName{Namespace: namespace, Name: name}
+		// Only the maintenance ConfigMap is read, so cache nothing else.
+		cacheOptions.ByObject = map[client.Object]cache.ByObject{
+			&corev1.ConfigMap{}: {
+				Namespaces: map[string]cache.Config{namespace: {}},
+				Field:      fields.OneTermEqualSelector("metadata.name", name),
+			},
+		}
+	}
+	retryTracker := retries.NewTracker()
+	drainer := shutdown.NewDrainer(shutdownDrainTimeout)
+	// Leave the manager room to stop the remaining runnables after the drain.
+	gracefulShutdownTimeout := shutdownDrainTimeout + 10*time.Second
+
+	references := refs.Policy{AllowCrossNamespace: allowCrossNamespaceRefs}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme: scheme,
 		Metrics: server.Options{
 			BindAddress: metricsAddr,
 		},
-		HealthProbeBindAddress: probeAddr,
-		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "ba97f330.monitoring.betterstack.io",
+		Cache:                   cacheOptions,
+		HealthProbeBindAddress:  probeAddr,
+		LeaderElection:          enableLeaderElection,
+		LeaderElectionID:        "ba97f330.monitoring.betterstack.io",
+		GracefulShutdownTimeout: &gracefulShutdownTimeout,
+		// With --priority-queue, events from the initial list and periodic resyncs are
+		// queued at low priority, so an edit made while thousands of resources are being
+		// resynced is picked up next instead of waiting its turn. It stays opt-in while
+		// controller-runtime marks the priority queue experimental.
+		Controller: config.Controller{UsePriorityQueue: &priorityQueue},
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
+	if err := mgr.Add(drainer); err != nil {
+		setupLog.Error(err, "unable to set up shutdown drainer")
+		os.Exit(1)
+	}
+	if levelsWatcher != nil {
+		if err := mgr.Add(levelsWatcher); err != nil {
+			setupLog.Error(err, "unable to set up log level reload")
+			os.Exit(1)
+		}
+	}
+
+	if adminAddr != "" {
+		// Resource names and sync errors are not for every pod that can reach the
+		// manager, so the admin endpoint checks the caller with a TokenReview and a
+		// SubjectAccessReview for the requested path.
+		adminServer, err := server.NewServer(server.Options{
+			BindAddress:    adminAddr,
+			SecureServing:  true,
+			FilterProvider: filters.WithAuthenticationAndAuthorization,
+			ExtraHandlers: map[string]http.Handler{
+				"/backoff": retryTracker.Handler(),
+			},
+		}, mgr.GetConfig(), mgr.GetHTTPClient())
+		if err != nil {
+			setupLog.Error(err, "unable to create admin server")
+			os.Exit(1)
+		}
+		if err := mgr.Add(adminServer); err != nil {
+			setupLog.Error(err, "unable to set up admin server")
+			os.Exit(1)
+		}
+	}
+
+	if maintenanceConfigMap != "" {
+		maintenanceReconciler := &controllers.MaintenanceReconciler{Client: mgr.GetClient(), Switch: maintenanceSwitch, ConfigMap: maintenanceKey}
+		if err := maintenanceReconciler.Load(context.Background(), mgr.GetAPIReader()); err != nil {
+			setupLog.Error(err, "unable to read maintenance ConfigMap")
+			os.Exit(1)
+		}
+		if err := maintenanceReconciler.SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Maintenance")
+			os.Exit(1)
+		}
+	}
+
+	accountRegistry := accounts.NewRegistry()
+	apiHTTPClient := betterstack.TuneHTTPClient(&http.Client{Timeout: 30 * time.Second},
+		betterstack.WithMaxIdleConnsPerHost(apiMaxIdleConnsPerHost),
+		betterstack.WithIdleConnTimeout(apiIdleConnTimeout),
+		betterstack.WithHTTPVersion(httpVersion),
+		betterstack.WithKeepAlive(apiKeepAlive),
+	)
 
 	reconciler := &controllers.BetterStackMonitorReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:         mgr.GetClient(),
+		Scheme:         mgr.GetScheme(),
+		HTTPClient:     apiHTTPClient,
+		Accounts:       accountRegistry,
+		Retries:        retryTracker,
+		Recorder:       mgr.GetEventRecorderFor("betterstackmonitor-controller"),
+		Maintenance:    maintenanceSwitch,
+		Drainer:        drainer,
+		References:     references,
+		RemoteCacheTTL: remoteCacheTTL,
+		ClusterName:    clusterName,
+		DefaultBaseURL: defaultBaseURL,
+		AlertRoutes:    alertRoutes,
 	}
 
 	if err := reconciler.SetupWithManager(mgr); err != nil {
@@ -65,18 +289,40 @@ func main() {
 	}
 
 	heartbeatReconciler := &controllers.BetterStackHeartbeatReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:         mgr.GetClient(),
+		Scheme:         mgr.GetScheme(),
+		HTTPClient:     apiHTTPClient,
+		Accounts:       accountRegistry,
+		Retries:        retryTracker,
+		Recorder:       mgr.GetEventRecorderFor("betterstackheartbeat-controller"),
+		Drainer:        drainer,
+		References:     references,
+		ClusterName:    clusterName,
+		DefaultBaseURL: defaultBaseURL,
 	}
 
 	if err := heartbeatReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "BetterStackHeartbeat")
 		os.Exit(1)
 	}
+	if pingProxyAddr != "" {
+		if err := mgr.Add(&controllers.PingProxy{Heartbeats: heartbeatReconciler, Addr: pingProxyAddr}); err != nil {
+			setupLog.Error(err, "unable to set up heartbeat ping proxy")
+			os.Exit(1)
+		}
+	}
 
 	monitorGroupReconciler := &controllers.BetterStackMonitorGroupReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:         mgr.GetClient(),
+		Scheme:         mgr.GetScheme(),
+		HTTPClient:     apiHTTPClient,
+		Accounts:       accountRegistry,
+		Retries:        retryTracker,
+		Recorder:       mgr.GetEventRecorderFor("betterstackmonitorgroup-controller"),
+		Drainer:        drainer,
+		References:     references,
+		ClusterName:    clusterName,
+		DefaultBaseURL: defaultBaseURL,
 	}
 
 	if err := monitorGroupReconciler.SetupWithManager(mgr); err != nil {
@@ -84,6 +330,50 @@ func main() {
 		os.Exit(1)
 	}
 
+	syncReportReconciler := &controllers.BetterStackSyncReportReconciler{
+		Client:         mgr.GetClient(),
+		Scheme:         mgr.GetScheme(),
+		HTTPClient:     apiHTTPClient,
+		Accounts:       accountRegistry,
+		Drainer:        drainer,
+		References:     references,
+		DefaultBaseURL: defaultBaseURL,
+	}
+
+	if err := syncReportReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "BetterStackSyncReport")
+		os.Exit(1)
+	}
+
+	if alertRoutes {
+		alertRouteReconciler := &controllers.BetterStackAlertRouteReconciler{
+			Client: mgr.GetClient(),
+			Scheme: mgr.GetScheme(),
+		}
+		if err := alertRouteReconciler.SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "BetterStackAlertRoute")
+			os.Exit(1)
+		}
+	}
+
+	if enableWebhooks {
+		validator := &controllers.UniquenessValidator{
+			Client: mgr.GetClient(),
+			Scope:  uniquenessScope,
+			Verifier: &controllers.AdmissionVerifier{
+				Client:         mgr.GetClient(),
+				HTTPClient:     apiHTTPClient,
+				Accounts:       accountRegistry,
+				References:     references,
+				DefaultBaseURL: defaultBaseURL,
+			},
+		}
+		if err := validator.SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "uniqueness")
+			os.Exit(1)
+		}
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -93,9 +383,28 @@ func main() {
 		os.Exit(1)
 	}
 
-	setupLog.Info("starting manager")
+	enabledControllers := []string{"monitor", "heartbeat", "monitorgroup", "syncreport"}
+	if alertRoutes {
+		enabledControllers = append(enabledControllers, "alertroute")
+	}
+	setupLog.Info("starting manager", "controllers", enabledControllers, "webhooks", enableWebhooks, "pingProxyBindAddress", pingProxyAddr)
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
 }
+
+// countDroppedLogEntry is the sampler hook behind metrics.LogEntriesDropped.
+func countDroppedLogEntry(entry zapcore.Entry, decision zapcore.SamplingDecision) {
+	if decision&zapcore.LogDropped != 0 {
+		metrics.LogEntriesDropped.WithLabelValues(entry.Level.String()).Inc()
+	}
+}
+
+// logSampling describes the effective log sampling for the startup log.
+func logSampling(development bool, initial, thereafter int) string {
+	if development || initial <= 0 {
+		return "disabled"
+	}
+	return fmt.Sprintf("first %d then every %d per second, errors unsampled", initial, thereafter)
+}
@@ -0,0 +1,376 @@
+// Package betterstackfakes provides fakes of the betterstack client interfaces for tests.
+// Each fake counts calls per method, records the last create and update request, and
+// delegates to the matching Fn field when it is set; otherwise methods return zero values
+// and a nil error.
+package betterstackfakes
+
+import (
+	"context"
+	"sync"
+
+	"loks0n/betterstack-operator/pkg/betterstack"
+)
+
+// MonitorClient is a fake betterstack.MonitorClient.
+type MonitorClient struct {
+	GetFn    func(ctx context.Context, id string) (betterstack.Monitor, error)
+	CreateFn func(ctx context.Context, req betterstack.MonitorCreateRequest) (betterstack.Monitor, error)
+	UpdateFn func(ctx context.Context, id string, req betterstack.MonitorUpdateRequest) (betterstack.Monitor, error)
+	DeleteFn func(ctx context.Context, id string) error
+	ListFn   func(ctx context.Context) ([]betterstack.Monitor, error)
+
+	GetCalls    int
+	CreateCalls int
+	UpdateCalls int
+	DeleteCalls int
+	ListCalls   int
+
+	LastCreateReq betterstack.MonitorCreateRequest
+	LastUpdateReq betterstack.MonitorUpdateRequest
+
+	mu sync.Mutex
+}
+
+// Get implements betterstack.MonitorClient.
+func (f *MonitorClient) Get(ctx context.Context, id string) (betterstack.Monitor, error) {
+	f.mu.Lock()
+	f.GetCalls++
+	fn := f.GetFn
+	f.mu.Unlock()
+	if fn != nil {
+		return fn(ctx, id)
+	}
+	return betterstack.Monitor{}, nil
+}
+
+// Create implements betterstack.MonitorClient.
+func (f *MonitorClient) Create(ctx context.Context, req betterstack.MonitorCreateRequest) (betterstack.Monitor, error) {
+	f.mu.Lock()
+	f.CreateCalls++
+	f.LastCreateReq = req
+	fn := f.CreateFn
+	f.mu.Unlock()
+	if fn != nil {
+		return fn(ctx, req)
+	}
+	return betterstack.Monitor{}, nil
+}
+
+// Update implements betterstack.MonitorClient.
+func (f *MonitorClient) Update(ctx context.Context, id string, req betterstack.MonitorUpdateRequest) (betterstack.Monitor, error) {
+	f.mu.Lock()
+	f.UpdateCalls++
+	f.LastUpdateReq = req
+	fn := f.UpdateFn
+	f.mu.Unlock()
+	if fn != nil {
+		return fn(ctx, id, req)
+	}
+	return betterstack.Monitor{}, nil
+}
+
+// Delete implements betterstack.MonitorClient.
+func (f *MonitorClient) Delete(ctx context.Context, id string) error {
+	f.mu.Lock()
+	f.DeleteCalls++
+	fn := f.DeleteFn
+	f.mu.Unlock()
+	if fn != nil {
+		return fn(ctx, id)
+	}
+	return nil
+}
+
+// List implements betterstack.MonitorClient.
+func (f *MonitorClient) List(ctx context.Context) ([]betterstack.Monitor, error) {
+	f.mu.Lock()
+	f.ListCalls++
+	fn := f.ListFn
+	f.mu.Unlock()
+	if fn != nil {
+		return fn(ctx)
+	}
+	return nil, nil
+}
+
+var _ betterstack.MonitorClient = (*MonitorClient)(nil)
+
+// HeartbeatClient is a fake betterstack.HeartbeatClient.
+type HeartbeatClient struct {
+	GetFn    func(ctx context.Context, id string) (betterstack.Heartbeat, error)
+	CreateFn func(ctx context.Context, req betterstack.HeartbeatCreateRequest) (betterstack.Heartbeat, error)
+	UpdateFn func(ctx context.Context, id string, req betterstack.HeartbeatUpdateRequest) (betterstack.Heartbeat, error)
+	DeleteFn func(ctx context.Context, id string) error
+	ListFn   func(ctx context.Context) ([]betterstack.Heartbeat, error)
+
+	GetCalls    int
+	CreateCalls int
+	UpdateCalls int
+	DeleteCalls int
+	ListCalls   int
+
+	LastCreateReq betterstack.HeartbeatCreateRequest
+	LastUpdateReq betterstack.HeartbeatUpdateRequest
+
+	mu sync.Mutex
+}
+
+// Get implements betterstack.HeartbeatClient.
+func (f *HeartbeatClient) Get(ctx context.Context, id string) (betterstack.Heartbeat, error) {
+	f.mu.Lock()
+	f.GetCalls++
+	fn := f.GetFn
+	f.mu.Unlock()
+	if fn != nil {
+		return fn(ctx, id)
+	}
+	return betterstack.Heartbeat{}, nil
+}
+
+// Create implements betterstack.HeartbeatClient.
+func (f *HeartbeatClient) Create(ctx context.Context, req betterstack.HeartbeatCreateRequest) (betterstack.Heartbeat, error) {
+	f.mu.Lock()
+	f.CreateCalls++
+	f.LastCreateReq = req
+	fn := f.CreateFn
+	f.mu.Unlock()
+	if fn != nil {
+		return fn(ctx, req)
+	}
+	return betterstack.Heartbeat{}, nil
+}
+
+// Update implements betterstack.HeartbeatClient.
+func (f *HeartbeatClient) Update(ctx context.Context, id string, req betterstack.HeartbeatUpdateRequest) (betterstack.Heartbeat, error) {
+	f.mu.Lock()
+	f.UpdateCalls++
+	f.LastUpdateReq = req
+	fn := f.UpdateFn
+	f.mu.Unlock()
+	if fn != nil {
+		return fn(ctx, id, req)
+	}
+	return betterstack.Heartbeat{}, nil
+}
+
+// Delete implements betterstack.HeartbeatClient.
+func (f *HeartbeatClient) Delete(ctx context.Context, id string) error {
+	f.mu.Lock()
+	f.DeleteCalls++
+	fn := f.DeleteFn
+	f.mu.Unlock()
+	if fn != nil {
+		return fn(ctx, id)
+	}
+	return nil
+}
+
+// List implements betterstack.HeartbeatClient.
+func (f *HeartbeatClient) List(ctx context.Context) ([]betterstack.Heartbeat, error) {
+	f.mu.Lock()
+	f.ListCalls++
+	fn := f.ListFn
+	f.mu.Unlock()
+	if fn != nil {
+		return fn(ctx)
+	}
+	return nil, nil
+}
+
+var _ betterstack.HeartbeatClient = (*HeartbeatClient)(nil)
+
+// MonitorGroupClient is a fake betterstack.MonitorGroupClient.
+type MonitorGroupClient struct {
+	GetFn          func(ctx context.Context, id string) (betterstack.MonitorGroup, error)
+	CreateFn       func(ctx context.Context, req betterstack.MonitorGroupCreateRequest) (betterstack.MonitorGroup, error)
+	UpdateFn       func(ctx context.Context, id string, req betterstack.MonitorGroupUpdateRequest) (betterstack.MonitorGroup, error)
+	DeleteFn       func(ctx context.Context, id string) error
+	ListFn         func(ctx context.Context) ([]betterstack.MonitorGroup, error)
+	ListMonitorsFn func(ctx context.Context, groupID string) ([]betterstack.Monitor, error)
+
+	GetCalls          int
+	CreateCalls       int
+	UpdateCalls       int
+	DeleteCalls       int
+	ListCalls         int
+	ListMonitorsCalls int
+
+	LastCreateReq betterstack.MonitorGroupCreateRequest
+	LastUpdateReq betterstack.MonitorGroupUpdateRequest
+
+	mu sync.Mutex
+}
+
+// Get implements betterstack.MonitorGroupClient.
+func (f *MonitorGroupClient) Get(ctx context.Context, id string) (betterstack.MonitorGroup, error) {
+	f.mu.Lock()
+	f.GetCalls++
+	fn := f.GetFn
+	f.mu.Unlock()
+	if fn != nil {
+		return fn(ctx, id)
+	}
+	return betterstack.MonitorGroup{}, nil
+}
+
+// Create implements betterstack.MonitorGroupClient.
+func (f *MonitorGroupClient) Create(ctx context.Context, req betterstack.MonitorGroupCreateRequest) (betterstack.MonitorGroup, error) {
+	f.mu.Lock()
+	f.CreateCalls++
+	f.LastCreateReq = req
+	fn := f.CreateFn
+	f.mu.Unlock()
+	if fn != nil {
+		return fn(ctx, req)
+	}
+	return betterstack.MonitorGroup{}, nil
+}
+
+// Update implements betterstack.MonitorGroupClient.
+func (f *MonitorGroupClient) Update(ctx context.Context, id string, req betterstack.MonitorGroupUpdateRequest) (betterstack.MonitorGroup, error) {
+	f.mu.Lock()
+	f.UpdateCalls++
+	f.LastUpdateReq = req
+	fn := f.UpdateFn
+	f.mu.Unlock()
+	if fn != nil {
+		return fn(ctx, id, req)
+	}
+	return betterstack.MonitorGroup{}, nil
+}
+
+// Delete implements betterstack.MonitorGroupClient.
+func (f *MonitorGroupClient) Delete(ctx context.Context, id string) error {
+	f.mu.Lock()
+	f.DeleteCalls++
+	fn := f.DeleteFn
+	f.mu.Unlock()
+	if fn != nil {
+		return fn(ctx, id)
+	}
+	return nil
+}
+
+// List implements betterstack.MonitorGroupClient.
+func (f *MonitorGroupClient) List(ctx context.Context) ([]betterstack.MonitorGroup, error) {
+	f.mu.Lock()
+	f.ListCalls++
+	fn := f.ListFn
+	f.mu.Unlock()
+	if fn != nil {
+		return fn(ctx)
+	}
+	return nil, nil
+}
+
+// ListMonitors implements betterstack.MonitorGroupClient.
+func (f *MonitorGroupClient) ListMonitors(ctx context.Context, groupID string) ([]betterstack.Monitor, error) {
+	f.mu.Lock()
+	f.ListMonitorsCalls++
+	fn := f.ListMonitorsFn
+	f.mu.Unlock()
+	if fn != nil {
+		return fn(ctx, groupID)
+	}
+	return nil, nil
+}
+
+var _ betterstack.MonitorGroupClient = (*MonitorGroupClient)(nil)
+
+// HeartbeatGroupClient is a fake betterstack.HeartbeatGroupClient.
+type HeartbeatGroupClient struct {
+	GetFn            func(ctx context.Context, id string) (betterstack.HeartbeatGroup, error)
+	CreateFn         func(ctx context.Context, req betterstack.HeartbeatGroupCreateRequest) (betterstack.HeartbeatGroup, error)
+	UpdateFn         func(ctx context.Context, id string, req betterstack.HeartbeatGroupUpdateRequest) (betterstack.HeartbeatGroup, error)
+	DeleteFn         func(ctx context.Context, id string) error
+	ListFn           func(ctx context.Context) ([]betterstack.HeartbeatGroup, error)
+	ListHeartbeatsFn func(ctx context.Context, groupID string, opts ...betterstack.ListOption) ([]betterstack.Heartbeat, error)
+
+	GetCalls            int
+	CreateCalls         int
+	UpdateCalls         int
+	DeleteCalls         int
+	ListCalls           int
+	ListHeartbeatsCalls int
+
+	LastCreateReq betterstack.HeartbeatGroupCreateRequest
+	LastUpdateReq betterstack.HeartbeatGroupUpdateRequest
+
+	mu sync.Mutex
+}
+
+// Get implements betterstack.HeartbeatGroupClient.
+func (f *HeartbeatGroupClient) Get(ctx context.Context, id string) (betterstack.HeartbeatGroup, error) {
+	f.mu.Lock()
+	f.GetCalls++
+	fn := f.GetFn
+	f.mu.Unlock()
+	if fn != nil {
+		return fn(ctx, id)
+	}
+	return betterstack.HeartbeatGroup{}, nil
+}
+
+// Create implements betterstack.HeartbeatGroupClient.
+func (f *HeartbeatGroupClient) Create(ctx context.Context, req betterstack.HeartbeatGroupCreateRequest) (betterstack.HeartbeatGroup, error) {
+	f.mu.Lock()
+	f.CreateCalls++
+	f.LastCreateReq = req
+	fn := f.CreateFn
+	f.mu.Unlock()
+	if fn != nil {
+		return fn(ctx, req)
+	}
+	return betterstack.HeartbeatGroup{}, nil
+}
+
+// Update implements betterstack.HeartbeatGroupClient.
+func (f *HeartbeatGroupClient) Update(ctx context.Context, id string, req betterstack.HeartbeatGroupUpdateRequest) (betterstack.HeartbeatGroup, error) {
+	f.mu.Lock()
+	f.UpdateCalls++
+	f.LastUpdateReq = req
+	fn := f.UpdateFn
+	f.mu.Unlock()
+	if fn != nil {
+		return fn(ctx, id, req)
+	}
+	return betterstack.HeartbeatGroup{}, nil
+}
+
+// Delete implements betterstack.HeartbeatGroupClient.
+func (f *HeartbeatGroupClient) Delete(ctx context.Context, id string) error {
+	f.mu.Lock()
+	f.DeleteCalls++
+	fn := f.DeleteFn
+	f.mu.Unlock()
+	if fn != nil {
+		return fn(ctx, id)
+	}
+	return nil
+}
+
+// List implements betterstack.HeartbeatGroupClient.
+func (f *HeartbeatGroupClient) List(ctx context.Context) ([]betterstack.HeartbeatGroup, error) {
+	f.mu.Lock()
+	f.ListCalls++
+	fn := f.ListFn
+	f.mu.Unlock()
+	if fn != nil {
+		return fn(ctx)
+	}
+	return nil, nil
+}
+
+// ListHeartbeats implements betterstack.HeartbeatGroupClient.
+func (f *HeartbeatGroupClient) ListHeartbeats(ctx context.Context, groupID string, opts ...betterstack.ListOption) ([]betterstack.Heartbeat, error) {
+	f.mu.Lock()
+	f.ListHeartbeatsCalls++
+	fn := f.ListHeartbeatsFn
+	f.mu.Unlock()
+	if fn != nil {
+		return fn(ctx, groupID, opts...)
+	}
+	return nil, nil
+}
+
+var _ betterstack.HeartbeatGroupClient = (*HeartbeatGroupClient)(nil)
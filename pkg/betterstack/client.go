@@ -8,11 +8,16 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptrace"
+	"strconv"
 	"strings"
 	"time"
+
+	"golang.org/x/time/rate"
 )
 
-const defaultBaseURL = "https://uptime.betterstack.com/api/v2"
+// DefaultBaseURL is the public Better Stack Uptime API used when no base URL is configured.
+const DefaultBaseURL = "https://uptime.betterstack.com/api/v2"
 
 // Client interacts with the Better Stack REST API.
 type Client struct {
@@ -20,6 +25,17 @@ type Client struct {
 	token      string
 	httpClient *http.Client
 
+	userAgent    string
+	maxRetries   int
+	retryBackoff time.Duration
+	limiter      *rate.Limiter
+
+	transportSettings transportSettings
+	connTrace         func(httptrace.GotConnInfo)
+	warningHandler    func(context.Context, Warning)
+	requestObserver   func(context.Context, RequestResult)
+	useNumber         bool
+
 	Monitors        *MonitorService
 	MonitorGroups   *MonitorGroupService
 	Heartbeats      *HeartbeatService
@@ -30,6 +46,14 @@ type Client struct {
 type APIError struct {
 	StatusCode int
 	Message    string
+
+	// RequestID is the X-Request-Id Better Stack assigned to the request; quote it when
+	// contacting support.
+	RequestID string
+	// RateLimitRemaining is the RateLimit-Remaining header, or nil when absent.
+	RateLimitRemaining *int
+	// RetryAfter is the delay requested by the Retry-After header, or zero when absent.
+	RetryAfter time.Duration
 }
 
 // Error implements the error interface.
@@ -37,13 +61,25 @@ func (e *APIError) Error() string {
 	if e == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("better uptime api returned %d: %s", e.StatusCode, e.Message)
+	msg := fmt.Sprintf("better uptime api returned %d: %s", e.StatusCode, e.Message)
+	var details []string
+	if e.RetryAfter > 0 {
+		details = append(details, fmt.Sprintf("retry after %s", e.RetryAfter))
+	}
+	if e.RequestID != "" {
+		details = append(details, fmt.Sprintf("request id %s", e.RequestID))
+	}
+	if len(details) > 0 {
+		msg += " (" + strings.Join(details, ", ") + ")"
+	}
+	return msg
 }
 
-// NewClient creates a Better Stack API client.
-func NewClient(baseURL, token string, httpClient *http.Client) *Client {
+// NewClient creates a Better Stack API client. An empty baseURL selects the public
+// Better Stack Uptime API and a nil httpClient a client with a 30 second timeout.
+func NewClient(baseURL, token string, httpClient *http.Client, opts ...Option) *Client {
 	if baseURL == "" {
-		baseURL = defaultBaseURL
+		baseURL = DefaultBaseURL
 	}
 	if httpClient == nil {
 		httpClient = &http.Client{Timeout: 30 * time.Second}
@@ -52,7 +88,12 @@ func NewClient(baseURL, token string, httpClient *http.Client) *Client {
 		baseURL:    strings.TrimSuffix(baseURL, "/"),
 		token:      token,
 		httpClient: httpClient,
+		useNumber:  true,
+	}
+	for _, opt := range opts {
+		opt(client)
 	}
+	client.httpClient = tuneHTTPClient(client.httpClient, client.transportSettings)
 	client.Monitors = &MonitorService{client: client}
 	client.MonitorGroups = &MonitorGroupService{client: client}
 	client.Heartbeats = &HeartbeatService{client: client}
@@ -70,33 +111,43 @@ func IsNotFound(err error) bool {
 }
 
 func (c *Client) do(ctx context.Context, method, path string, payload any, out any) error {
-	var body io.Reader
+	var encoded []byte
 	if payload != nil {
-		encoded, err := json.Marshal(payload)
+		var err error
+		encoded, err = json.Marshal(payload)
 		if err != nil {
 			return err
 		}
-		body = bytes.NewReader(encoded)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
-	if err != nil {
-		return err
-	}
-
-	req.Header.Set("Accept", "application/json")
-	if payload != nil {
-		req.Header.Set("Content-Type", "application/json")
-	}
-	if c.token != "" {
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
-	}
+	var resp *http.Response
+	for attempt := 0; ; attempt++ {
+		var err error
+		started := time.Now()
+		resp, err = c.send(ctx, method, path, encoded, payload != nil)
+		c.observeRequest(ctx, method, path, resp, err, started)
+		if attempt >= c.maxRetries || !retryable(method, resp, err) {
+			if err != nil {
+				return err
+			}
+			break
+		}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return err
+		delay := c.retryDelay(attempt, resp)
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
 	}
 	defer resp.Body.Close()
+	c.reportWarnings(ctx, method, path, resp)
 
 	if resp.StatusCode >= 400 {
 		return parseAPIError(resp)
@@ -112,8 +163,7 @@ func (c *Client) do(ctx context.Context, method, path string, payload any, out a
 		return nil
 	}
 
-	decoder := json.NewDecoder(resp.Body)
-	if err := decoder.Decode(out); err != nil {
+	if err := decodeJSON(resp.Body, out, c.useNumber); err != nil {
 		if errors.Is(err, io.EOF) {
 			return nil
 		}
@@ -122,6 +172,38 @@ func (c *Client) do(ctx context.Context, method, path string, payload any, out a
 	return nil
 }
 
+func (c *Client) send(ctx context.Context, method, path string, encoded []byte, hasPayload bool) (*http.Response, error) {
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	var body io.Reader
+	if hasPayload {
+		body = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(c.traceContext(ctx), method, c.baseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if hasPayload {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	return resp, classifyConnectionError(err)
+}
+
 func parseAPIError(resp *http.Response) error {
 	data, _ := io.ReadAll(resp.Body)
 
@@ -164,5 +246,35 @@ func parseAPIError(resp *http.Response) error {
 		message = resp.Status
 	}
 
-	return &APIError{StatusCode: resp.StatusCode, Message: message}
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Message:    message,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+	}
+	if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		apiErr.RetryAfter = delay
+	}
+	if remaining, err := strconv.Atoi(resp.Header.Get("RateLimit-Remaining")); err == nil {
+		apiErr.RateLimitRemaining = &remaining
+	}
+	return apiErr
+}
+
+// parseRetryAfter reads a Retry-After value given either in seconds or as an HTTP date. A
+// date in the past yields zero.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	at, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if !at.After(now) {
+		return 0, true
+	}
+	return at.Sub(now), true
 }
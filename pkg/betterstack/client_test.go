@@ -0,0 +1,70 @@
+package betterstack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"loks0n/betterstack-operator/internal/testutil/assert"
+	"loks0n/betterstack-operator/internal/testutil/httpmock"
+)
+
+func TestAPIErrorCapturesResponseHeaders(t *testing.T) {
+	client := NewClient("https://api.test", "token", &http.Client{Transport: httpmock.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.JSONResponse(http.StatusTooManyRequests, `{"errors":[{"detail":"Rate limit exceeded"}]}`)
+		resp.Header.Set("X-Request-Id", "req-123")
+		resp.Header.Set("RateLimit-Remaining", "0")
+		resp.Header.Set("Retry-After", "30")
+		return resp, nil
+	})})
+
+	_, err := client.Monitors.Get(context.Background(), "abc")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got %v", err)
+	}
+	assert.String(t, "request id", apiErr.RequestID, "req-123")
+	assert.EqualPtr(t, "rate limit remaining", apiErr.RateLimitRemaining, 0)
+	assert.Equal(t, "retry after", apiErr.RetryAfter, 30*time.Second)
+	assert.String(t, "error", apiErr.Error(), "better uptime api returned 429: Rate limit exceeded (retry after 30s, request id req-123)")
+}
+
+func TestAPIErrorWithoutResponseHeaders(t *testing.T) {
+	client := NewClient("https://api.test", "token", &http.Client{Transport: httpmock.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return httpmock.JSONResponse(http.StatusUnprocessableEntity, `{"errors":[{"detail":"URL is invalid"}]}`), nil
+	})})
+
+	_, err := client.Monitors.Get(context.Background(), "abc")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got %v", err)
+	}
+	assert.String(t, "request id", apiErr.RequestID, "")
+	if apiErr.RateLimitRemaining != nil {
+		t.Fatalf("expected no rate limit remaining, got %d", *apiErr.RateLimitRemaining)
+	}
+	assert.String(t, "error", apiErr.Error(), "better uptime api returned 422: URL is invalid")
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{value: "", want: 0, ok: false},
+		{value: "15", want: 15 * time.Second, ok: true},
+		{value: "-1", want: 0, ok: false},
+		{value: "Mon, 01 Jan 2024 12:01:00 GMT", want: time.Minute, ok: true},
+		{value: "Mon, 01 Jan 2024 11:00:00 GMT", want: 0, ok: true},
+		{value: "soon", want: 0, ok: false},
+	}
+	for _, tc := range cases {
+		got, ok := parseRetryAfter(tc.value, now)
+		assert.Equal(t, "delay for "+tc.value, got, tc.want)
+		assert.Bool(t, "ok for "+tc.value, ok, tc.ok)
+	}
+}
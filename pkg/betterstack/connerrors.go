@@ -0,0 +1,93 @@
+package betterstack
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+)
+
+// ConnectionErrorKind classifies transport failures talking to Better Stack.
+type ConnectionErrorKind string
+
+const (
+	// ConnectionRefused means the dial was rejected; the request was never sent.
+	ConnectionRefused ConnectionErrorKind = "refused"
+	// ConnectionDNS means the API host could not be resolved; the request was never sent.
+	ConnectionDNS ConnectionErrorKind = "dns"
+	// ConnectionTimeout means dialing or waiting for the response timed out.
+	ConnectionTimeout ConnectionErrorKind = "timeout"
+	// ConnectionReset means the connection was closed or reset mid-request, which some
+	// proxies do when they mishandle keep-alive or HTTP/2 connections.
+	ConnectionReset ConnectionErrorKind = "reset"
+	// ConnectionProtocol means the peer broke the HTTP/2 protocol, for example with an
+	// unexpected GOAWAY or stream reset.
+	ConnectionProtocol ConnectionErrorKind = "protocol"
+	// ConnectionTLS means the TLS handshake or certificate verification failed. Retrying
+	// does not help.
+	ConnectionTLS ConnectionErrorKind = "tls"
+	// ConnectionOther covers any other transport failure.
+	ConnectionOther ConnectionErrorKind = "other"
+)
+
+// ConnectionError wraps a transport failure with its classification.
+type ConnectionError struct {
+	Kind ConnectionErrorKind
+	// Sent reports whether the request may have reached Better Stack. It is false when the
+	// connection could not be established.
+	Sent bool
+	Err  error
+}
+
+func (e *ConnectionError) Error() string {
+	return fmt.Sprintf("better stack connection error (%s): %v", e.Kind, e.Err)
+}
+
+func (e *ConnectionError) Unwrap() error {
+	return e.Err
+}
+
+// classifyConnectionError wraps a transport error from http.Client.Do. Context
+// cancellation is returned unchanged because it is not a connection problem.
+func classifyConnectionError(err error) error {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	var connErr *ConnectionError
+	if errors.As(err, &connErr) {
+		return err
+	}
+
+	var opErr *net.OpError
+	dialFailed := errors.As(err, &opErr) && opErr.Op == "dial"
+
+	var dnsErr *net.DNSError
+	var netErr net.Error
+	var certErr *tls.CertificateVerificationError
+	var recordErr tls.RecordHeaderError
+	var authorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+
+	kind := ConnectionOther
+	switch {
+	case errors.As(err, &dnsErr):
+		kind = ConnectionDNS
+		dialFailed = true
+	case errors.Is(err, syscall.ECONNREFUSED):
+		kind = ConnectionRefused
+	case errors.As(err, &certErr), errors.As(err, &recordErr), errors.As(err, &authorityErr), errors.As(err, &hostnameErr):
+		kind = ConnectionTLS
+	case errors.As(err, &netErr) && netErr.Timeout():
+		kind = ConnectionTimeout
+	case errors.Is(err, syscall.ECONNRESET), errors.Is(err, syscall.EPIPE), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		kind = ConnectionReset
+	case strings.Contains(err.Error(), "http2:"):
+		kind = ConnectionProtocol
+	}
+	return &ConnectionError{Kind: kind, Sent: !dialFailed, Err: err}
+}
@@ -0,0 +1,7 @@
+// Package betterstack is a Go client for the Better Stack Uptime REST API.
+//
+// The package has no dependencies on the operator and can be imported by any Go
+// program. Create a Client with NewClient and use its Monitors, MonitorGroups,
+// Heartbeats and HeartbeatGroups services; behaviour such as retries, rate limiting
+// and the User-Agent header is configured through Option values.
+package betterstack
@@ -0,0 +1,65 @@
+package betterstack_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"loks0n/betterstack-operator/pkg/betterstack"
+)
+
+func ExampleNewClient() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"data":{"id":"123","type":"monitor","attributes":{"url":"https://example.com","status":"up"}}}`)
+	}))
+	defer server.Close()
+
+	client := betterstack.NewClient(server.URL, "api-token", nil,
+		betterstack.WithUserAgent("example/1.0"),
+		betterstack.WithRateLimit(5, 10),
+	)
+
+	monitor, err := client.Monitors.Get(context.Background(), "123")
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	fmt.Println(monitor.ID, monitor.Attributes.URL, monitor.Attributes.Healthy())
+	// Output: 123 https://example.com true
+}
+
+func ExampleWithRetry() {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"data":[],"pagination":{}}`)
+	}))
+	defer server.Close()
+
+	client := betterstack.NewClient(server.URL, "api-token", nil, betterstack.WithRetry(3, 10*time.Millisecond))
+
+	heartbeats, err := client.Heartbeats.List(context.Background())
+	fmt.Println(len(heartbeats), err, attempts)
+	// Output: 0 <nil> 3
+}
+
+func ExampleIsNotFound() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"errors":"Resource not found"}`, http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := betterstack.NewClient(server.URL, "api-token", nil)
+
+	_, err := client.Monitors.Get(context.Background(), "missing")
+	fmt.Println(betterstack.IsNotFound(err))
+	// Output: true
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"strings"
 	"time"
 )
 
@@ -16,7 +15,7 @@ type HeartbeatGroupClient interface {
 	Update(ctx context.Context, id string, req HeartbeatGroupUpdateRequest) (HeartbeatGroup, error)
 	Delete(ctx context.Context, id string) error
 	List(ctx context.Context) ([]HeartbeatGroup, error)
-	ListHeartbeats(ctx context.Context, groupID string) ([]Heartbeat, error)
+	ListHeartbeats(ctx context.Context, groupID string, opts ...ListOption) ([]Heartbeat, error)
 }
 
 // HeartbeatGroupService provides heartbeat group operations for Better Stack.
@@ -117,6 +116,7 @@ func (s *HeartbeatGroupService) Delete(ctx context.Context, id string) error {
 func (s *HeartbeatGroupService) List(ctx context.Context) ([]HeartbeatGroup, error) {
 	path := "/heartbeat-groups"
 	var groups []HeartbeatGroup
+	links := newPager(s.client.baseURL, path)
 
 	for path != "" {
 		var envelope heartbeatGroupListEnvelope
@@ -128,23 +128,25 @@ func (s *HeartbeatGroupService) List(ctx context.Context) ([]HeartbeatGroup, err
 			groups = append(groups, HeartbeatGroup{ID: item.ID, Attributes: item.Attributes})
 		}
 
-		next := strings.TrimSpace(envelope.Pagination.Next)
-		if next == "" {
-			break
+		next, err := links.next(envelope.Pagination.Next)
+		if err != nil {
+			return nil, err
 		}
-		next, _ = strings.CutPrefix(next, s.client.baseURL)
 		path = next
 	}
 
 	return groups, nil
 }
 
-// ListHeartbeats returns all heartbeats belonging to a heartbeat group.
-func (s *HeartbeatGroupService) ListHeartbeats(ctx context.Context, groupID string) ([]Heartbeat, error) {
-	path := fmt.Sprintf("/heartbeat-groups/%s/heartbeats", url.PathEscape(groupID))
+// ListHeartbeats returns the heartbeats belonging to a heartbeat group, following
+// pagination until the last page or the WithMaxPages limit.
+func (s *HeartbeatGroupService) ListHeartbeats(ctx context.Context, groupID string, opts ...ListOption) ([]Heartbeat, error) {
+	settings := newListSettings(opts)
+	path := settings.firstPage(fmt.Sprintf("/heartbeat-groups/%s/heartbeats", url.PathEscape(groupID)))
 	var heartbeats []Heartbeat
+	links := newPager(s.client.baseURL, path)
 
-	for path != "" {
+	for pages := 0; path != ""; {
 		var envelope heartbeatListEnvelope
 		if err := s.client.do(ctx, http.MethodGet, path, nil, &envelope); err != nil {
 			return nil, err
@@ -154,11 +156,14 @@ func (s *HeartbeatGroupService) ListHeartbeats(ctx context.Context, groupID stri
 			heartbeats = append(heartbeats, Heartbeat{ID: item.ID, Attributes: item.Attributes})
 		}
 
-		next := strings.TrimSpace(envelope.Pagination.Next)
-		if next == "" {
+		pages++
+		if settings.done(pages) {
 			break
 		}
-		next, _ = strings.CutPrefix(next, s.client.baseURL)
+		next, err := links.next(envelope.Pagination.Next)
+		if err != nil {
+			return nil, err
+		}
 		path = next
 	}
 
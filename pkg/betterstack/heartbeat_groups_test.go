@@ -137,3 +137,28 @@ func TestHeartbeatGroupServiceListHeartbeats(t *testing.T) {
 	assert.String(t, "first name", heartbeats[0].Attributes.Name, "Primary")
 	assert.String(t, "second name", heartbeats[1].Attributes.Name, "Backup")
 }
+
+func TestHeartbeatGroupServiceListHeartbeatsOptions(t *testing.T) {
+	var paths []string
+	client := NewClient("https://api.test", "token", &http.Client{Transport: httpmock.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		paths = append(paths, req.URL.RequestURI())
+		switch req.URL.RequestURI() {
+		case "/heartbeat-groups/group-1/heartbeats?per_page=1":
+			return httpmock.JSONResponse(http.StatusOK, `{"data":[{"id":"h1","type":"heartbeat","attributes":{"name":"Primary"}}],"pagination":{"next":"https://api.test/heartbeat-groups/group-1/heartbeats?page=2&per_page=1"}}`), nil
+		case "/heartbeat-groups/group-1/heartbeats?page=2&per_page=1":
+			return httpmock.JSONResponse(http.StatusOK, `{"data":[{"id":"h2","type":"heartbeat","attributes":{"name":"Backup"}}],"pagination":{"next":"https://api.test/heartbeat-groups/group-1/heartbeats?page=3&per_page=1"}}`), nil
+		default:
+			t.Fatalf("unexpected path: %s", req.URL.RequestURI())
+		}
+		return nil, nil
+	})})
+
+	heartbeats, err := client.HeartbeatGroups.ListHeartbeats(context.Background(), "group-1", WithPerPage(1), WithMaxPages(2))
+	assert.NoError(t, err, "List heartbeats in group")
+	assert.StringSlice(t, "paths", paths, []string{
+		"/heartbeat-groups/group-1/heartbeats?per_page=1",
+		"/heartbeat-groups/group-1/heartbeats?page=2&per_page=1",
+	})
+	assert.Int(t, "heartbeat count", len(heartbeats), 2)
+	assert.String(t, "second id", heartbeats[1].ID, "h2")
+}
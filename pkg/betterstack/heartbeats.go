@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"strings"
 	"time"
 )
 
@@ -64,6 +63,24 @@ const (
 	HeartbeatStatusDown    HeartbeatStatus = "down"
 )
 
+// IsPaused reports whether Better Stack considers the heartbeat paused.
+func (a HeartbeatAttributes) IsPaused() bool {
+	return a.PausedAt != nil || a.Status == HeartbeatStatusPaused
+}
+
+// Healthy reports whether the heartbeat last checked in on time.
+func (a HeartbeatAttributes) Healthy() bool {
+	return a.Status == HeartbeatStatusUp
+}
+
+// Age returns how long ago the heartbeat was created, or zero when unknown.
+func (a HeartbeatAttributes) Age(now time.Time) time.Duration {
+	if a.CreatedAt == nil {
+		return 0
+	}
+	return now.Sub(*a.CreatedAt)
+}
+
 // HeartbeatCreateRequest describes fields accepted when creating a heartbeat.
 type HeartbeatCreateRequest struct {
 	TeamName            *string  `json:"team_name,omitempty"`
@@ -152,6 +169,7 @@ func (s *HeartbeatService) Delete(ctx context.Context, id string) error {
 func (s *HeartbeatService) List(ctx context.Context) ([]Heartbeat, error) {
 	path := "/heartbeats"
 	var heartbeats []Heartbeat
+	links := newPager(s.client.baseURL, path)
 
 	for path != "" {
 		var envelope heartbeatListEnvelope
@@ -163,13 +181,10 @@ func (s *HeartbeatService) List(ctx context.Context) ([]Heartbeat, error) {
 			heartbeats = append(heartbeats, Heartbeat{ID: item.ID, Attributes: item.Attributes})
 		}
 
-		next := strings.TrimSpace(envelope.Pagination.Next)
-		if next == "" {
-			break
+		next, err := links.next(envelope.Pagination.Next)
+		if err != nil {
+			return nil, err
 		}
-
-		// normalise next path relative to base URL when required
-		next, _ = strings.CutPrefix(next, s.client.baseURL)
 		path = next
 	}
 
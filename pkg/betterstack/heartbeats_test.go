@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"testing"
+	"time"
 
 	"loks0n/betterstack-operator/internal/testutil/assert"
 	"loks0n/betterstack-operator/internal/testutil/httpmock"
@@ -125,3 +126,22 @@ func TestHeartbeatServiceList(t *testing.T) {
 	assert.String(t, "first name", heartbeats[0].Attributes.Name, "Daily")
 	assert.String(t, "second name", heartbeats[1].Attributes.Name, "Weekly")
 }
+
+func TestHeartbeatAttributesDerivedState(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := created.Add(36 * time.Hour)
+
+	up := HeartbeatAttributes{Status: HeartbeatStatusUp, CreatedAt: &created}
+	assert.Bool(t, "up paused", up.IsPaused(), false)
+	assert.Bool(t, "up healthy", up.Healthy(), true)
+	assert.Equal(t, "up age", up.Age(now), 36*time.Hour)
+
+	pausedAt := created.Add(time.Hour)
+	paused := HeartbeatAttributes{Status: HeartbeatStatusDown, PausedAt: &pausedAt}
+	assert.Bool(t, "paused_at paused", paused.IsPaused(), true)
+	assert.Bool(t, "paused_at healthy", paused.Healthy(), false)
+	assert.Equal(t, "unknown age", paused.Age(now), time.Duration(0))
+
+	statusPaused := HeartbeatAttributes{Status: HeartbeatStatusPaused}
+	assert.Bool(t, "status paused", statusPaused.IsPaused(), true)
+}
@@ -0,0 +1,41 @@
+package betterstack
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"maps"
+)
+
+// WithUseNumber controls how numbers in responses are decoded into untyped values, such as
+// a map[string]any destination: as json.Number when enabled (the default), which keeps
+// 64-bit IDs exact, or as float64, which rounds integers above 2^53.
+func WithUseNumber(enabled bool) Option {
+	return func(c *Client) {
+		c.useNumber = enabled
+	}
+}
+
+// decodeJSON decodes the JSON value read from r into out.
+func decodeJSON(r io.Reader, out any, useNumber bool) error {
+	decoder := json.NewDecoder(r)
+	if useNumber {
+		decoder.UseNumber()
+	}
+	return decoder.Decode(out)
+}
+
+// mergeAttributes returns the JSON object data with extra merged in, extra taking
+// precedence. Numbers already in data pass through as json.Number, so integers keep their
+// exact digits instead of round-tripping through float64.
+func mergeAttributes(data []byte, extra map[string]any) ([]byte, error) {
+	if len(extra) == 0 {
+		return data, nil
+	}
+	var payload map[string]any
+	if err := decodeJSON(bytes.NewReader(data), &payload, true); err != nil {
+		return nil, err
+	}
+	maps.Copy(payload, extra)
+	return json.Marshal(payload)
+}
@@ -2,10 +2,10 @@ package betterstack
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
-	"strings"
 	"time"
 )
 
@@ -42,10 +42,21 @@ type MonitorGroupAttributes struct {
 
 // MonitorGroupRequest captures writable monitor group attributes for create and update operations.
 type MonitorGroupRequest struct {
-	TeamName  *string `json:"team_name,omitempty"`
-	Paused    *bool   `json:"paused,omitempty"`
-	Name      *string `json:"name,omitempty"`
-	SortIndex *int    `json:"sort_index,omitempty"`
+	TeamName             *string        `json:"team_name,omitempty"`
+	Paused               *bool          `json:"paused,omitempty"`
+	Name                 *string        `json:"name,omitempty"`
+	SortIndex            *int           `json:"sort_index,omitempty"`
+	AdditionalAttributes map[string]any `json:"-"`
+}
+
+// MarshalJSON ensures additional attributes are merged into the serialized payload.
+func (r MonitorGroupRequest) MarshalJSON() ([]byte, error) {
+	type alias MonitorGroupRequest
+	data, err := json.Marshal(alias(r))
+	if err != nil {
+		return nil, err
+	}
+	return mergeAttributes(data, r.AdditionalAttributes)
 }
 
 // MonitorGroupCreateRequest describes fields accepted when creating a monitor group.
@@ -117,6 +128,7 @@ func (s *MonitorGroupService) Delete(ctx context.Context, id string) error {
 func (s *MonitorGroupService) List(ctx context.Context) ([]MonitorGroup, error) {
 	path := "/monitor-groups"
 	var groups []MonitorGroup
+	links := newPager(s.client.baseURL, path)
 
 	for path != "" {
 		var envelope monitorGroupListEnvelope
@@ -128,11 +140,10 @@ func (s *MonitorGroupService) List(ctx context.Context) ([]MonitorGroup, error)
 			groups = append(groups, MonitorGroup{ID: item.ID, Attributes: item.Attributes})
 		}
 
-		next := strings.TrimSpace(envelope.Pagination.Next)
-		if next == "" {
-			break
+		next, err := links.next(envelope.Pagination.Next)
+		if err != nil {
+			return nil, err
 		}
-		next, _ = strings.CutPrefix(next, s.client.baseURL)
 		path = next
 	}
 
@@ -143,6 +154,7 @@ func (s *MonitorGroupService) List(ctx context.Context) ([]MonitorGroup, error)
 func (s *MonitorGroupService) ListMonitors(ctx context.Context, groupID string) ([]Monitor, error) {
 	path := fmt.Sprintf("/monitor-groups/%s/monitors", url.PathEscape(groupID))
 	var monitors []Monitor
+	links := newPager(s.client.baseURL, path)
 
 	for path != "" {
 		var envelope monitorListEnvelope
@@ -154,11 +166,10 @@ func (s *MonitorGroupService) ListMonitors(ctx context.Context, groupID string)
 			monitors = append(monitors, Monitor{ID: item.ID, Attributes: item.Attributes})
 		}
 
-		next := strings.TrimSpace(envelope.Pagination.Next)
-		if next == "" {
-			break
+		next, err := links.next(envelope.Pagination.Next)
+		if err != nil {
+			return nil, err
 		}
-		next, _ = strings.CutPrefix(next, s.client.baseURL)
 		path = next
 	}
 
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"strings"
 	"testing"
 
 	"loks0n/betterstack-operator/internal/testutil/assert"
@@ -69,6 +70,39 @@ func TestMonitorGroupServiceUpdate(t *testing.T) {
 	assert.String(t, "id", group.ID, "team/group")
 }
 
+func TestMonitorGroupRequestMergesAdditionalAttributes(t *testing.T) {
+	name := "Backend services"
+	req := MonitorGroupRequest{
+		Name: &name,
+		AdditionalAttributes: map[string]any{
+			"name":   "Overridden",
+			"custom": "value",
+		},
+	}
+
+	data, err := json.Marshal(req)
+	assert.NoError(t, err, "marshal request")
+	var payload map[string]any
+	assert.NoError(t, json.Unmarshal(data, &payload), "decode payload")
+	assert.Equal(t, "name", payload["name"], any("Overridden"))
+	assert.Equal(t, "custom", payload["custom"], any("value"))
+	_, ok := payload["AdditionalAttributes"]
+	assert.Bool(t, "raw field serialized", ok, false)
+}
+
+func TestMonitorGroupRequestMergeKeepsLargeIntegers(t *testing.T) {
+	sortIndex := 9007199254740993 // 2^53 + 1, not representable as float64
+	req := MonitorGroupRequest{
+		SortIndex:            &sortIndex,
+		AdditionalAttributes: map[string]any{"custom_id": int64(9223372036854775807)},
+	}
+
+	data, err := json.Marshal(req)
+	assert.NoError(t, err, "marshal request")
+	assert.Bool(t, "sort index digits", strings.Contains(string(data), `"sort_index":9007199254740993`), true)
+	assert.Bool(t, "additional attribute digits", strings.Contains(string(data), `"custom_id":9223372036854775807`), true)
+}
+
 func TestMonitorGroupServiceDelete(t *testing.T) {
 	deleted := false
 	client := NewClient("https://api.test", "token", &http.Client{Transport: httpmock.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
@@ -4,10 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"maps"
 	"net/http"
 	"net/url"
-	"strings"
 	"time"
 )
 
@@ -65,7 +63,8 @@ type MonitorAttributes struct {
 	SSLExpiration        *int              `json:"ssl_expiration"`
 	DomainExpiration     *int              `json:"domain_expiration"`
 	Regions              []string          `json:"regions"`
-	Port                 *string           `json:"port"`
+	Tags                 []string          `json:"tags,omitempty"`
+	Port                 *FlexibleString   `json:"port"`
 	ConfirmationPeriod   int               `json:"confirmation_period"`
 	ExpectedStatusCodes  []int             `json:"expected_status_codes"`
 	MaintenanceDays      []string          `json:"maintenance_days"`
@@ -97,6 +96,26 @@ const (
 	MonitorStatusMaintenance MonitorStatus = "maintenance"
 )
 
+// IsPaused reports whether Better Stack considers the monitor paused. The
+// Paused attribute is authoritative when set; PausedAt and Status cover
+// responses where only the derived state is populated.
+func (a MonitorAttributes) IsPaused() bool {
+	return a.Paused || a.PausedAt != nil || a.Status == MonitorStatusPaused
+}
+
+// Healthy reports whether the monitor's last check succeeded.
+func (a MonitorAttributes) Healthy() bool {
+	return a.Status == MonitorStatusUp
+}
+
+// Age returns how long ago the monitor was created, or zero when unknown.
+func (a MonitorAttributes) Age(now time.Time) time.Duration {
+	if a.CreatedAt == nil {
+		return 0
+	}
+	return now.Sub(*a.CreatedAt)
+}
+
 // MonitorRequestHeader describes an HTTP header to include with a monitor check.
 type MonitorRequestHeader struct {
 	ID      *string `json:"id,omitempty"`
@@ -129,6 +148,7 @@ type MonitorRequest struct {
 	Paused               *bool                  `json:"paused,omitempty"`
 	Port                 *string                `json:"port,omitempty"`
 	Regions              []string               `json:"regions,omitempty"`
+	Tags                 []string               `json:"tags,omitempty"`
 	MonitorGroupID       *string                `json:"monitor_group_id,omitempty"`
 	RecoveryPeriod       *int                   `json:"recovery_period,omitempty"`
 	VerifySSL            *bool                  `json:"verify_ssl,omitempty"`
@@ -157,15 +177,26 @@ func (r MonitorRequest) MarshalJSON() ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	if len(r.AdditionalAttributes) == 0 {
-		return data, nil
+	return mergeAttributes(data, r.AdditionalAttributes)
+}
+
+// FlexibleString is a string attribute that Better Stack sometimes returns as a JSON
+// number, such as a monitor's port.
+type FlexibleString string
+
+// UnmarshalJSON accepts a JSON string or number; numbers keep their literal form.
+func (s *FlexibleString) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*s = FlexibleString(text)
+		return nil
 	}
-	var payload map[string]any
-	if err := json.Unmarshal(data, &payload); err != nil {
-		return nil, err
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return fmt.Errorf("expected a string or number, got %s", data)
 	}
-	maps.Copy(payload, r.AdditionalAttributes)
-	return json.Marshal(payload)
+	*s = FlexibleString(number)
+	return nil
 }
 
 // MonitorCreateRequest describes fields accepted when creating a monitor.
@@ -237,6 +268,7 @@ func (s *MonitorService) Delete(ctx context.Context, id string) error {
 func (s *MonitorService) List(ctx context.Context) ([]Monitor, error) {
 	path := "/monitors"
 	var monitors []Monitor
+	links := newPager(s.client.baseURL, path)
 
 	for path != "" {
 		var envelope monitorListEnvelope
@@ -248,11 +280,10 @@ func (s *MonitorService) List(ctx context.Context) ([]Monitor, error) {
 			monitors = append(monitors, Monitor{ID: item.ID, Attributes: item.Attributes})
 		}
 
-		next := strings.TrimSpace(envelope.Pagination.Next)
-		if next == "" {
-			break
+		next, err := links.next(envelope.Pagination.Next)
+		if err != nil {
+			return nil, err
 		}
-		next, _ = strings.CutPrefix(next, s.client.baseURL)
 		path = next
 	}
 
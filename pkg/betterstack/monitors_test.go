@@ -3,9 +3,11 @@ package betterstack
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"testing"
+	"time"
 
 	"loks0n/betterstack-operator/internal/testutil/assert"
 	"loks0n/betterstack-operator/internal/testutil/httpmock"
@@ -124,6 +126,21 @@ func TestMonitorServiceGet(t *testing.T) {
 	assert.String(t, "url", monitor.Attributes.URL, "https://example.com")
 }
 
+func TestMonitorServiceGetAcceptsNumericPort(t *testing.T) {
+	for _, port := range []string{`"5432"`, `5432`} {
+		client := NewClient("https://api.test", "token", &http.Client{Transport: httpmock.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return httpmock.JSONResponse(http.StatusOK, `{"data":{"id":"1","type":"monitor","attributes":{"monitor_type":"tcp","port":`+port+`}}}`), nil
+		})})
+
+		monitor, err := client.Monitors.Get(context.Background(), "1")
+		assert.NoError(t, err, "GetMonitor with port %s", port)
+		assert.EqualPtr(t, "port", monitor.Attributes.Port, FlexibleString("5432"))
+	}
+
+	var port FlexibleString
+	assert.Error(t, json.Unmarshal([]byte(`true`), &port), "boolean port")
+}
+
 func TestMonitorServiceGetNotFound(t *testing.T) {
 	client := NewClient("https://api.test", "token", &http.Client{Transport: httpmock.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
 		return httpmock.JSONResponse(http.StatusNotFound, `{"errors":"Resource with provided ID was not found"}`), nil
@@ -156,3 +173,52 @@ func TestMonitorServiceList(t *testing.T) {
 	assert.String(t, "first name", monitors[0].Attributes.PronounceableName, "First")
 	assert.String(t, "second url", monitors[1].Attributes.URL, "https://second.example.com")
 }
+
+func TestMonitorServiceListDetectsPaginationLoop(t *testing.T) {
+	var calls int
+	client := NewClient("https://api.test", "token", &http.Client{Transport: httpmock.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		switch req.URL.RequestURI() {
+		case "/monitors":
+			return httpmock.JSONResponse(http.StatusOK, `{"data":[{"id":"1","type":"monitor","attributes":{}}],"pagination":{"next":"https://api.test/monitors?page=2"}}`), nil
+		case "/monitors?page=2":
+			return httpmock.JSONResponse(http.StatusOK, `{"data":[{"id":"2","type":"monitor","attributes":{}}],"pagination":{"next":"https://api.test/monitors?page=2"}}`), nil
+		default:
+			t.Fatalf("unexpected path: %s", req.URL.RequestURI())
+		}
+		return nil, nil
+	})})
+
+	_, err := client.Monitors.List(context.Background())
+	assert.Error(t, err, "expected pagination loop error")
+	assert.String(t, "error", err.Error(), `pagination loop: next link "https://api.test/monitors?page=2" was already requested`)
+	assert.Int(t, "call count", calls, 2)
+}
+
+func TestMonitorServiceListStopsAtPageCap(t *testing.T) {
+	var calls int
+	client := NewClient("https://api.test", "token", &http.Client{Transport: httpmock.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return httpmock.JSONResponse(http.StatusOK, fmt.Sprintf(`{"data":[],"pagination":{"next":"https://api.test/monitors?page=%d"}}`, calls+1)), nil
+	})})
+
+	_, err := client.Monitors.List(context.Background())
+	assert.Error(t, err, "expected page cap error")
+	assert.String(t, "error", err.Error(), fmt.Sprintf("pagination exceeded %d pages", maxListPages))
+	assert.Int(t, "call count", calls, maxListPages)
+}
+
+func TestMonitorAttributesDerivedState(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := created.Add(2 * time.Hour)
+
+	up := MonitorAttributes{Status: MonitorStatusUp, CreatedAt: &created}
+	assert.Bool(t, "up paused", up.IsPaused(), false)
+	assert.Bool(t, "up healthy", up.Healthy(), true)
+	assert.Equal(t, "up age", up.Age(now), 2*time.Hour)
+
+	assert.Bool(t, "paused flag", MonitorAttributes{Paused: true}.IsPaused(), true)
+	assert.Bool(t, "paused status", MonitorAttributes{Status: MonitorStatusPaused}.IsPaused(), true)
+	assert.Bool(t, "paused_at", MonitorAttributes{PausedAt: &created}.IsPaused(), true)
+	assert.Bool(t, "down healthy", MonitorAttributes{Status: MonitorStatusDown}.Healthy(), false)
+}
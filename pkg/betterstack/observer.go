@@ -0,0 +1,38 @@
+package betterstack
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// RequestResult describes one completed attempt of an API request. Retried requests are
+// reported once per attempt.
+type RequestResult struct {
+	Method string
+	Path   string
+	// StatusCode is zero when no response was received.
+	StatusCode int
+	Duration   time.Duration
+	// Err is the transport error, if any. Error responses are reported by StatusCode.
+	Err error
+}
+
+// WithRequestObserver calls fn after every request attempt. The context is the one passed
+// to the service method that issued the request.
+func WithRequestObserver(fn func(context.Context, RequestResult)) Option {
+	return func(c *Client) {
+		c.requestObserver = fn
+	}
+}
+
+func (c *Client) observeRequest(ctx context.Context, method, path string, resp *http.Response, err error, started time.Time) {
+	if c.requestObserver == nil {
+		return
+	}
+	result := RequestResult{Method: method, Path: path, Duration: time.Since(started), Err: err}
+	if resp != nil {
+		result.StatusCode = resp.StatusCode
+	}
+	c.requestObserver(ctx, result)
+}
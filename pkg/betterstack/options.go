@@ -0,0 +1,258 @@
+package betterstack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Option customises a Client constructed by NewClient.
+type Option func(*Client)
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithRetry retries failed requests up to maxRetries times, waiting backoff before the
+// first retry and doubling it on each subsequent attempt. Rate-limited responses (429)
+// are retried for every method and honour Retry-After; server errors and transport
+// failures are only retried for requests that are safe to repeat, so a create is never
+// submitted twice.
+func WithRetry(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		if maxRetries < 0 {
+			maxRetries = 0
+		}
+		c.maxRetries = maxRetries
+		c.retryBackoff = backoff
+	}
+}
+
+// WithRateLimit caps outgoing requests at requestsPerSecond with the given burst. A
+// non-positive rate disables limiting; a non-positive burst defaults to one request.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(c *Client) {
+		if requestsPerSecond <= 0 {
+			c.limiter = nil
+			return
+		}
+		if burst <= 0 {
+			burst = 1
+		}
+		c.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+	}
+}
+
+// WithMaxIdleConnsPerHost sets how many idle keep-alive connections to Better Stack are
+// retained. It applies when the HTTP client uses the default transport or an
+// *http.Transport; custom round trippers are left untouched. Tuned transports are shared
+// between clients with the same settings, so connections are reused across NewClient calls.
+func WithMaxIdleConnsPerHost(n int) Option {
+	return func(c *Client) {
+		c.transportSettings.maxIdleConnsPerHost = n
+	}
+}
+
+// WithIdleConnTimeout sets how long idle keep-alive connections are retained. The same
+// transport rules as WithMaxIdleConnsPerHost apply.
+func WithIdleConnTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.transportSettings.idleConnTimeout = d
+	}
+}
+
+// WithConnectionTrace calls fn whenever a request obtains a connection, reporting whether
+// an idle connection was reused.
+func WithConnectionTrace(fn func(httptrace.GotConnInfo)) Option {
+	return func(c *Client) {
+		c.connTrace = fn
+	}
+}
+
+// HTTPVersion selects the HTTP protocol used to reach Better Stack.
+type HTTPVersion string
+
+const (
+	// HTTPVersionAuto keeps the transport's default negotiation.
+	HTTPVersionAuto HTTPVersion = ""
+	// HTTPVersion1 forces HTTP/1.1, for proxies that mishandle HTTP/2.
+	HTTPVersion1 HTTPVersion = "1.1"
+	// HTTPVersion2 negotiates HTTP/2 even when the transport has a custom TLS config,
+	// falling back to HTTP/1.1 when the server does not offer it.
+	HTTPVersion2 HTTPVersion = "2"
+)
+
+// ParseHTTPVersion parses "auto", "1.1" or "2".
+func ParseHTTPVersion(s string) (HTTPVersion, error) {
+	switch s {
+	case "", "auto":
+		return HTTPVersionAuto, nil
+	case "1", "1.1":
+		return HTTPVersion1, nil
+	case "2":
+		return HTTPVersion2, nil
+	default:
+		return "", fmt.Errorf("unsupported HTTP version %q (want auto, 1.1 or 2)", s)
+	}
+}
+
+// WithHTTPVersion selects the HTTP protocol. The same transport rules as
+// WithMaxIdleConnsPerHost apply.
+func WithHTTPVersion(version HTTPVersion) Option {
+	return func(c *Client) {
+		c.transportSettings.httpVersion = version
+	}
+}
+
+// WithKeepAlive sets the TCP keep-alive period of new connections and, for HTTP/2, how long
+// a connection may be idle before a health-check ping is sent. A negative value disables
+// TCP keep-alives. The same transport rules as WithMaxIdleConnsPerHost apply.
+func WithKeepAlive(d time.Duration) Option {
+	return func(c *Client) {
+		c.transportSettings.keepAlive = d
+	}
+}
+
+type transportSettings struct {
+	maxIdleConnsPerHost int
+	idleConnTimeout     time.Duration
+	httpVersion         HTTPVersion
+	keepAlive           time.Duration
+}
+
+type transportKey struct {
+	base     http.RoundTripper
+	settings transportSettings
+}
+
+var tunedTransports sync.Map
+
+// TuneHTTPClient applies the transport options in opts (WithMaxIdleConnsPerHost,
+// WithIdleConnTimeout, WithHTTPVersion and WithKeepAlive) to client and returns the tuned
+// copy; other options are ignored. It is useful when the client is later wrapped by a
+// custom round tripper, which NewClient cannot tune. A nil client is treated as
+// http.DefaultClient.
+func TuneHTTPClient(client *http.Client, opts ...Option) *http.Client {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	c := &Client{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return tuneHTTPClient(client, c.transportSettings)
+}
+
+// tuneHTTPClient returns a copy of client whose transport carries the settings. The tuned
+// transport is cached per base transport and settings so its connection pool is shared.
+func tuneHTTPClient(client *http.Client, settings transportSettings) *http.Client {
+	if settings == (transportSettings{}) {
+		return client
+	}
+
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	source, ok := base.(*http.Transport)
+	if !ok {
+		return client
+	}
+
+	key := transportKey{base: base, settings: settings}
+	tuned, ok := tunedTransports.Load(key)
+	if !ok {
+		transport := source.Clone()
+		if settings.maxIdleConnsPerHost > 0 {
+			transport.MaxIdleConnsPerHost = settings.maxIdleConnsPerHost
+			if transport.MaxIdleConns > 0 && transport.MaxIdleConns < settings.maxIdleConnsPerHost {
+				transport.MaxIdleConns = settings.maxIdleConnsPerHost
+			}
+		}
+		if settings.idleConnTimeout > 0 {
+			transport.IdleConnTimeout = settings.idleConnTimeout
+		}
+		switch settings.httpVersion {
+		case HTTPVersion1:
+			protocols := new(http.Protocols)
+			protocols.SetHTTP1(true)
+			transport.Protocols = protocols
+			transport.ForceAttemptHTTP2 = false
+		case HTTPVersion2:
+			protocols := new(http.Protocols)
+			protocols.SetHTTP1(true)
+			protocols.SetHTTP2(true)
+			transport.Protocols = protocols
+			transport.ForceAttemptHTTP2 = true
+		}
+		if settings.keepAlive != 0 {
+			dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: settings.keepAlive}
+			transport.DialContext = dialer.DialContext
+			if settings.keepAlive > 0 {
+				http2 := http.HTTP2Config{}
+				if transport.HTTP2 != nil {
+					http2 = *transport.HTTP2
+				}
+				http2.SendPingTimeout = settings.keepAlive
+				transport.HTTP2 = &http2
+			}
+		}
+		tuned, _ = tunedTransports.LoadOrStore(key, transport)
+	}
+
+	copied := *client
+	copied.Transport = tuned.(*http.Transport)
+	return &copied
+}
+
+func (c *Client) traceContext(ctx context.Context) context.Context {
+	if c.connTrace == nil {
+		return ctx
+	}
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{GotConn: c.connTrace})
+}
+
+// retryable reports whether a request that produced resp or err may be sent again.
+// Connection failures before the request was sent are retried for every method; TLS
+// failures and cancellation are never retried.
+func retryable(method string, resp *http.Response, err error) bool {
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if err != nil {
+		var connErr *ConnectionError
+		if !errors.As(err, &connErr) || connErr.Kind == ConnectionTLS {
+			return false
+		}
+		if !connErr.Sent {
+			return true
+		}
+	}
+	if method == http.MethodPost {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	return resp != nil && resp.StatusCode >= http.StatusInternalServerError
+}
+
+// retryDelay returns how long to wait before the given retry attempt (starting at zero).
+func (c *Client) retryDelay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return delay
+		}
+	}
+	return c.retryBackoff << attempt
+}
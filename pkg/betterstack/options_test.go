@@ -0,0 +1,241 @@
+package betterstack
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"syscall"
+	"testing"
+	"time"
+
+	"loks0n/betterstack-operator/internal/testutil/assert"
+	"loks0n/betterstack-operator/internal/testutil/httpmock"
+)
+
+func TestWithUserAgentSetsHeader(t *testing.T) {
+	client := NewClient("https://api.test", "token", &http.Client{Transport: httpmock.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		assert.String(t, "user agent", req.Header.Get("User-Agent"), "operator-test/1.0")
+		return httpmock.JSONResponse(http.StatusOK, `{"data":{"id":"1","type":"monitor","attributes":{}}}`), nil
+	})}, WithUserAgent("operator-test/1.0"))
+
+	_, err := client.Monitors.Get(context.Background(), "1")
+	assert.NoError(t, err, "get monitor")
+}
+
+func TestWithRetryRetriesServerErrors(t *testing.T) {
+	calls := 0
+	client := NewClient("https://api.test", "token", &http.Client{Transport: httpmock.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return httpmock.JSONResponse(http.StatusBadGateway, `{"message":"upstream"}`), nil
+		}
+		return httpmock.JSONResponse(http.StatusOK, `{"data":{"id":"1","type":"monitor","attributes":{}}}`), nil
+	})}, WithRetry(2, time.Millisecond))
+
+	monitor, err := client.Monitors.Get(context.Background(), "1")
+	assert.NoError(t, err, "get monitor")
+	assert.String(t, "monitor id", monitor.ID, "1")
+	assert.Int(t, "calls", calls, 2)
+}
+
+func TestWithRetryDoesNotRepeatCreateOnServerError(t *testing.T) {
+	calls := 0
+	client := NewClient("https://api.test", "token", &http.Client{Transport: httpmock.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return httpmock.JSONResponse(http.StatusInternalServerError, `{"message":"boom"}`), nil
+	})}, WithRetry(3, time.Millisecond))
+
+	_, err := client.Monitors.Create(context.Background(), MonitorCreateRequest{})
+	assert.Error(t, err, "create monitor")
+	assert.Int(t, "calls", calls, 1)
+}
+
+func TestWithRetryRetriesRateLimitedCreate(t *testing.T) {
+	calls := 0
+	client := NewClient("https://api.test", "token", &http.Client{Transport: httpmock.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			resp := httpmock.JSONResponse(http.StatusTooManyRequests, `{"message":"slow down"}`)
+			resp.Header.Set("Retry-After", "0")
+			return resp, nil
+		}
+		assert.String(t, "content type", req.Header.Get("Content-Type"), "application/json")
+		return httpmock.JSONResponse(http.StatusCreated, `{"data":{"id":"new","type":"monitor","attributes":{}}}`), nil
+	})}, WithRetry(1, time.Hour))
+
+	monitor, err := client.Monitors.Create(context.Background(), MonitorCreateRequest{})
+	assert.NoError(t, err, "create monitor")
+	assert.String(t, "monitor id", monitor.ID, "new")
+	assert.Int(t, "calls", calls, 2)
+}
+
+func TestWithRateLimitSpacesRequests(t *testing.T) {
+	client := NewClient("https://api.test", "token", &http.Client{Transport: httpmock.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return httpmock.JSONResponse(http.StatusNoContent, ""), nil
+	})}, WithRateLimit(20, 1))
+
+	start := time.Now()
+	for range 3 {
+		assert.NoError(t, client.Monitors.Delete(context.Background(), "1"), "delete monitor")
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		assert.Failf(t, "expected rate limiting to delay requests, took %s", elapsed)
+	}
+}
+
+func TestTransportOptionsShareTunedTransport(t *testing.T) {
+	base := &http.Client{Transport: http.DefaultTransport.(*http.Transport).Clone()}
+	first := NewClient("https://api.test", "token", base, WithMaxIdleConnsPerHost(32), WithIdleConnTimeout(time.Minute))
+	second := NewClient("https://api.test", "token", base, WithMaxIdleConnsPerHost(32), WithIdleConnTimeout(time.Minute))
+
+	transport, ok := first.httpClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected *http.Transport, got %T", first.httpClient.Transport)
+	}
+	assert.Int(t, "max idle conns per host", transport.MaxIdleConnsPerHost, 32)
+	assert.Equal(t, "idle conn timeout", transport.IdleConnTimeout, time.Minute)
+	if second.httpClient.Transport != transport {
+		t.Fatalf("expected clients with the same settings to share a transport")
+	}
+	if base.Transport == transport {
+		t.Fatalf("expected the caller's transport to be left untouched")
+	}
+}
+
+func TestTransportOptionsLeaveCustomRoundTripper(t *testing.T) {
+	roundTripper := httpmock.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return httpmock.JSONResponse(http.StatusOK, `{}`), nil
+	})
+	client := NewClient("https://api.test", "token", &http.Client{Transport: roundTripper}, WithMaxIdleConnsPerHost(32))
+
+	if _, ok := client.httpClient.Transport.(httpmock.RoundTripFunc); !ok {
+		t.Fatalf("expected custom round tripper to be kept, got %T", client.httpClient.Transport)
+	}
+}
+
+func TestWithConnectionTraceReportsReuse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"id":"1","type":"monitor","attributes":{}}}`))
+	}))
+	defer server.Close()
+
+	var reused []bool
+	client := NewClient(server.URL, "token", server.Client(), WithConnectionTrace(func(info httptrace.GotConnInfo) {
+		reused = append(reused, info.Reused)
+	}))
+
+	for i := 0; i < 2; i++ {
+		_, err := client.Monitors.Get(context.Background(), "1")
+		assert.NoError(t, err, "get monitor")
+	}
+	assert.Int(t, "traced connections", len(reused), 2)
+	assert.Bool(t, "first reused", reused[0], false)
+	assert.Bool(t, "second reused", reused[1], true)
+}
+
+func TestWithRequestObserverReportsEachAttempt(t *testing.T) {
+	calls := 0
+	var results []RequestResult
+	client := NewClient("https://api.test", "token", &http.Client{Transport: httpmock.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return httpmock.JSONResponse(http.StatusBadGateway, `{"message":"upstream"}`), nil
+		}
+		return httpmock.JSONResponse(http.StatusOK, `{"data":{"id":"1","type":"monitor","attributes":{}}}`), nil
+	})}, WithRetry(2, time.Millisecond), WithRequestObserver(func(_ context.Context, result RequestResult) {
+		results = append(results, result)
+	}))
+
+	_, err := client.Monitors.Get(context.Background(), "1")
+	assert.NoError(t, err, "get monitor")
+	assert.Int(t, "observed attempts", len(results), 2)
+	assert.String(t, "method", results[0].Method, http.MethodGet)
+	assert.String(t, "path", results[0].Path, "/monitors/1")
+	assert.Int(t, "first status", results[0].StatusCode, http.StatusBadGateway)
+	assert.Int(t, "second status", results[1].StatusCode, http.StatusOK)
+}
+
+func TestHTTPVersionOptionsConfigureProtocols(t *testing.T) {
+	base := &http.Client{Transport: http.DefaultTransport.(*http.Transport).Clone()}
+
+	http1 := TuneHTTPClient(base, WithHTTPVersion(HTTPVersion1)).Transport.(*http.Transport)
+	assert.Bool(t, "http1 allowed", http1.Protocols.HTTP1(), true)
+	assert.Bool(t, "http2 disabled", http1.Protocols.HTTP2(), false)
+	assert.Bool(t, "force attempt http2", http1.ForceAttemptHTTP2, false)
+
+	http2 := TuneHTTPClient(base, WithHTTPVersion(HTTPVersion2), WithKeepAlive(15*time.Second)).Transport.(*http.Transport)
+	assert.Bool(t, "http2 enabled", http2.Protocols.HTTP2(), true)
+	assert.Bool(t, "http1 fallback", http2.Protocols.HTTP1(), true)
+	assert.NotNil(t, "dialer", http2.DialContext)
+	assert.Equal(t, "ping timeout", http2.HTTP2.SendPingTimeout, 15*time.Second)
+
+	_, err := ParseHTTPVersion("3")
+	assert.Error(t, err, "parse unsupported version")
+}
+
+func TestWithRetryRetriesCreateWhenConnectionRefused(t *testing.T) {
+	calls := 0
+	client := NewClient("https://api.test", "token", &http.Client{Transport: httpmock.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return nil, &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+		}
+		return httpmock.JSONResponse(http.StatusCreated, `{"data":{"id":"new","type":"monitor","attributes":{}}}`), nil
+	})}, WithRetry(1, time.Millisecond))
+
+	monitor, err := client.Monitors.Create(context.Background(), MonitorCreateRequest{})
+	assert.NoError(t, err, "create monitor")
+	assert.String(t, "monitor id", monitor.ID, "new")
+	assert.Int(t, "calls", calls, 2)
+}
+
+func TestWithRetryClassifiesConnectionErrors(t *testing.T) {
+	calls := 0
+	client := NewClient("https://api.test", "token", &http.Client{Transport: httpmock.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		if req.Method == http.MethodPost {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, &tls.CertificateVerificationError{Err: errors.New("unknown authority")}
+	})}, WithRetry(3, time.Millisecond))
+
+	_, err := client.Monitors.Get(context.Background(), "1")
+	var connErr *ConnectionError
+	if !errors.As(err, &connErr) {
+		t.Fatalf("expected *ConnectionError, got %v", err)
+	}
+	assert.Equal(t, "tls kind", connErr.Kind, ConnectionTLS)
+	assert.Int(t, "tls calls", calls, 1)
+
+	calls = 0
+	_, err = client.Monitors.Create(context.Background(), MonitorCreateRequest{})
+	if !errors.As(err, &connErr) {
+		t.Fatalf("expected *ConnectionError, got %v", err)
+	}
+	assert.Equal(t, "reset kind", connErr.Kind, ConnectionReset)
+	assert.Bool(t, "sent", connErr.Sent, true)
+	assert.Int(t, "create calls", calls, 1)
+}
+
+func TestWithUseNumberPreservesLargeIntegers(t *testing.T) {
+	transport := httpmock.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return httpmock.JSONResponse(http.StatusOK, `{"id":9007199254740993}`), nil
+	})
+
+	client := NewClient("https://api.test", "token", &http.Client{Transport: transport})
+	var out map[string]any
+	assert.NoError(t, client.do(context.Background(), http.MethodGet, "/raw", nil, &out), "decode with json.Number")
+	assert.Equal(t, "id", out["id"], any(json.Number("9007199254740993")))
+
+	client = NewClient("https://api.test", "token", &http.Client{Transport: transport}, WithUseNumber(false))
+	out = nil
+	assert.NoError(t, client.do(context.Background(), http.MethodGet, "/raw", nil, &out), "decode with float64")
+	assert.Equal(t, "id", out["id"], any(float64(9007199254740992)))
+}
@@ -0,0 +1,90 @@
+package betterstack
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// maxListPages bounds how many pages a single list call follows, so a pagination bug in
+// the API cannot keep the operator requesting pages forever.
+const maxListPages = 1000
+
+// ListOption tunes a paginated list call.
+type ListOption func(*listSettings)
+
+type listSettings struct {
+	perPage  int
+	maxPages int
+}
+
+// WithPerPage requests pages of n items instead of the API default. Better Stack caps the
+// page size, so larger values still return at most its maximum per page.
+func WithPerPage(n int) ListOption {
+	return func(s *listSettings) {
+		if n > 0 {
+			s.perPage = n
+		}
+	}
+}
+
+// WithMaxPages stops following pagination after n pages. Zero, the default, reads every page.
+func WithMaxPages(n int) ListOption {
+	return func(s *listSettings) {
+		if n > 0 {
+			s.maxPages = n
+		}
+	}
+}
+
+func newListSettings(opts []ListOption) listSettings {
+	var settings listSettings
+	for _, opt := range opts {
+		opt(&settings)
+	}
+	return settings
+}
+
+// firstPage returns path with the per_page query parameter applied. Later pages follow
+// the API's next links, which already carry it.
+func (s listSettings) firstPage(path string) string {
+	if s.perPage == 0 {
+		return path
+	}
+	return path + "?" + url.Values{"per_page": {strconv.Itoa(s.perPage)}}.Encode()
+}
+
+// done reports whether pages pages have been read and no more should be requested.
+func (s listSettings) done(pages int) bool {
+	return s.maxPages > 0 && pages >= s.maxPages
+}
+
+// pager follows the next links of a paginated list, refusing links it has already
+// requested and stopping with an error once maxListPages pages have been read.
+type pager struct {
+	baseURL string
+	seen    map[string]struct{}
+}
+
+func newPager(baseURL, first string) *pager {
+	return &pager{baseURL: baseURL, seen: map[string]struct{}{first: {}}}
+}
+
+// next returns the path of the page linked by next, relative to the client's base URL, or
+// an empty path when there are no more pages.
+func (p *pager) next(next string) (string, error) {
+	next = strings.TrimSpace(next)
+	if next == "" {
+		return "", nil
+	}
+	path, _ := strings.CutPrefix(next, p.baseURL)
+	if _, ok := p.seen[path]; ok {
+		return "", fmt.Errorf("pagination loop: next link %q was already requested", next)
+	}
+	if len(p.seen) >= maxListPages {
+		return "", fmt.Errorf("pagination exceeded %d pages", maxListPages)
+	}
+	p.seen[path] = struct{}{}
+	return path, nil
+}
@@ -0,0 +1,68 @@
+package betterstack
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Warning is a deprecation notice attached to a Better Stack API response, taken from the
+// Warning, Deprecation and Sunset response headers.
+type Warning struct {
+	Method  string
+	Path    string
+	Message string
+	// Sunset is the raw Sunset header value announcing when the endpoint will be removed.
+	Sunset string
+}
+
+// WithWarningHandler calls fn for every deprecation notice returned by the API. The
+// context is the one passed to the service method that issued the request.
+func WithWarningHandler(fn func(context.Context, Warning)) Option {
+	return func(c *Client) {
+		c.warningHandler = fn
+	}
+}
+
+func (c *Client) reportWarnings(ctx context.Context, method, path string, resp *http.Response) {
+	if c.warningHandler == nil || resp == nil {
+		return
+	}
+	for _, warning := range parseWarnings(method, path, resp.Header) {
+		c.warningHandler(ctx, warning)
+	}
+}
+
+func parseWarnings(method, path string, header http.Header) []Warning {
+	sunset := header.Get("Sunset")
+
+	var warnings []Warning
+	for _, value := range header.Values("Warning") {
+		warnings = append(warnings, Warning{Method: method, Path: path, Message: warningText(value), Sunset: sunset})
+	}
+
+	if deprecation := header.Get("Deprecation"); deprecation != "" && len(warnings) == 0 {
+		message := "endpoint is deprecated"
+		if deprecation != "true" {
+			message += " since " + deprecation
+		}
+		warnings = append(warnings, Warning{Method: method, Path: path, Message: message, Sunset: sunset})
+	} else if sunset != "" && len(warnings) == 0 {
+		warnings = append(warnings, Warning{Method: method, Path: path, Message: "endpoint is scheduled for removal", Sunset: sunset})
+	}
+	return warnings
+}
+
+// warningText extracts the quoted text from an RFC 7234 Warning value such as
+// `299 - "Deprecated field"`, falling back to the raw value.
+func warningText(value string) string {
+	start := strings.Index(value, `"`)
+	if start < 0 {
+		return strings.TrimSpace(value)
+	}
+	end := strings.Index(value[start+1:], `"`)
+	if end < 0 {
+		return strings.TrimSpace(value)
+	}
+	return value[start+1 : start+1+end]
+}
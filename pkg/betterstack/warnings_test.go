@@ -0,0 +1,41 @@
+package betterstack
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"loks0n/betterstack-operator/internal/testutil/assert"
+	"loks0n/betterstack-operator/internal/testutil/httpmock"
+)
+
+func TestWithWarningHandlerReportsDeprecationHeaders(t *testing.T) {
+	var warnings []Warning
+	client := NewClient("https://api.test", "token", &http.Client{Transport: httpmock.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.JSONResponse(http.StatusOK, `{"data":{"id":"1","type":"monitor","attributes":{}}}`)
+		resp.Header.Set("Deprecation", "@1735689600")
+		resp.Header.Set("Sunset", "Wed, 01 Jul 2026 00:00:00 GMT")
+		return resp, nil
+	})}, WithWarningHandler(func(_ context.Context, warning Warning) {
+		warnings = append(warnings, warning)
+	}))
+
+	_, err := client.Monitors.Get(context.Background(), "1")
+	assert.NoError(t, err, "get monitor")
+	assert.Int(t, "warnings", len(warnings), 1)
+	assert.String(t, "method", warnings[0].Method, http.MethodGet)
+	assert.String(t, "path", warnings[0].Path, "/monitors/1")
+	assert.String(t, "message", warnings[0].Message, "endpoint is deprecated since @1735689600")
+	assert.String(t, "sunset", warnings[0].Sunset, "Wed, 01 Jul 2026 00:00:00 GMT")
+}
+
+func TestParseWarningsExtractsWarningText(t *testing.T) {
+	header := http.Header{}
+	header.Add("Warning", `299 - "field foo is deprecated" "Wed, 01 Jan 2025 00:00:00 GMT"`)
+	header.Add("Warning", "unquoted notice")
+
+	warnings := parseWarnings(http.MethodPatch, "/monitors/1", header)
+	assert.Int(t, "warnings", len(warnings), 2)
+	assert.String(t, "first", warnings[0].Message, "field foo is deprecated")
+	assert.String(t, "second", warnings[1].Message, "unquoted notice")
+}
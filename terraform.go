@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/controllers"
+)
+
+// runTerraform implements the "terraform" subcommand, which converts between the custom
+// resources and betteruptime Terraform provider resources. "export" renders manifests as
+// HCL; "import" turns a .tf.json configuration into manifests.
+func runTerraform(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, "usage: terraform export|import [flags]")
+		return 2
+	}
+	switch args[0] {
+	case "export":
+		return runTerraformExport(args[1:], stdout, stderr)
+	case "import":
+		return runTerraformImport(args[1:], stdout, stderr)
+	default:
+		fmt.Fprintf(stderr, "unknown terraform command %q\n", args[0])
+		return 2
+	}
+}
+
+func runTerraformExport(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("terraform export", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	file := fs.String("f", "-", "Path to a manifest of BetterStackMonitor, BetterStackHeartbeat and BetterStackMonitorGroup resources (- for stdin).")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	data, err := readManifest(*file)
+	if err != nil {
+		fmt.Fprintf(stderr, "read manifest: %v\n", err)
+		return 1
+	}
+	objects, err := decodeManifests(data)
+	if err != nil {
+		fmt.Fprintf(stderr, "decode manifest: %v\n", err)
+		return 1
+	}
+
+	resources := make([]controllers.TerraformResource, 0, len(objects))
+	for _, obj := range objects {
+		resource, err := controllers.TerraformResourceFor(obj)
+		if err != nil {
+			fmt.Fprintf(stderr, "convert %s: %v\n", obj.GetName(), err)
+			return 1
+		}
+		resources = append(resources, resource)
+	}
+	if err := controllers.WriteTerraformHCL(stdout, resources); err != nil {
+		fmt.Fprintf(stderr, "write terraform: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func runTerraformImport(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("terraform import", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	file := fs.String("f", "-", "Path to a Terraform JSON configuration (.tf.json) with betteruptime resources (- for stdin).")
+	namespace := fs.String("namespace", "default", "Namespace of the generated resources.")
+	secret := fs.String("secret", "betterstack-api", "Name of the secret holding the Better Stack API token.")
+	secretKey := fs.String("secret-key", "token", "Key of the API token within the secret.")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	data, err := readManifest(*file)
+	if err != nil {
+		fmt.Fprintf(stderr, "read configuration: %v\n", err)
+		return 1
+	}
+	tokenRef := corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: *secret},
+		Key:                  *secretKey,
+	}
+	objects, err := controllers.ObjectsFromTerraformJSON(data, *namespace, tokenRef)
+	if err != nil {
+		fmt.Fprintf(stderr, "convert terraform: %v\n", err)
+		return 1
+	}
+
+	for i, obj := range objects {
+		out, err := manifestYAML(obj)
+		if err != nil {
+			fmt.Fprintf(stderr, "encode %s: %v\n", obj.GetName(), err)
+			return 1
+		}
+		if i > 0 {
+			fmt.Fprintln(stdout, "---")
+		}
+		if _, err := stdout.Write(out); err != nil {
+			fmt.Fprintf(stderr, "write manifest: %v\n", err)
+			return 1
+		}
+	}
+	return 0
+}
+
+// manifestYAML renders obj without the status and server-populated metadata.
+func manifestYAML(obj client.Object) ([]byte, error) {
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var manifest map[string]any
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, err
+	}
+	delete(manifest, "status")
+	if meta, ok := manifest["metadata"].(map[string]any); ok {
+		delete(meta, "creationTimestamp")
+	}
+	return yaml.Marshal(manifest)
+}
+
+// decodeManifests decodes every supported resource in a multi-document manifest.
+func decodeManifests(data []byte) ([]client.Object, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	var objects []client.Object
+	for {
+		var raw map[string]any
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return objects, nil
+			}
+			return nil, err
+		}
+		if len(raw) == 0 {
+			continue
+		}
+
+		var obj client.Object
+		switch kind, _ := raw["kind"].(string); kind {
+		case "BetterStackMonitor":
+			obj = &monitoringv1alpha1.BetterStackMonitor{}
+		case "BetterStackHeartbeat":
+			obj = &monitoringv1alpha1.BetterStackHeartbeat{}
+		case "BetterStackMonitorGroup":
+			obj = &monitoringv1alpha1.BetterStackMonitorGroup{}
+		default:
+			return nil, fmt.Errorf("unsupported kind %q", kind)
+		}
+		doc, err := yaml.Marshal(raw)
+		if err != nil {
+			return nil, err
+		}
+		if err := yaml.Unmarshal(doc, obj); err != nil {
+			return nil, err
+		}
+		objects = append(objects, obj)
+	}
+}
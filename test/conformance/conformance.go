@@ -0,0 +1,278 @@
+// Package conformance checks that a Better Stack-compatible API behaves the way the
+// operator's controllers rely on: 404 semantics for missing and deleted resources,
+// pagination links, partial updates and how attributes are normalised on the way back.
+//
+// Run executes the suite against any endpoint, so the same assertions validate the real
+// API and stand-in servers used for testing:
+//
+//	BETTERSTACK_TOKEN=... go test -tags=conformance ./test/conformance
+//
+// BETTERSTACK_BASE_URL selects another endpoint than the public API.
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"k8s.io/utils/ptr"
+
+	"loks0n/betterstack-operator/internal/testutil/assert"
+	"loks0n/betterstack-operator/pkg/betterstack"
+)
+
+// Config selects the endpoint under test.
+type Config struct {
+	// BaseURL of the API, e.g. https://uptime.betterstack.com/api/v2. Empty uses the
+	// public API.
+	BaseURL string
+	// Token authenticates against the API.
+	Token string
+	// Prefix starts the name of every resource the suite creates, so leftovers from an
+	// interrupted run can be found and removed. Defaults to "conformance-".
+	Prefix string
+	// Timeout bounds each API call. Defaults to 30 seconds.
+	Timeout time.Duration
+}
+
+// Run executes every conformance check against the endpoint in cfg. Each check creates
+// its own resources and deletes them when it finishes.
+func Run(t *testing.T, cfg Config) {
+	t.Helper()
+	s := newSuite(cfg)
+
+	t.Run("MissingResources", s.missingResources)
+	t.Run("MonitorLifecycle", s.monitorLifecycle)
+	t.Run("HeartbeatLifecycle", s.heartbeatLifecycle)
+	t.Run("MonitorGroupLifecycle", s.monitorGroupLifecycle)
+	t.Run("Pagination", s.pagination)
+}
+
+type suite struct {
+	client  *betterstack.Client
+	prefix  string
+	timeout time.Duration
+}
+
+func newSuite(cfg Config) *suite {
+	s := &suite{
+		client:  betterstack.NewClient(cfg.BaseURL, cfg.Token, nil),
+		prefix:  cfg.Prefix,
+		timeout: cfg.Timeout,
+	}
+	if s.prefix == "" {
+		s.prefix = "conformance-"
+	}
+	if s.timeout <= 0 {
+		s.timeout = 30 * time.Second
+	}
+	return s
+}
+
+func (s *suite) ctx(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func (s *suite) name(kind string) string {
+	return fmt.Sprintf("%s%s-%d", s.prefix, kind, time.Now().UnixNano())
+}
+
+// missingResources checks that unknown IDs yield 404 errors on read and are treated as
+// already gone on delete, which the finalizers depend on.
+func (s *suite) missingResources(t *testing.T) {
+	const missing = "999999999"
+
+	_, err := s.client.Monitors.Get(s.ctx(t), missing)
+	assert.Bool(t, "monitor get is not found", betterstack.IsNotFound(err), true)
+	_, err = s.client.Heartbeats.Get(s.ctx(t), missing)
+	assert.Bool(t, "heartbeat get is not found", betterstack.IsNotFound(err), true)
+	_, err = s.client.MonitorGroups.Get(s.ctx(t), missing)
+	assert.Bool(t, "monitor group get is not found", betterstack.IsNotFound(err), true)
+
+	assert.NoError(t, s.client.Monitors.Delete(s.ctx(t), missing), "delete missing monitor")
+	assert.NoError(t, s.client.Heartbeats.Delete(s.ctx(t), missing), "delete missing heartbeat")
+	assert.NoError(t, s.client.MonitorGroups.Delete(s.ctx(t), missing), "delete missing monitor group")
+}
+
+func (s *suite) monitorLifecycle(t *testing.T) {
+	name := s.name("monitor")
+	url := "https://example.com/" + name
+
+	created, err := s.client.Monitors.Create(s.ctx(t), betterstack.MonitorCreateRequest{
+		URL:                 ptr.To(url),
+		PronounceableName:   ptr.To(name),
+		MonitorType:         ptr.To("status"),
+		CheckFrequency:      ptr.To(180),
+		ExpectedStatusCodes: []int{200, 204},
+		HTTPMethod:          ptr.To("head"),
+		RequestHeaders:      []betterstack.MonitorRequestHeader{{Name: "X-Conformance", Value: "initial"}},
+		Paused:              ptr.To(true),
+	})
+	assert.NoError(t, err, "create monitor")
+	t.Cleanup(func() { _ = s.client.Monitors.Delete(context.Background(), created.ID) })
+	if created.ID == "" {
+		t.Fatalf("create monitor returned no id")
+	}
+
+	remote, err := s.client.Monitors.Get(s.ctx(t), created.ID)
+	assert.NoError(t, err, "get monitor")
+	attrs := remote.Attributes
+	assert.String(t, "url", attrs.URL, url)
+	assert.String(t, "pronounceable_name", attrs.PronounceableName, name)
+	assert.String(t, "monitor_type", attrs.MonitorType, "status")
+	// Frequencies are sent and returned in seconds.
+	assert.Int(t, "check_frequency", attrs.CheckFrequency, 180)
+	assert.IntSlice(t, "expected_status_codes", attrs.ExpectedStatusCodes, []int{200, 204})
+	// The operator compares methods in lower case.
+	assert.String(t, "http_method", attrs.HTTPMethod, "head")
+	assert.Bool(t, "paused", attrs.Paused, true)
+	assert.Item(t, "request_headers", attrs.RequestHeaders, "X-Conformance", "initial", func(h betterstack.MonitorHeader) (string, string) {
+		return h.Name, h.Value
+	})
+	// Header updates address existing headers by the IDs the API assigns them.
+	for _, header := range attrs.RequestHeaders {
+		if header.ID == "" {
+			t.Fatalf("request header %s has no id", header.Name)
+		}
+	}
+
+	// Updates are partial: fields left out of the request keep their values.
+	_, err = s.client.Monitors.Update(s.ctx(t), created.ID, betterstack.MonitorUpdateRequest{PronounceableName: ptr.To(name + "-updated")})
+	assert.NoError(t, err, "update monitor")
+	remote, err = s.client.Monitors.Get(s.ctx(t), created.ID)
+	assert.NoError(t, err, "get updated monitor")
+	assert.String(t, "updated pronounceable_name", remote.Attributes.PronounceableName, name+"-updated")
+	assert.Int(t, "check_frequency after partial update", remote.Attributes.CheckFrequency, 180)
+	assert.String(t, "url after partial update", remote.Attributes.URL, url)
+
+	monitors, err := s.client.Monitors.List(s.ctx(t))
+	assert.NoError(t, err, "list monitors")
+	assert.Bool(t, "list contains monitor", slices.ContainsFunc(monitors, func(m betterstack.Monitor) bool { return m.ID == created.ID }), true)
+
+	assert.NoError(t, s.client.Monitors.Delete(s.ctx(t), created.ID), "delete monitor")
+	_, err = s.client.Monitors.Get(s.ctx(t), created.ID)
+	assert.Bool(t, "deleted monitor is not found", betterstack.IsNotFound(err), true)
+	assert.NoError(t, s.client.Monitors.Delete(s.ctx(t), created.ID), "delete monitor again")
+}
+
+func (s *suite) heartbeatLifecycle(t *testing.T) {
+	name := s.name("heartbeat")
+
+	created, err := s.client.Heartbeats.Create(s.ctx(t), betterstack.HeartbeatCreateRequest{
+		Name:   ptr.To(name),
+		Period: ptr.To(300),
+		Grace:  ptr.To(60),
+		Paused: ptr.To(true),
+	})
+	assert.NoError(t, err, "create heartbeat")
+	t.Cleanup(func() { _ = s.client.Heartbeats.Delete(context.Background(), created.ID) })
+	if created.ID == "" {
+		t.Fatalf("create heartbeat returned no id")
+	}
+
+	remote, err := s.client.Heartbeats.Get(s.ctx(t), created.ID)
+	assert.NoError(t, err, "get heartbeat")
+	assert.String(t, "name", remote.Attributes.Name, name)
+	assert.Int(t, "period", remote.Attributes.Period, 300)
+	assert.Int(t, "grace", remote.Attributes.Grace, 60)
+	// The ping proxy forwards pings to this URL.
+	if remote.Attributes.URL == "" {
+		t.Fatalf("heartbeat has no ping url")
+	}
+
+	_, err = s.client.Heartbeats.Update(s.ctx(t), created.ID, betterstack.HeartbeatUpdateRequest{Grace: ptr.To(120)})
+	assert.NoError(t, err, "update heartbeat")
+	remote, err = s.client.Heartbeats.Get(s.ctx(t), created.ID)
+	assert.NoError(t, err, "get updated heartbeat")
+	assert.Int(t, "updated grace", remote.Attributes.Grace, 120)
+	assert.Int(t, "period after partial update", remote.Attributes.Period, 300)
+
+	assert.NoError(t, s.client.Heartbeats.Delete(s.ctx(t), created.ID), "delete heartbeat")
+	_, err = s.client.Heartbeats.Get(s.ctx(t), created.ID)
+	assert.Bool(t, "deleted heartbeat is not found", betterstack.IsNotFound(err), true)
+	assert.NoError(t, s.client.Heartbeats.Delete(s.ctx(t), created.ID), "delete heartbeat again")
+}
+
+func (s *suite) monitorGroupLifecycle(t *testing.T) {
+	name := s.name("group")
+
+	created, err := s.client.MonitorGroups.Create(s.ctx(t), betterstack.MonitorGroupCreateRequest{Name: ptr.To(name), SortIndex: ptr.To(7)})
+	assert.NoError(t, err, "create monitor group")
+	t.Cleanup(func() { _ = s.client.MonitorGroups.Delete(context.Background(), created.ID) })
+
+	remote, err := s.client.MonitorGroups.Get(s.ctx(t), created.ID)
+	assert.NoError(t, err, "get monitor group")
+	assert.String(t, "name", remote.Attributes.Name, name)
+	assert.IntPtr(t, "sort_index", remote.Attributes.SortIndex, 7)
+
+	monitor, err := s.client.Monitors.Create(s.ctx(t), betterstack.MonitorCreateRequest{
+		URL:            ptr.To("https://example.com/" + name),
+		MonitorType:    ptr.To("status"),
+		MonitorGroupID: ptr.To(created.ID),
+		Paused:         ptr.To(true),
+	})
+	assert.NoError(t, err, "create grouped monitor")
+	t.Cleanup(func() { _ = s.client.Monitors.Delete(context.Background(), monitor.ID) })
+	// status.monitorGroupID and drift detection compare the group as a number.
+	assert.IntPtr(t, "monitor_group_id", monitor.Attributes.MonitorGroupID, mustAtoi(t, created.ID))
+
+	members, err := s.client.MonitorGroups.ListMonitors(s.ctx(t), created.ID)
+	assert.NoError(t, err, "list group monitors")
+	assert.Bool(t, "group lists monitor", slices.ContainsFunc(members, func(m betterstack.Monitor) bool { return m.ID == monitor.ID }), true)
+
+	assert.NoError(t, s.client.Monitors.Delete(s.ctx(t), monitor.ID), "delete grouped monitor")
+	assert.NoError(t, s.client.MonitorGroups.Delete(s.ctx(t), created.ID), "delete monitor group")
+	_, err = s.client.MonitorGroups.Get(s.ctx(t), created.ID)
+	assert.Bool(t, "deleted monitor group is not found", betterstack.IsNotFound(err), true)
+}
+
+// pagination checks that per_page is honoured and that next links lead through every
+// page, using a heartbeat group small enough to control.
+func (s *suite) pagination(t *testing.T) {
+	name := s.name("paging")
+
+	group, err := s.client.HeartbeatGroups.Create(s.ctx(t), betterstack.HeartbeatGroupCreateRequest{Name: ptr.To(name)})
+	assert.NoError(t, err, "create heartbeat group")
+	t.Cleanup(func() { _ = s.client.HeartbeatGroups.Delete(context.Background(), group.ID) })
+	groupID := mustAtoi(t, group.ID)
+
+	var want []string
+	for i := range 3 {
+		heartbeat, err := s.client.Heartbeats.Create(s.ctx(t), betterstack.HeartbeatCreateRequest{
+			Name:             ptr.To(fmt.Sprintf("%s-%d", name, i)),
+			Period:           ptr.To(300),
+			Grace:            ptr.To(60),
+			HeartbeatGroupID: ptr.To(groupID),
+			Paused:           ptr.To(true),
+		})
+		assert.NoError(t, err, "create heartbeat %d", i)
+		t.Cleanup(func() { _ = s.client.Heartbeats.Delete(context.Background(), heartbeat.ID) })
+		want = append(want, heartbeat.ID)
+	}
+
+	all, err := s.client.HeartbeatGroups.ListHeartbeats(s.ctx(t), group.ID, betterstack.WithPerPage(1))
+	assert.NoError(t, err, "list heartbeats one per page")
+	got := make([]string, 0, len(all))
+	for _, heartbeat := range all {
+		got = append(got, heartbeat.ID)
+	}
+	slices.Sort(got)
+	slices.Sort(want)
+	assert.StringSlice(t, "heartbeats across pages", got, want)
+
+	first, err := s.client.HeartbeatGroups.ListHeartbeats(s.ctx(t), group.ID, betterstack.WithPerPage(1), betterstack.WithMaxPages(1))
+	assert.NoError(t, err, "list first page")
+	assert.Int(t, "heartbeats on first page", len(first), 1)
+}
+
+func mustAtoi(t *testing.T, id string) int {
+	t.Helper()
+	n, err := strconv.Atoi(id)
+	assert.NoError(t, err, "id %q is numeric", id)
+	return n
+}
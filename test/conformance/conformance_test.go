@@ -0,0 +1,20 @@
+//go:build conformance
+
+package conformance
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestConformance(t *testing.T) {
+	token := strings.TrimSpace(os.Getenv("BETTERSTACK_TOKEN"))
+	if token == "" {
+		t.Skip("BETTERSTACK_TOKEN not set; skipping conformance suite")
+	}
+	Run(t, Config{
+		BaseURL: strings.TrimSpace(os.Getenv("BETTERSTACK_BASE_URL")),
+		Token:   token,
+	})
+}
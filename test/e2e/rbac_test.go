@@ -0,0 +1,412 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/config"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	monitoringv1alpha1 "loks0n/betterstack-operator/api/v1alpha1"
+	"loks0n/betterstack-operator/controllers"
+	"loks0n/betterstack-operator/internal/controller/accounts"
+	"loks0n/betterstack-operator/internal/testutil/assert"
+)
+
+const (
+	rbacNamespace      = "betterstack-rbac-e2e"
+	rbacServiceAccount = "betterstack-operator"
+)
+
+// TestOperatorRBAC runs every controller as a service account bound to nothing but
+// config/rbac/role.yaml, against a stand-in Better Stack API, and checks each resource
+// kind reconciles and deletes cleanly. A watch or write added without its RBAC marker
+// and role rule makes the manager fail to sync its caches or leaves a resource unready.
+func TestOperatorRBAC(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping e2e test in short mode")
+	}
+
+	ensureBinary(t, "kind")
+
+	clusterName := os.Getenv("KIND_CLUSTER_NAME")
+	if clusterName == "" {
+		clusterName = fmt.Sprintf("betterstack-rbac-%d", time.Now().UnixNano())
+		createKindCluster(t, clusterName)
+		defer deleteKindCluster(clusterName)
+	}
+
+	rootDir := projectRoot()
+	kubeconfigPath := fetchKubeconfig(t, clusterName)
+	adminCfg, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	assert.NoError(t, err, "build config")
+
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme), "add core scheme")
+	assert.NoError(t, monitoringv1alpha1.AddToScheme(scheme), "add CR scheme")
+
+	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
+
+	admin, err := client.New(adminCfg, client.Options{Scheme: scheme})
+	assert.NoError(t, err, "new admin client")
+
+	crds, err := filepath.Glob(filepath.Join(rootDir, "config", "crd", "bases", "*.yaml"))
+	assert.NoError(t, err, "find CRDs")
+	for _, path := range crds {
+		installCRD(t, adminCfg, path)
+	}
+	installOperatorRBAC(t, admin, filepath.Join(rootDir, "config", "rbac", "role.yaml"))
+
+	api := newFakeBetterStackAPI()
+	defer api.Close()
+
+	mgrCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Caches the role does not allow listing never sync, so fail fast instead of after the
+	// default two minutes.
+	cacheSyncTimeout := 30 * time.Second
+	manager, err := ctrl.NewManager(serviceAccountConfig(adminCfg, rbacNamespace, rbacServiceAccount), ctrl.Options{
+		Scheme:                 scheme,
+		Metrics:                server.Options{BindAddress: "0"},
+		HealthProbeBindAddress: "0",
+		LeaderElection:         false,
+		Controller:             config.Controller{CacheSyncTimeout: cacheSyncTimeout},
+	})
+	assert.NoError(t, err, "new manager")
+	setupAllControllers(t, manager)
+
+	mgrErr := make(chan error, 1)
+	go func() {
+		mgrErr <- manager.Start(mgrCtx)
+	}()
+
+	ctx := context.Background()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: rbacNamespace, Name: "betterstack-credentials"},
+		Data:       map[string][]byte{"api-key": []byte("rbac-e2e-token")},
+	}
+	createOrFail(t, admin, secret)
+	tokenRef := corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name}, Key: "api-key"}
+
+	createOrFail(t, admin, &monitoringv1alpha1.BetterStackCredential{
+		ObjectMeta: metav1.ObjectMeta{Namespace: rbacNamespace, Name: "account"},
+		Spec:       monitoringv1alpha1.BetterStackCredentialSpec{APITokenSecretRef: tokenRef, BaseURL: api.URL},
+	})
+	accountRef := &monitoringv1alpha1.ResourceRef{Name: "account"}
+
+	createOrFail(t, admin, &monitoringv1alpha1.BetterStackAlertRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: rbacNamespace, Name: "everything"},
+		Spec:       monitoringv1alpha1.BetterStackAlertRouteSpec{PolicyID: "rbac-e2e-policy"},
+	})
+
+	resources := []client.Object{
+		&monitoringv1alpha1.BetterStackMonitor{
+			ObjectMeta: metav1.ObjectMeta{Namespace: rbacNamespace, Name: "monitor"},
+			Spec: monitoringv1alpha1.BetterStackMonitorSpec{
+				URL:               "https://rbac-e2e.example.com/health",
+				APITokenSecretRef: tokenRef,
+				BaseURL:           api.URL,
+			},
+		},
+		&monitoringv1alpha1.BetterStackHeartbeat{
+			ObjectMeta: metav1.ObjectMeta{Namespace: rbacNamespace, Name: "heartbeat"},
+			Spec: monitoringv1alpha1.BetterStackHeartbeatSpec{
+				Name:              "rbac-e2e-heartbeat",
+				PeriodSeconds:     300,
+				APITokenSecretRef: tokenRef,
+				AccountRef:        accountRef,
+			},
+		},
+		&monitoringv1alpha1.BetterStackMonitorGroup{
+			ObjectMeta: metav1.ObjectMeta{Namespace: rbacNamespace, Name: "group"},
+			Spec: monitoringv1alpha1.BetterStackMonitorGroupSpec{
+				Name:              "rbac-e2e-group",
+				APITokenSecretRef: tokenRef,
+				BaseURL:           api.URL,
+			},
+		},
+		&monitoringv1alpha1.BetterStackSyncReport{
+			ObjectMeta: metav1.ObjectMeta{Namespace: rbacNamespace, Name: "report"},
+			Spec: monitoringv1alpha1.BetterStackSyncReportSpec{
+				APITokenSecretRef: tokenRef,
+				BaseURL:           api.URL,
+			},
+		},
+	}
+	for _, obj := range resources {
+		createOrFail(t, admin, obj)
+	}
+
+	for _, obj := range resources {
+		t.Run(fmt.Sprintf("%T", obj), func(t *testing.T) {
+			err := waitForReady(ctx, admin, obj)
+			assert.NoError(t, err, "wait for %s to become ready", obj.GetName())
+		})
+	}
+
+	t.Run("alert route status", func(t *testing.T) {
+		err := wait.PollUntilContextTimeout(ctx, 2*time.Second, 2*time.Minute, true, func(ctx context.Context) (bool, error) {
+			route := &monitoringv1alpha1.BetterStackAlertRoute{}
+			if err := admin.Get(ctx, client.ObjectKey{Namespace: rbacNamespace, Name: "everything"}, route); err != nil {
+				return false, err
+			}
+			return route.Status.MonitorCount == 1, nil
+		})
+		assert.NoError(t, err, "wait for alert route status")
+	})
+
+	// Deletion removes the finalizers, which needs update on the finalizers subresources.
+	for _, obj := range resources {
+		t.Run("delete "+obj.GetName(), func(t *testing.T) {
+			assert.NoError(t, admin.Delete(ctx, obj), "delete %s", obj.GetName())
+			err := wait.PollUntilContextTimeout(ctx, 2*time.Second, 2*time.Minute, true, func(ctx context.Context) (bool, error) {
+				err := admin.Get(ctx, client.ObjectKeyFromObject(obj), obj.DeepCopyObject().(client.Object))
+				if errors.IsNotFound(err) {
+					return true, nil
+				}
+				return false, err
+			})
+			assert.NoError(t, err, "wait for %s to be deleted", obj.GetName())
+		})
+	}
+
+	select {
+	case err := <-mgrErr:
+		t.Fatalf("manager stopped: %v", err)
+	default:
+	}
+}
+
+// installOperatorRBAC binds the ClusterRole in rolePath to the service account the
+// manager impersonates. The role is installed under its own name, so a cluster shared
+// with a deployed operator is left alone.
+func installOperatorRBAC(t *testing.T, admin client.Client, rolePath string) {
+	t.Helper()
+	data, err := os.ReadFile(rolePath)
+	assert.NoError(t, err, "read role")
+	obj, _, err := clientgoscheme.Codecs.UniversalDeserializer().Decode(data, nil, nil)
+	assert.NoError(t, err, "decode role")
+	role, ok := obj.(*rbacv1.ClusterRole)
+	assert.Bool(t, "decoded ClusterRole", ok, true)
+
+	roleName := role.Name + "-rbac-e2e"
+	ctx := context.Background()
+	existing := &rbacv1.ClusterRole{}
+	switch err := admin.Get(ctx, client.ObjectKey{Name: roleName}, existing); {
+	case errors.IsNotFound(err):
+		role.Name = roleName
+		assert.NoError(t, admin.Create(ctx, role), "create role")
+	case err != nil:
+		assert.NoError(t, err, "get role")
+	default:
+		existing.Rules = role.Rules
+		assert.NoError(t, admin.Update(ctx, existing), "update role")
+	}
+
+	createOrFail(t, admin, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: rbacNamespace}})
+	createOrFail(t, admin, &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: rbacNamespace, Name: rbacServiceAccount}})
+	createOrFail(t, admin, &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: roleName},
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: roleName},
+		Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Namespace: rbacNamespace, Name: rbacServiceAccount}},
+	})
+}
+
+// serviceAccountConfig returns cfg impersonating the service account, so requests carry
+// only the permissions bound to it.
+func serviceAccountConfig(cfg *rest.Config, namespace, name string) *rest.Config {
+	impersonated := rest.CopyConfig(cfg)
+	impersonated.Impersonate = rest.ImpersonationConfig{UserName: fmt.Sprintf("system:serviceaccount:%s:%s", namespace, name)}
+	return impersonated
+}
+
+// setupAllControllers registers the controllers the way main does, with every optional
+// watch enabled.
+func setupAllControllers(t *testing.T, manager ctrl.Manager) {
+	t.Helper()
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	registry := accounts.NewRegistry()
+
+	assert.NoError(t, (&controllers.BetterStackMonitorReconciler{
+		Client:      manager.GetClient(),
+		Scheme:      manager.GetScheme(),
+		HTTPClient:  httpClient,
+		Accounts:    registry,
+		Recorder:    manager.GetEventRecorderFor("betterstackmonitor-controller"),
+		AlertRoutes: true,
+	}).SetupWithManager(manager), "setup monitor reconciler")
+	assert.NoError(t, (&controllers.BetterStackHeartbeatReconciler{
+		Client:     manager.GetClient(),
+		Scheme:     manager.GetScheme(),
+		HTTPClient: httpClient,
+		Accounts:   registry,
+		Recorder:   manager.GetEventRecorderFor("betterstackheartbeat-controller"),
+	}).SetupWithManager(manager), "setup heartbeat reconciler")
+	assert.NoError(t, (&controllers.BetterStackMonitorGroupReconciler{
+		Client:     manager.GetClient(),
+		Scheme:     manager.GetScheme(),
+		HTTPClient: httpClient,
+		Accounts:   registry,
+		Recorder:   manager.GetEventRecorderFor("betterstackmonitorgroup-controller"),
+	}).SetupWithManager(manager), "setup monitor group reconciler")
+	assert.NoError(t, (&controllers.BetterStackSyncReportReconciler{
+		Client:     manager.GetClient(),
+		Scheme:     manager.GetScheme(),
+		HTTPClient: httpClient,
+		Accounts:   registry,
+	}).SetupWithManager(manager), "setup sync report reconciler")
+	assert.NoError(t, (&controllers.BetterStackAlertRouteReconciler{
+		Client: manager.GetClient(),
+		Scheme: manager.GetScheme(),
+	}).SetupWithManager(manager), "setup alert route reconciler")
+}
+
+func createOrFail(t *testing.T, c client.Client, obj client.Object) {
+	t.Helper()
+	if err := c.Create(context.Background(), obj); err != nil && !errors.IsAlreadyExists(err) {
+		assert.NoError(t, err, "create %T %s", obj, obj.GetName())
+	}
+}
+
+// waitForReady polls obj until its Ready condition is True.
+func waitForReady(ctx context.Context, c client.Client, obj client.Object) error {
+	var last *metav1.Condition
+	err := wait.PollUntilContextTimeout(ctx, 2*time.Second, 2*time.Minute, true, func(ctx context.Context) (bool, error) {
+		latest := obj.DeepCopyObject().(client.Object)
+		if err := c.Get(ctx, client.ObjectKeyFromObject(obj), latest); err != nil {
+			return false, err
+		}
+		var conds []metav1.Condition
+		switch o := latest.(type) {
+		case *monitoringv1alpha1.BetterStackMonitor:
+			conds = o.Status.Conditions
+		case *monitoringv1alpha1.BetterStackHeartbeat:
+			conds = o.Status.Conditions
+		case *monitoringv1alpha1.BetterStackMonitorGroup:
+			conds = o.Status.Conditions
+		case *monitoringv1alpha1.BetterStackSyncReport:
+			conds = o.Status.Conditions
+		}
+		last = findCondition(conds, monitoringv1alpha1.ConditionReady)
+		return last != nil && last.Status == metav1.ConditionTrue, nil
+	})
+	if err != nil && last != nil {
+		return fmt.Errorf("%w: last Ready condition %s: %s", err, last.Reason, last.Message)
+	}
+	return err
+}
+
+// fakeBetterStackAPI is an in-memory stand-in for the Better Stack API: it stores the
+// attributes of created resources and echoes them back, enough for the controllers to
+// reconcile without a real account.
+type fakeBetterStackAPI struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	nextID int
+	// resources maps a collection such as "monitors" to its resources by ID.
+	resources map[string]map[string]map[string]any
+}
+
+func newFakeBetterStackAPI() *fakeBetterStackAPI {
+	api := &fakeBetterStackAPI{resources: map[string]map[string]map[string]any{}}
+	api.Server = httptest.NewServer(http.HandlerFunc(api.serve))
+	return api
+}
+
+func (a *fakeBetterStackAPI) serve(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	collection := parts[0]
+	if a.resources[collection] == nil {
+		a.resources[collection] = map[string]map[string]any{}
+	}
+	items := a.resources[collection]
+
+	var body map[string]any
+	if r.Body != nil {
+		data, _ := io.ReadAll(r.Body)
+		if len(data) > 0 {
+			if err := json.Unmarshal(data, &body); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+	}
+
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		data := make([]map[string]any, 0, len(items))
+		for id, attrs := range items {
+			data = append(data, fakeResource(collection, id, attrs))
+		}
+		writeFakeJSON(w, http.StatusOK, map[string]any{"data": data, "pagination": map[string]any{"next": nil}})
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		a.nextID++
+		id := strconv.Itoa(a.nextID)
+		items[id] = body
+		writeFakeJSON(w, http.StatusCreated, map[string]any{"data": fakeResource(collection, id, body)})
+	case len(parts) == 2:
+		attrs, ok := items[parts[1]]
+		if !ok {
+			http.Error(w, `{"errors":"Resource not found"}`, http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPatch:
+			for k, v := range body {
+				attrs[k] = v
+			}
+		case http.MethodDelete:
+			delete(items, parts[1])
+			w.WriteHeader(http.StatusNoContent)
+			return
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		writeFakeJSON(w, http.StatusOK, map[string]any{"data": fakeResource(collection, parts[1], attrs)})
+	default:
+		// Nested collections such as /monitor-groups/{id}/monitors are always empty.
+		writeFakeJSON(w, http.StatusOK, map[string]any{"data": []any{}, "pagination": map[string]any{"next": nil}})
+	}
+}
+
+func fakeResource(collection, id string, attrs map[string]any) map[string]any {
+	return map[string]any{"id": id, "type": strings.TrimSuffix(collection, "s"), "attributes": attrs}
+}
+
+func writeFakeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}